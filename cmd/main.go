@@ -1,17 +1,34 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"expvar"
 	"log"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/poolcamacho/jobs-service/internal/repository"
 	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/poolcamacho/jobs-service/internal/service/events"
+	"github.com/poolcamacho/jobs-service/internal/service/jobcache"
+	"github.com/poolcamacho/jobs-service/internal/service/matching"
+	"github.com/poolcamacho/jobs-service/internal/service/scheduler"
+	"github.com/poolcamacho/jobs-service/internal/service/webhooks"
 	"github.com/poolcamacho/jobs-service/internal/transport"
+	"github.com/poolcamacho/jobs-service/pkg/cache"
 	"github.com/poolcamacho/jobs-service/pkg/config"
 	"github.com/poolcamacho/jobs-service/pkg/db"
 	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/loginguard"
+	"github.com/poolcamacho/jobs-service/pkg/ratelimit"
+	"github.com/poolcamacho/jobs-service/pkg/requestid"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+	"github.com/poolcamacho/jobs-service/pkg/utils"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/crypto/bcrypt"
 
 	_ "github.com/poolcamacho/jobs-service/docs" // Import Swagger docs
 )
@@ -42,24 +59,197 @@ func main() {
 	// Initialize repository
 	// Create a new instance of JobRepository to interact with the database
 	candidateRepo := repository.NewJobRepository(dbConn)
+	taxonomyRepo := repository.NewTaxonomyRepository(dbConn)
+	skillRepo := repository.NewSkillRepository(dbConn)
+	auditRepo := repository.NewAuditRepository(dbConn)
+	outboxRepo := repository.NewOutboxRepository(dbConn)
+	webhookRepo := repository.NewWebhookRepository(dbConn)
+	idempotencyRepo := repository.NewIdempotencyRepository(dbConn)
+	apiKeyRepo := repository.NewAPIKeyRepository(dbConn)
+	userRepo := repository.NewUserRepository(dbConn)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(dbConn)
 
 	// Initialize service
 	// Create a new instance of JobService to manage business logic
-	candidateService := service.NewJobService(candidateRepo)
+	transactor := repository.NewTransactor(dbConn) // Audits and announces job changes in the same transaction
+	candidateService := service.NewJobService(candidateRepo,
+		service.WithTaxonomyRepository(taxonomyRepo),
+		service.WithSkillRepository(skillRepo),
+		service.WithTransactor(transactor),
+	)
+	taxonomyService := service.NewTaxonomyService(taxonomyRepo)
+	skillService := service.NewSkillService(skillRepo, candidateRepo, service.WithSkillTransactor(transactor))
+	if cfg.JobCacheSize > 0 {
+		// Serve the job listings from memory; job and skill changes invalidate them
+		jobCache := jobcache.NewJobCache(candidateService, cache.NewLRU(cfg.JobCacheSize), jobcache.WithTTL(cfg.JobCacheTTL))
+		expvar.Publish("job_cache", expvar.Func(func() any { return jobCache.Stats() }))
+		candidateService = jobCache
+		skillService = jobCache.WrapSkillService(skillService)
+	}
+	auditService := service.NewAuditService(auditRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	var jwtIssuer string
+	if issuers := splitList(cfg.JWTIssuers); len(issuers) > 0 {
+		jwtIssuer = issuers[0]
+	}
+	passwords, passwordPolicy := newPasswordHashing(cfg)
+	revocations := newRevocationStore(cfg, dbConn)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, passwords, cfg.JWTSecretKey,
+		service.WithPasswordPolicy(passwordPolicy),
+		service.WithLoginGuard(newLoginGuard(cfg, dbConn)),
+		service.WithLoginAudit(auditRepo),
+		service.WithRevocationList(revocations),
+		service.WithAccessTokenTTL(cfg.AccessTokenTTL),
+		service.WithRefreshTokenTTL(cfg.RefreshTokenTTL),
+		service.WithTokenIssuer(jwtIssuer, splitList(cfg.JWTAudiences)),
+	)
+	recommender := matching.NewRecommender(candidateRepo, skillRepo, matching.NewDefaultMatcher())
+
+	// Start background jobs
+	// Permanently remove soft-deleted jobs once they are past the retention period
+	go service.RunJobPurger(context.Background(), candidateService, cfg.JobRetention, cfg.JobPurgeInterval)
+	// Remove the idempotency keys past their TTL
+	go service.RunIdempotencyPurger(context.Background(), idempotencyService, cfg.IdempotencyPurgeInterval)
+	// Remove the refresh tokens past their expiry
+	go service.RunRefreshTokenPurger(context.Background(), authService, cfg.RefreshTokenPurgeInterval)
+	// Deliver the job events written to the outbox to the configured publisher and the webhook subscriptions
+	publishers := []events.EventPublisher{webhooks.NewFanout(webhookRepo)}
+	switch cfg.EventPublisher {
+	case "none": // Webhooks only
+	case "file":
+		filePublisher, file, err := events.NewFilePublisher(cfg.EventFile)
+		if err != nil {
+			log.Fatalf("Failed to open event file: %v", err)
+		}
+		defer file.Close()
+		publishers = append(publishers, filePublisher)
+	default:
+		publishers = append(publishers, events.NewStdoutPublisher())
+	}
+	relay := events.NewRelay(outboxRepo, events.NewMultiPublisher(publishers...), events.WithPollInterval(cfg.OutboxPollInterval))
+	go relay.Run(context.Background())
+	// Send the queued webhook deliveries
+	go webhooks.NewDispatcher(webhookRepo).Run(context.Background())
+	// Publish and close jobs on schedule; replicas elect the one running it through a lease
+	go scheduler.NewScheduler(candidateService, repository.NewLeaseRepository(dbConn),
+		scheduler.WithInterval(cfg.SchedulerInterval)).Run(context.Background())
 
 	// Initialize Gin and routes
 	// Setup the Gin HTTP router
 	r := gin.Default()
-	candidateHandler := transport.NewJobHandler(candidateService)
+	r.Use(requestid.Middleware())
+	verifier := newTokenVerifier(cfg, revocations)
+	apiKeys := transport.NewAPIKeyAuthenticator(apiKeyService)
+	if rateLimit := newRateLimit(cfg, dbConn, verifier, apiKeys); rateLimit != nil {
+		r.Use(rateLimit)
+	}
+	postingProfile := domain.JobPostingProfile{
+		OrganizationName: cfg.HiringOrganization,
+		OrganizationURL:  cfg.HiringOrganizationURL,
+		OrganizationLogo: cfg.HiringOrganizationLogo,
+		CareersURL:       cfg.CareersURL,
+		Locality:         cfg.JobLocality,
+		Region:           cfg.JobRegion,
+		Country:          cfg.JobCountry,
+		Currency:         cfg.SalaryCurrency,
+	}
+	cachePolicy := transport.CachePolicy{Public: cfg.PublicCacheControl, Authenticated: cfg.AuthenticatedCacheControl}
+	candidateHandler := transport.NewJobHandler(candidateService,
+		transport.WithJobPostingProfile(postingProfile),
+		transport.WithCachePolicy(cachePolicy),
+	)
+	taxonomyHandler := transport.NewTaxonomyHandler(taxonomyService)
+	skillHandler := transport.NewSkillHandler(skillService)
+	matchHandler := transport.NewMatchHandler(recommender)
+	auditHandler := transport.NewAuditHandler(auditService)
+	webhookHandler := transport.NewWebhookHandler(webhookService)
+	apiKeyHandler := transport.NewAPIKeyHandler(apiKeyService)
+	authHandler := transport.NewAuthHandler(authService)
+	feedHandler := transport.NewFeedHandler(candidateService, postingProfile,
+		transport.WithFeedCachePolicy(cachePolicy),
+		transport.WithPublicBaseURL(cfg.PublicBaseURL),
+	)
 
 	// Swagger route
 	// Serve Swagger documentation at /swagger/*any
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Register routes
-	// Protected routes requiring authentication
-	r.GET("/jobs", jwtUtil.AuthMiddleware(cfg.JWTSecretKey), candidateHandler.GetJobs)    // Get all jobs
-	r.POST("/jobs", jwtUtil.AuthMiddleware(cfg.JWTSecretKey), candidateHandler.CreateJob) // Add a new candidate
+	// Protected routes requiring a bearer token or an API key
+	auth := jwtUtil.Middleware(verifier, apiKeys)
+	adminOnly := jwtUtil.RequireRole("admin")               // API keys never hold a role
+	canRead := jwtUtil.RequireScope(domain.ScopeJobsRead)   // API keys and tokens with a scope claim need jobs:read
+	canWrite := jwtUtil.RequireScope(domain.ScopeJobsWrite) // API keys and tokens with a scope claim need jobs:write
+	idempotent := transport.Idempotency(idempotencyService) // Replays retries carrying an Idempotency-Key
+
+	r.GET("/jobs", auth, canRead, candidateHandler.GetJobs)                                      // Get all jobs
+	r.POST("/jobs", auth, canWrite, idempotent, candidateHandler.CreateJob)                      // Add a new candidate
+	r.GET("/jobs/recommended", auth, canRead, matchHandler.GetRecommendedJobs)                   // Rank jobs for a candidate
+	r.POST("/jobs/import", auth, canWrite, idempotent, candidateHandler.ImportJobs)              // Bulk import jobs from CSV or NDJSON
+	r.GET("/jobs/export", auth, canRead, candidateHandler.ExportJobs)                            // Download jobs as CSV, NDJSON or XLSX
+	r.GET("/jobs/:id", auth, canRead, candidateHandler.GetJobByID)                               // Get a single job
+	r.PUT("/jobs/:id", auth, canWrite, idempotent, candidateHandler.UpdateJob)                   // Update a job
+	r.DELETE("/jobs/:id", auth, canWrite, idempotent, candidateHandler.DeleteJob)                // Soft-delete a job
+	r.POST("/jobs/:id/restore", auth, adminOnly, idempotent, candidateHandler.RestoreJob)        // Restore a deleted job (admin only)
+	r.PATCH("/jobs/:id/status", auth, canWrite, idempotent, candidateHandler.ChangeJobStatus)    // Change the status of a job
+	r.GET("/jobs/:id/history", auth, canRead, auditHandler.GetJobHistory)                        // List the changes made to a job
+	r.GET("/taxonomies/:kind", auth, canRead, taxonomyHandler.ListTerms)                         // List the terms of a job classification
+	r.GET("/skills", auth, canRead, skillHandler.ListSkills)                                     // List the skills dictionary
+	r.GET("/jobs/:id/skills", auth, canRead, skillHandler.GetJobSkills)                          // List the skills of a job
+	r.PUT("/jobs/:id/skills", auth, canWrite, idempotent, skillHandler.SetJobSkills)             // Replace the skills of a job
+	r.DELETE("/jobs/:id/skills/:skill", auth, canWrite, idempotent, skillHandler.DeleteJobSkill) // Untag a skill from a job
+
+	// Public syndication feeds fetched by the job boards and feed readers
+	r.GET("/feeds/:format", feedHandler.GetFeed)      // Published jobs in a job board XML format
+	r.GET("/jobs/feed.atom", feedHandler.GetAtomFeed) // Newest published jobs as an Atom feed
+	r.GET("/jobs/feed.rss", feedHandler.GetRSSFeed)   // Newest published jobs as an RSS feed
+	if cfg.PublicBaseURL == "" {
+		// Their links need the public URL of the API, which is not taken from the request headers
+		log.Printf("PUBLIC_BASE_URL is not set: /jobs/feed.atom and /jobs/feed.rss answer 503 until it is")
+	}
+
+	// Local accounts; access tokens are signed with JWT_SECRET_KEY, so they need it set
+	if cfg.JWTSecretKey != "" {
+		r.POST("/auth/login", authHandler.Login)     // Exchange credentials for tokens
+		r.POST("/auth/refresh", authHandler.Refresh) // Exchange a refresh token for new tokens
+		if cfg.AuthRegistrationEnabled {
+			r.POST("/auth/register", authHandler.Register) // Create a read-only user account
+		}
+	}
+
+	// Webhook subscriptions of the authenticated user; API keys need jobs:write to change them
+	hooks := r.Group("/webhooks", auth, idempotent)
+	hooks.POST("", canWrite, webhookHandler.CreateSubscription)                              // Subscribe an endpoint to job events
+	hooks.GET("", canRead, webhookHandler.ListSubscriptions)                                 // List own webhook subscriptions
+	hooks.GET("/:id", canRead, webhookHandler.GetSubscription)                               // Get a webhook subscription
+	hooks.PUT("/:id", canWrite, webhookHandler.UpdateSubscription)                           // Update a webhook subscription
+	hooks.DELETE("/:id", canWrite, webhookHandler.DeleteSubscription)                        // Delete a webhook subscription
+	hooks.GET("/:id/deliveries", canRead, webhookHandler.ListDeliveries)                     // List the deliveries of a subscription
+	hooks.POST("/:id/deliveries/:delivery_id/redeliver", canWrite, webhookHandler.Redeliver) // Send a delivery again
+
+	// Admin routes requiring the admin role
+	admin := r.Group("/admin", auth, adminOnly, idempotent)
+	admin.POST("/taxonomies/:kind", taxonomyHandler.CreateTerm)          // Add a classification term
+	admin.DELETE("/taxonomies/:kind/:value", taxonomyHandler.DeleteTerm) // Remove a classification term
+	admin.POST("/skills", skillHandler.CreateSkill)                      // Add a skill to the dictionary
+	admin.POST("/skills/:id/aliases", skillHandler.AddAlias)             // Add an alias to a skill
+	admin.DELETE("/skills/:id", skillHandler.DeleteSkill)                // Remove a skill from the dictionary
+	admin.GET("/audit", auditHandler.SearchAuditLog)                     // Search the audit log
+	admin.GET("/metrics", gin.WrapH(expvar.Handler()))                   // Runtime and job cache counters
+	admin.POST("/api-keys", apiKeyHandler.IssueKey)                      // Issue an API key
+	admin.GET("/api-keys", apiKeyHandler.ListKeys)                       // List the API keys
+	admin.POST("/api-keys/:id/rotate", apiKeyHandler.RotateKey)          // Replace the secret of an API key
+	admin.DELETE("/api-keys/:id", apiKeyHandler.RevokeKey)               // Revoke an API key
+	admin.POST("/users/:id/unlock", authHandler.UnlockUser)              // Lift the login lockout of a user
+	admin.PUT("/users/:id/role", authHandler.SetUserRole)                // Change the role of a user
+	if revocations != nil {
+		revocationHandler := transport.NewRevocationHandler(service.NewRevocationService(revocations, cfg.JWTMaxTokenLifetime,
+			service.WithRefreshTokens(refreshTokenRepo)))
+		admin.POST("/revocations/tokens", revocationHandler.RevokeToken)     // Revoke a token by jti
+		admin.POST("/revocations/subjects", revocationHandler.RevokeSubject) // Revoke the tokens of a user issued so far
+	}
 
 	// Public route
 	// Health check endpoint to verify if the service is running
@@ -72,3 +262,141 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// newRateLimit builds the rate limiting middleware from the configuration, nil when disabled
+func newRateLimit(cfg *config.Config, dbConn *sql.DB, verifier *jwtUtil.Verifier, keys jwtUtil.KeyAuthenticator) gin.HandlerFunc {
+	var store ratelimit.Store
+	switch cfg.RateLimitStore {
+	case "none":
+		return nil
+	case "mysql":
+		store = repository.NewRateLimitRepository(dbConn)
+	default:
+		store = ratelimit.NewMemoryStore()
+	}
+
+	policy := transport.RateLimitPolicy{}
+	if cfg.RateLimitDefault != "" {
+		limit, err := ratelimit.ParseLimit(cfg.RateLimitDefault)
+		if err != nil {
+			log.Fatalf("Invalid RATE_LIMIT_DEFAULT: %v", err)
+		}
+		policy.Default = limit
+	}
+	routes, err := ratelimit.ParseRules(cfg.RateLimitRoutes)
+	if err != nil {
+		log.Fatalf("Invalid RATE_LIMIT_ROUTES: %v", err)
+	}
+	policy.Routes = routes
+	return transport.RateLimit(store, policy, transport.ClientIdentity(verifier, keys))
+}
+
+// newRevocationStore builds the store of the revoked tokens from the configuration, nil when disabled
+func newRevocationStore(cfg *config.Config, dbConn *sql.DB) revocation.Store {
+	switch cfg.TokenRevocationStore {
+	case "none":
+		return nil
+	case "memory":
+		return revocation.NewMemoryStore()
+	default:
+		return repository.NewRevocationRepository(dbConn)
+	}
+}
+
+// newLoginGuard builds the throttling of the failed logins from the configuration, nil when disabled
+func newLoginGuard(cfg *config.Config, dbConn *sql.DB) *loginguard.Guard {
+	var store loginguard.Store
+	switch cfg.LoginGuardStore {
+	case "none":
+		return nil
+	case "memory":
+		store = loginguard.NewMemoryStore()
+	default:
+		store = repository.NewLoginFailureRepository(dbConn)
+	}
+	return loginguard.NewGuard(store, loginguard.Policy{
+		MaxAccountFailures: cfg.LoginMaxAccountFailures,
+		MaxIPFailures:      cfg.LoginMaxIPFailures,
+		LockoutDuration:    cfg.LoginLockoutDuration,
+		FailureWindow:      cfg.LoginFailureWindow,
+		BaseDelay:          cfg.LoginBaseDelay,
+		MaxDelay:           cfg.LoginMaxDelay,
+	})
+}
+
+// newTokenVerifier builds the verifier of the bearer tokens from the configuration
+// The JWKS, when configured, is loaded before serving and refreshed in the background.
+func newTokenVerifier(cfg *config.Config, revocations revocation.Store) *jwtUtil.Verifier {
+	opts := []jwtUtil.VerifierOption{
+		jwtUtil.WithHMACSecret(cfg.JWTSecretKey),
+		jwtUtil.WithIssuer(splitList(cfg.JWTIssuers)...),
+		jwtUtil.WithAudience(splitList(cfg.JWTAudiences)...),
+		jwtUtil.WithLeeway(cfg.JWTLeeway),
+		jwtUtil.WithMaxLifetime(cfg.JWTMaxTokenLifetime),
+		jwtUtil.WithRequiredClaims(splitList(cfg.JWTRequiredClaims)...),
+	}
+	if cfg.JWKSURL != "" {
+		keys, err := jwtUtil.NewKeySet(context.Background(), cfg.JWKSURL, jwtUtil.WithRetention(cfg.JWKSKeyRetention))
+		if err != nil {
+			log.Fatalf("Failed to load JWKS: %v", err)
+		}
+		go keys.Run(context.Background(), cfg.JWKSRefreshInterval)
+		opts = append(opts, jwtUtil.WithKeySet(keys))
+	}
+	if revocations != nil {
+		opts = append(opts, jwtUtil.WithRevocationList(revocations))
+	}
+	if algorithms := splitList(cfg.JWTAlgorithms); algorithms != nil {
+		opts = append(opts, jwtUtil.WithAlgorithms(algorithms...))
+	}
+
+	verifier, err := jwtUtil.NewVerifier(opts...)
+	if err != nil {
+		log.Fatalf("Invalid token verification settings: %v", err)
+	}
+	return verifier
+}
+
+// newPasswordHashing builds the password hasher and the password policy from the configuration
+func newPasswordHashing(cfg *config.Config) (utils.PasswordUtils, *utils.PasswordPolicy) {
+	var passwords utils.PasswordUtils
+	policy := &utils.PasswordPolicy{MinLength: cfg.PasswordMinLength}
+	switch cfg.PasswordHasher {
+	case "bcrypt":
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			log.Fatalf("Invalid BCRYPT_COST: must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		passwords = &utils.DefaultPasswordUtils{Cost: cfg.BcryptCost}
+		policy.MaxLength = 72 // bcrypt ignores the bytes past 72
+	case "argon2id":
+		if cfg.Argon2Memory < 8*cfg.Argon2Parallelism || cfg.Argon2Iterations < 1 || cfg.Argon2Parallelism < 1 || cfg.Argon2Parallelism > 255 {
+			log.Fatalf("Invalid Argon2id parameters: ARGON2_MEMORY=%d ARGON2_ITERATIONS=%d ARGON2_PARALLELISM=%d",
+				cfg.Argon2Memory, cfg.Argon2Iterations, cfg.Argon2Parallelism)
+		}
+		argon := utils.NewArgon2idPasswordUtils()
+		argon.Memory, argon.Iterations, argon.Parallelism = uint32(cfg.Argon2Memory), uint32(cfg.Argon2Iterations), uint8(cfg.Argon2Parallelism)
+		passwords = argon
+		policy.MaxLength = 128
+	default:
+		log.Fatalf("Invalid PASSWORD_HASHER %q: must be argon2id or bcrypt", cfg.PasswordHasher)
+	}
+
+	if cfg.BreachedPasswordsFile != "" {
+		if err := policy.LoadBreachedPasswords(cfg.BreachedPasswordsFile); err != nil {
+			log.Fatalf("Failed to load breached passwords: %v", err)
+		}
+		log.Printf("Loaded %d breached passwords", policy.BreachedCount())
+	}
+	return passwords, policy
+}
+
+// splitList splits a comma separated setting, dropping blanks; nil when empty
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
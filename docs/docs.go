@@ -24,19 +24,78 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/admin/api-keys": {
             "get": {
-                "description": "Returns the health status of the jobs service",
+                "description": "Retrieve every API key, revoked ones included, without their secrets",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "API keys"
                 ],
-                "summary": "Check service health",
+                "summary": "List API keys",
                 "responses": {
                     "200": {
-                        "description": "Service is healthy",
+                        "description": "List of API keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.APIKey"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch API keys",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an API key for a machine client, sent in the X-API-Key header instead of a bearer token.\nThe key is only returned in this response; store it now, it cannot be retrieved again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Issue an API key",
+                "parameters": [
+                    {
+                        "description": "Name, scopes and optional expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API key issued, with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/domain.IssuedAPIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid name, scope or expiry",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to issue API key",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -47,28 +106,228 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs": {
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "description": "Disable an API key immediately, including a previous secret still in its grace period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key revoked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid API key ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or already revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to revoke API key",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys/{id}/rotate": {
+            "post": {
+                "description": "Issue a new secret for an API key, keeping its name and scopes. The previous secret keeps working\nfor the grace period (0 to 168h, default 0) so clients can be updated without downtime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Rotate an API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grace period of the previous secret, as a Go duration (e.g., 1h)",
+                        "name": "grace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key rotated, with its new secret",
+                        "schema": {
+                            "$ref": "#/definitions/domain.IssuedAPIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid API key ID or grace period",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to rotate API key",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit": {
             "get": {
-                "description": "Retrieve a list of all jobs in the system",
+                "description": "Retrieve audit entries filtered by entity, action, actor, request and time range, newest first.\nRequires the admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Audit"
+                ],
+                "summary": "Search the audit log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "job",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "Filter by entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "status_change",
+                            "delete",
+                            "restore",
+                            "login_failed",
+                            "lockout",
+                            "unlock"
+                        ],
+                        "type": "string",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries recorded at or after this instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries recorded before this instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all jobs",
                 "responses": {
                     "200": {
-                        "description": "List of jobs",
+                        "description": "Matching audit entries",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Job"
+                                "$ref": "#/definitions/domain.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch jobs",
+                        "description": "Failed to fetch audit log",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -77,9 +336,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/revocations/subjects": {
             "post": {
-                "description": "Add a new job by providing title, description, and salary range",
+                "description": "Reject the tokens of the subject issued at or before the cutoff time (now by default), e.g. after\na password change or a compromised account. Tokens issued afterwards keep working.",
                 "consumes": [
                     "application/json"
                 ],
@@ -87,32 +348,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Revocations"
                 ],
-                "summary": "Create a new job",
+                "summary": "Revoke the tokens of a subject",
                 "parameters": [
                     {
-                        "description": "Job Creation Request",
+                        "description": "The subject and the cutoff time",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Job"
+                            "$ref": "#/definitions/domain.SubjectRevocationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Job created successfully",
+                        "description": "Tokens revoked",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubjectRevocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subject or cutoff time",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to revoke tokens",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/admin/revocations/tokens": {
+            "post": {
+                "description": "Reject the token with the given jti from now on, until it expires. Pass the exp of the\ntoken as expires_at when known; otherwise the revocation is kept for the longest token lifetime.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revocations"
+                ],
+                "summary": "Revoke a token",
+                "parameters": [
+                    {
+                        "description": "The jti of the token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenRevocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Token revoked",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenRevocation"
+                        }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid jti or expiry",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -121,7 +431,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to create job",
+                        "description": "Failed to revoke token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -131,34 +441,3348 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Job": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "Creation timestamp",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "Job description",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Job ID",
-                    "type": "integer"
-                },
-                "salary_range": {
-                    "description": "Salary range",
-                    "type": "string"
-                },
-                "title": {
-                    "description": "Job title",
-                    "type": "string"
-                },
-                "updated_at": {
-                    "description": "Last update timestamp",
+        },
+        "/admin/skills": {
+            "post": {
+                "description": "Add a canonical skill and its aliases to the dictionary. Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Create a skill",
+                "parameters": [
+                    {
+                        "description": "Skill Creation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Skill"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Skill created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Skill"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Skill or alias already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create skill",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/skills/{id}": {
+            "delete": {
+                "description": "Remove a skill from the dictionary and from every job tagged with it. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Delete a skill",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skill deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid skill ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Skill not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete skill",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/skills/{id}/aliases": {
+            "post": {
+                "description": "Add an alternative spelling that resolves to the skill (e.g., \"golang\" for \"Go\"). Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Add a skill alias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias Creation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transport.aliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Alias created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Skill not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Alias already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add alias",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/taxonomies/{kind}": {
+            "post": {
+                "description": "Add an allowed value to a job classification. Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "Create a taxonomy term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Taxonomy kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Taxonomy Term Creation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TaxonomyTerm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Term created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TaxonomyTerm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Term already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create taxonomy term",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/taxonomies/{kind}/{value}": {
+            "delete": {
+                "description": "Remove an allowed value from a job classification. Jobs already using it are left untouched. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "Delete a taxonomy term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Taxonomy kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Term value",
+                        "name": "value",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Term deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown taxonomy kind",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete taxonomy term",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "description": "Set the role of a user: \"user\" (read only), \"recruiter\" (reads and changes jobs) or \"admin\".\nThe access tokens already issued keep their scopes; the new role applies from the next login or refresh.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Change the role of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User with the new role",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to change the role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "description": "Clear the failed logins of a user, lifting their lockout and delays. Failures counted for IPs are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Unlock a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unlocked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unlock user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Exchange an email and password for an access token and a refresh token. After failed attempts,\nfurther ones on the same account are delayed progressively and then locked out for a while;\nan IP failing on many accounts is locked out as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Email and password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AuthTokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, retry after the Retry-After seconds",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to log in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token\nworks once; reusing one revokes every token descending from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "The refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AuthTokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired, revoked or reused refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to refresh tokens",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a user account with the \"user\" role, which can read jobs but not change them until an admin\ngrants another role. Log in afterwards to get tokens. An email that is already registered gets the\nsame answer and creates nothing, so the answer does not reveal which emails exist.\nOnly served when AUTH_REGISTRATION_ENABLED is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register a user",
+                "parameters": [
+                    {
+                        "description": "Username, email and password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Registration received",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid username, email or password",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to register user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/{format}": {
+            "get": {
+                "description": "Syndicate the published jobs in the XML format of a job board (indeed or linkedin).\nThe response carries an ETag; send it back in If-None-Match to get a 304 when nothing changed.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get a job board feed",
+                "parameters": [
+                    {
+                        "enum": [
+                            "indeed.xml",
+                            "linkedin.xml"
+                        ],
+                        "type": "string",
+                        "description": "Feed file",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "The feed has not changed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown feed format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate feed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the jobs service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Check service health",
+                "responses": {
+                    "200": {
+                        "description": "Service is healthy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "Retrieve a list of jobs, optionally filtered by classification.\nWith facets=true the response also holds the job count per classification value.\nThe response carries a weak ETag for conditional requests. It has no Last-Modified date, as a job\nleaving the listing (e.g., deleted or closed) would not move it forward.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get all jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by employment type",
+                        "name": "employment_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by seniority level",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "closed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by skills (names or aliases, repeated or comma separated)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether jobs must have any (default) or all of the skills",
+                        "name": "skill_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include facet counts in the response",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted jobs (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched listing",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of jobs (a domain.JobList object when facets=true)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Job"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak validator of the listing"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The listing has not changed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted requires the admin role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new job by providing title, description, salary range and optional classification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Create a new job",
+                "parameters": [
+                    {
+                        "description": "Job Creation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Job"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Job created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request or unknown classification value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/export": {
+            "get": {
+                "description": "Download the jobs matching the listing filters as CSV, NDJSON or XLSX, in ID order.\nRows are streamed as they are read. In CSV, values starting with =, +, - or @ are prefixed\nwith a quote so spreadsheets do not evaluate them.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Export jobs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns (default id,title,description,salary_range,employment_type,seniority,department,status,publish_at,expires_at,created_at,updated_at; deleted_at is also available)",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by employment type",
+                        "name": "employment_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by seniority level",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "closed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by skills (names or aliases, repeated or comma separated)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether jobs must have any (default) or all of the skills",
+                        "name": "skill_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also export soft-deleted jobs (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The exported jobs",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format, columns or filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted requires the admin role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/feed.atom": {
+            "get": {
+                "description": "Atom feed of the newest published jobs matching the listing filters, for feed readers and bots.\nEntries are published at the job creation and updated at its last modification.",
+                "produces": [
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get the Atom feed of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by employment type",
+                        "name": "employment_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by seniority level",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by skills (names or aliases, repeated or comma separated)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether jobs must have any (default) or all of the skills",
+                        "name": "skill_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "The feed has not changed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate feed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "The public URL of the API is not configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/feed.rss": {
+            "get": {
+                "description": "RSS 2.0 feed of the newest published jobs matching the listing filters, for feed readers and bots.",
+                "produces": [
+                    "application/rss+xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get the RSS feed of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by employment type",
+                        "name": "employment_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by seniority level",
+                        "name": "seniority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by skills (names or aliases, repeated or comma separated)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether jobs must have any (default) or all of the skills",
+                        "name": "skill_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "The feed has not changed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate feed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "The public URL of the API is not configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/import": {
+            "post": {
+                "description": "Create jobs from a CSV file (header row with title, description, salary_range, employment_type,\nseniority, department and status columns) or an NDJSON file (one job object per line).\nEvery row is validated and jobs are only created when all rows are valid. With dry_run=true\nnothing is written. The format is taken from the format parameter or the Content-Type header.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Import jobs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jobs imported, or dry run report",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported format or unreadable file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid; nothing was imported",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/recommended": {
+            "get": {
+                "description": "Rank jobs by how well they fit a candidate. Each result carries its overall score\nand the breakdown of the factors (skills, term overlap, salary, seniority, location) that could be evaluated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get recommended jobs",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Candidate skills (names or aliases)",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Extra terms to look for in job titles and descriptions",
+                        "name": "keywords",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Years of professional experience",
+                        "name": "experience_years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expected salary",
+                        "name": "desired_salary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of jobs (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ranked jobs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.JobMatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to recommend jobs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Retrieve a job by its ID.\nWith Accept: application/ld+json the job is rendered as a schema.org JobPosting.\nThe ETag header holds the job version, to send back in If-Match when updating it; the JSON-LD\nrepresentation has a weak ETag of its own instead.\nSend it in If-None-Match (or Last-Modified in If-Modified-Since) to get a 304 when the job has not changed.",
+                "produces": [
+                    "application/json",
+                    "application/ld+json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also look up soft-deleted jobs (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a previously fetched version",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The job (a domain.JobPosting for application/ld+json)",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Job"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the job (weak for JSON-LD)"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Last update of the job"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The job has not changed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "include_deleted requires the admin role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the fields of an existing job; classification values must exist in their vocabularies.\nIf-Match must hold the ETag returned by GET /jobs/{id}; the update is rejected with 412 if the job\nchanged since, so concurrent edits do not overwrite each other. \"*\" updates whatever the version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Update a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the job version being edited",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Job Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Job"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request or unknown classification value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "The job was modified since it was read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete a job. It disappears from listings and lookups but is kept, and can be restored,\nuntil the retention period expires.\nIf-Match must hold the ETag returned by GET /jobs/{id}; the deletion is rejected with 412 if the job\nchanged since. \"*\" deletes whatever the version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Delete a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the job version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "The job was modified since it was read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/history": {
+            "get": {
+                "description": "Retrieve who changed a job, when and what, newest first. Deleted jobs keep their history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Get the history of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit entries of the job",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch job history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted job that has not been purged yet. Requires the admin role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Restore a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job restored successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restore job",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/skills": {
+            "get": {
+                "description": "Retrieve the required and nice-to-have skills of a job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "List job skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of job skills",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.JobSkill"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch job skills",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the skills of a job. Names and aliases are resolved to canonical skills; unknown skills are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Replace job skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Job Skills",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.JobSkill"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stored job skills",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.JobSkill"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request or unknown skill",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update job skills",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/skills/{skill}": {
+            "delete": {
+                "description": "Untag a skill, given by name or alias, from a job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Remove a job skill",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Skill name or alias",
+                        "name": "skill",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skill removed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found or not tagged with the skill",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove job skill",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/status": {
+            "patch": {
+                "description": "Set the publication status of a job. The change is recorded in the audit log.\nIf-Match must hold the ETag returned by GET /jobs/{id}; the change is rejected with 412 if the job\nchanged since. \"*\" changes the status whatever the version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Change the status of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the job version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.JobStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job status changed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID or status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "The job was modified since it was read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to change job status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/skills": {
+            "get": {
+                "description": "Retrieve every skill of the dictionary with its aliases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "List skills",
+                "responses": {
+                    "200": {
+                        "description": "List of skills",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Skill"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch skills",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomies/{kind}": {
+            "get": {
+                "description": "Retrieve the allowed values of a job classification (employment_type, seniority or department)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomies"
+                ],
+                "summary": "List taxonomy terms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Taxonomy kind",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of terms",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TaxonomyTerm"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown taxonomy kind",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch taxonomy terms",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Retrieve the webhook subscriptions created by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "List of subscriptions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch subscriptions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register an endpoint notified of job events. Requests are signed with HMAC-SHA256 using the\nreturned secret, which is only shown once: X-Webhook-Signature is \"sha256=\" followed by the hex\nHMAC of the X-Webhook-Timestamp value, a dot and the raw body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Endpoint and event types",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription created, with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or event type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Retrieve a webhook subscription of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The subscription",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the endpoint, event types or active flag of a subscription. The secret is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint and event types",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated subscription",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID, URL or event type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a webhook subscription and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete subscription",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Retrieve the deliveries of a subscription, newest first, with their status, attempts and last error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery log",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch deliveries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Queue a delivery again, including dead-lettered ones, with a fresh retry schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription or delivery not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to queue delivery",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.APIKey": {
+            "description": "API key of an integration; the secret key itself is only returned when issued or rotated.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "Subject of the admin who issued the key",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "When the key stops working, nil if never",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "API key ID",
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "description": "Last authenticated request, to the minute",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Label of the client (e.g., \"Greenhouse sync\")",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Public part of the key, to recognise it in logs",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "When the key was revoked, nil while valid",
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "description": "Last rotation, nil if never rotated",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Granted scopes (jobs:read, jobs:write)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.APIKeyRequest": {
+            "description": "Name, scopes and optional expiry of a new API key.",
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "Optional expiry (RFC 3339)",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Label of the client",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes to grant (jobs:read, jobs:write)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.AuditEntry": {
+            "description": "Who changed what and when, with the entity snapshots before and after the change.",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What happened (create, update, status_change, delete, restore, login_failed, lockout, unlock)",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "Subject of the token that made the change",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "Entity snapshot after the change, null on delete",
+                    "type": "object"
+                },
+                "before": {
+                    "description": "Entity snapshot before the change, null on create",
+                    "type": "object"
+                },
+                "changes": {
+                    "description": "Fields that differ between Before and After",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "description": "When the change was recorded",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "ID of the changed entity",
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "description": "Type of the changed entity (\"job\" or \"user\")",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Entry ID",
+                    "type": "integer"
+                },
+                "request_id": {
+                    "description": "ID of the HTTP request that made the change",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AuthTokens": {
+            "description": "A short-lived access token for the Authorization header and a refresh token to renew it.",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "JWT to send as \"Authorization: Bearer \u003ctoken\u003e\"",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "Lifetime of the access token in seconds",
+                    "type": "integer"
+                },
+                "refresh_expires_in": {
+                    "description": "Lifetime of the refresh token in seconds",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "Opaque token for POST /auth/refresh",
+                    "type": "string"
+                },
+                "token_type": {
+                    "description": "Always \"Bearer\"",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FieldChange": {
+            "description": "Value of a field before and after a change.",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Value before the change"
+                },
+                "to": {
+                    "description": "Value after the change"
+                }
+            }
+        },
+        "domain.ImportReport": {
+            "description": "Outcome of a job import. Rows are only imported when every row is valid.",
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "True if nothing was written",
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Rejected rows, capped at the first 100",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "description": "Number of jobs created",
+                    "type": "integer"
+                },
+                "invalid": {
+                    "description": "Number of rejected rows",
+                    "type": "integer"
+                },
+                "job_ids": {
+                    "description": "IDs of the created jobs, in row order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "description": "Number of data rows read",
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Number of rows that passed validation",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportRowError": {
+            "description": "Row number (1-based, the CSV header being row 1) and the reason it was rejected.",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Offending field, empty when the whole row is malformed",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Why the row was rejected",
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Line of the row in the uploaded file",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.IssuedAPIKey": {
+            "description": "A new API key; store Key now, it cannot be retrieved again.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "Subject of the admin who issued the key",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "When the key stops working, nil if never",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "API key ID",
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "The secret key, sent in the X-API-Key header",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "Last authenticated request, to the minute",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Label of the client (e.g., \"Greenhouse sync\")",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Public part of the key, to recognise it in logs",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "When the key was revoked, nil while valid",
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "description": "Last rotation, nil if never rotated",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Granted scopes (jobs:read, jobs:write)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.Job": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Soft deletion timestamp, nil while the job is live",
+                    "type": "string"
+                },
+                "department": {
+                    "description": "Department the position belongs to",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Job description",
+                    "type": "string"
+                },
+                "employment_type": {
+                    "description": "Employment type (e.g., full-time, contract)",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "When the job is closed automatically",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Job ID",
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "description": "When a draft goes live; set in the future to schedule the publication",
+                    "type": "string"
+                },
+                "salary_range": {
+                    "description": "Salary range",
+                    "type": "string"
+                },
+                "seniority": {
+                    "description": "Seniority level (e.g., junior, senior)",
+                    "type": "string"
+                },
+                "skills": {
+                    "description": "Skills tagged on the job; managed through /jobs/{id}/skills",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.JobSkill"
+                    }
+                },
+                "status": {
+                    "description": "Publication status (draft, published, closed)",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Job title",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Last update timestamp",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Incremented on every change; sent as the ETag of the job",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.JobMatch": {
+            "description": "A recommended job with its overall score and per-factor breakdown.",
+            "type": "object",
+            "properties": {
+                "factors": {
+                    "description": "Criteria that could be evaluated for this job",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MatchFactor"
+                    }
+                },
+                "job": {
+                    "description": "The recommended job",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Job"
+                        }
+                    ]
+                },
+                "score": {
+                    "description": "Weighted score between 0 and 1",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.JobSkill": {
+            "description": "A skill required by, or nice to have for, a job.",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Skill name; aliases are accepted on input",
+                    "type": "string"
+                },
+                "requirement": {
+                    "description": "\"required\" (default) or \"nice_to_have\"",
+                    "type": "string"
+                },
+                "skill_id": {
+                    "description": "Skill ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.JobStatusRequest": {
+            "description": "The request body for moving a job between draft, published and closed.",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "The new status",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LoginRequest": {
+            "description": "The request body for logging in a user.",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email address of the user",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MatchFactor": {
+            "description": "Score of one matching criterion, between 0 and 1, and the weight it carries.",
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "Human readable explanation of the score",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Criterion name (e.g., \"skills\", \"salary\")",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Criterion score between 0 and 1",
+                    "type": "number"
+                },
+                "weight": {
+                    "description": "Relative weight of the criterion",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.RefreshRequest": {
+            "description": "The refresh token returned by the last login or refresh.",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "The refresh token to exchange",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RegisterRequest": {
+            "description": "The request body for registering a new user.",
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email address of the new user",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password for the new user",
+                    "type": "string",
+                    "minLength": 8
+                },
+                "username": {
+                    "description": "Username of the new user",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Skill": {
+            "description": "A canonical skill name (e.g., \"Go\") with the aliases that resolve to it (e.g., \"golang\").",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "description": "Alternative spellings resolved to the canonical name",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Skill ID",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Canonical skill name",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SubjectRevocation": {
+            "description": "Tokens of the subject issued at or before RevokedBefore are rejected until ExpiresAt.",
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "When the revocation is forgotten, as the tokens it covers have expired",
+                    "type": "string"
+                },
+                "revoked_before": {
+                    "description": "Tokens issued at or before this time are revoked",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "The subject of the tokens",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SubjectRevocationRequest": {
+            "description": "The subject and the cutoff time; tokens issued at or before it are revoked.",
+            "type": "object",
+            "required": [
+                "subject"
+            ],
+            "properties": {
+                "before": {
+                    "description": "Cutoff time; defaults to now",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "The subject of the tokens (e.g., a user ID)",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TaxonomyTerm": {
+            "description": "A controlled vocabulary entry (e.g., \"full-time\" for employment_type).",
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Term ID",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Vocabulary the term belongs to",
+                    "type": "string"
+                },
+                "label": {
+                    "description": "Human readable label",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Machine value stored on jobs",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TokenRevocation": {
+            "description": "A token rejected until it expires.",
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "When the revocation is forgotten, as the token has expired",
+                    "type": "string"
+                },
+                "jti": {
+                    "description": "The \"jti\" claim of the token",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TokenRevocationRequest": {
+            "description": "The jti of the token and, if known, its expiry.",
+            "type": "object",
+            "required": [
+                "jti"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "The \"exp\" claim of the token; defaults to the longest token lifetime",
+                    "type": "string"
+                },
+                "jti": {
+                    "description": "The \"jti\" claim of the token",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.User": {
+            "description": "Represents a user entity in the system with all associated details.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the user was created",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "User's email",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "User ID",
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "User's role (e.g., admin, user)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the user was last updated",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "User's username",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserRoleRequest": {
+            "description": "The new role of the user (user, recruiter or admin).",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "The new role",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WebhookDelivery": {
+            "description": "Delivery log entry of a webhook subscription.",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Number of attempts made",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "When the event was queued",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "When the receiver acknowledged the event",
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "ID of the delivered event",
+                    "type": "string"
+                },
+                "event_type": {
+                    "description": "Type of the delivered event",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Delivery ID, sent in the X-Webhook-Delivery header",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "Error of the last failed attempt",
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "description": "HTTP status of the last attempt, 0 if none",
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "description": "When the next attempt is due, while pending",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Request body sent to the endpoint",
+                    "type": "object"
+                },
+                "status": {
+                    "description": "pending, delivered or dead",
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "description": "The receiving subscription",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.WebhookSubscription": {
+            "description": "Endpoint of a client company that receives the job events, signed with the subscription secret.",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Inactive subscriptions receive no new deliveries",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Event types delivered; empty means all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Subscription ID",
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "HMAC-SHA256 signing key, only returned on creation",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Last update timestamp",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Endpoint receiving the events",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WebhookSubscriptionRequest": {
+            "description": "Endpoint and event types of a webhook subscription.",
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "Event types to deliver; empty means all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "description": "Absolute http(s) URL of the endpoint",
+                    "type": "string"
+                }
+            }
+        },
+        "transport.aliasRequest": {
+            "type": "object",
+            "required": [
+                "alias"
+            ],
+            "properties": {
+                "alias": {
+                    "description": "Alternative spelling of the skill",
                     "type": "string"
                 }
             }
@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// Actor identifies who performs a change and through which request
+type Actor struct {
+	Subject   string // Subject of the authenticated token (e.g., the user ID)
+	RequestID string // ID of the HTTP request
+	IP        string // Address of the client that sent the request
+}
+
+// actorKey is the context key under which the Actor is stored
+type actorKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying the actor
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or the zero Actor if there is none
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// API key scopes, granting access to the job endpoints
+const (
+	ScopeJobsRead  = "jobs:read"  // List, search, export and read jobs and their skills
+	ScopeJobsWrite = "jobs:write" // Create, import, update, delete and change the status of jobs
+)
+
+// APIKeyScopes lists the scopes an API key can be granted
+var APIKeyScopes = []string{ScopeJobsRead, ScopeJobsWrite}
+
+// APIKey represents the credentials of a machine client
+// @Description API key of an integration; the secret key itself is only returned when issued or rotated.
+type APIKey struct {
+	ID         int        `json:"id"`                     // API key ID
+	Name       string     `json:"name"`                   // Label of the client (e.g., "Greenhouse sync")
+	Prefix     string     `json:"prefix"`                 // Public part of the key, to recognise it in logs
+	Scopes     []string   `json:"scopes"`                 // Granted scopes (jobs:read, jobs:write)
+	CreatedBy  string     `json:"created_by"`             // Subject of the admin who issued the key
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`   // When the key stops working, nil if never
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // Last authenticated request, to the minute
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`   // When the key was revoked, nil while valid
+	CreatedAt  time.Time  `json:"created_at"`             // Creation timestamp
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`   // Last rotation, nil if never rotated
+
+	KeyHash           string     `json:"-"` // SHA-256 of the current key
+	PreviousPrefix    string     `json:"-"` // Prefix of the key replaced by the last rotation, empty if none
+	PreviousKeyHash   string     `json:"-"` // SHA-256 of the replaced key
+	PreviousExpiresAt *time.Time `json:"-"` // End of the grace period of the replaced key
+}
+
+// HasScope reports whether the key was granted a scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyRequest is the payload for issuing an API key
+// @Description Name, scopes and optional expiry of a new API key.
+type APIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`   // Label of the client
+	Scopes    []string   `json:"scopes" binding:"required"` // Scopes to grant (jobs:read, jobs:write)
+	ExpiresAt *time.Time `json:"expires_at"`                // Optional expiry (RFC 3339)
+}
+
+// IssuedAPIKey is an API key together with its secret, returned once when issued or rotated
+// @Description A new API key; store Key now, it cannot be retrieved again.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"` // The secret key, sent in the X-API-Key header
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited entity types
+const (
+	AuditEntityJob  = "job"
+	AuditEntityUser = "user"
+)
+
+// Audited actions
+const (
+	AuditActionCreate       = "create"
+	AuditActionUpdate       = "update"
+	AuditActionStatusChange = "status_change"
+	AuditActionDelete       = "delete"
+	AuditActionRestore      = "restore"
+	AuditActionLoginFailed  = "login_failed"
+	AuditActionLockout      = "lockout"
+	AuditActionUnlock       = "unlock"
+	AuditActionRoleChange   = "role_change"
+)
+
+// FieldChange is the old and new value of a single field
+// @Description Value of a field before and after a change.
+type FieldChange struct {
+	From interface{} `json:"from"` // Value before the change
+	To   interface{} `json:"to"`   // Value after the change
+}
+
+// AuditEntry represents an immutable record of a change to an entity
+// @Description Who changed what and when, with the entity snapshots before and after the change.
+type AuditEntry struct {
+	ID         int                    `json:"id"`                          // Entry ID
+	EntityType string                 `json:"entity_type"`                 // Type of the changed entity ("job" or "user")
+	EntityID   int                    `json:"entity_id"`                   // ID of the changed entity
+	Action     string                 `json:"action"`                      // What happened (create, update, status_change, delete, restore, login_failed, lockout, unlock)
+	Actor      string                 `json:"actor"`                       // Subject of the token that made the change
+	RequestID  string                 `json:"request_id"`                  // ID of the HTTP request that made the change
+	Before     json.RawMessage        `json:"before" swaggertype:"object"` // Entity snapshot before the change, null on create
+	After      json.RawMessage        `json:"after" swaggertype:"object"`  // Entity snapshot after the change, null on delete
+	Changes    map[string]FieldChange `json:"changes"`                     // Fields that differ between Before and After
+	CreatedAt  time.Time              `json:"created_at"`                  // When the change was recorded
+}
+
+// AuditFilter holds the optional criteria used to query the audit log
+// @Description Query parameters accepted by the audit log endpoint.
+type AuditFilter struct {
+	EntityType string    `form:"entity_type"`                                  // Only entries for this entity type
+	EntityID   int       `form:"entity_id"`                                    // Only entries for this entity
+	Action     string    `form:"action"`                                       // Only entries with this action
+	Actor      string    `form:"actor"`                                        // Only changes made by this actor
+	RequestID  string    `form:"request_id"`                                   // Only changes made by this request
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"` // Only entries recorded at or after this instant
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`   // Only entries recorded before this instant
+	Limit      int       `form:"limit"`                                        // Maximum number of entries (default 50, max 500)
+	Offset     int       `form:"offset"`                                       // Number of entries to skip
+}
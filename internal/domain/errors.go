@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Errors returned by repositories and services
+var (
+	ErrJobNotFound          = errors.New("job not found")
+	ErrTaxonomyTermNotFound = errors.New("taxonomy term not found")
+	ErrTaxonomyTermExists   = errors.New("taxonomy term already exists")
+	ErrSkillNotFound        = errors.New("skill not found")
+	ErrSkillExists          = errors.New("skill or alias already exists")
+	ErrWebhookNotFound      = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrVersionConflict      = errors.New("job was modified since it was read")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	ErrRequestInProgress    = errors.New("a request with this idempotency key is still being processed")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrInvalidAPIKey        = errors.New("invalid, expired or revoked api key")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserExists           = errors.New("username or email already registered")
+	ErrUsernameTaken        = errors.New("username already taken")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrInvalidRefreshToken  = errors.New("invalid, expired or revoked refresh token")
+	ErrLoginThrottled       = errors.New("too many failed login attempts, retry later")
+)
+
+// ValidationError reports an invalid value supplied for a field
+type ValidationError struct {
+	Field   string // Name of the offending field
+	Message string // Why the value was rejected
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// LoginThrottledError reports a login rejected after too many failures, before the credentials are checked
+type LoginThrottledError struct {
+	RetryAfter time.Duration // Wait before the next attempt is allowed
+	Locked     bool          // True if the account or IP is locked out rather than delayed
+}
+
+// Error implements the error interface
+func (e *LoginThrottledError) Error() string {
+	return ErrLoginThrottled.Error()
+}
+
+// Is makes errors.Is(err, ErrLoginThrottled) match
+func (e *LoginThrottledError) Is(target error) bool {
+	return target == ErrLoginThrottled
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job event types published to other services
+const (
+	EventJobCreated   = "job.created"   // A job was added
+	EventJobUpdated   = "job.updated"   // The editable fields of a job changed
+	EventJobPublished = "job.published" // A job moved to the published status
+	EventJobClosed    = "job.closed"    // A job moved to the closed status
+	EventJobDeleted   = "job.deleted"   // A job was soft-deleted
+	EventJobRestored  = "job.restored"  // A soft-deleted job was restored
+)
+
+// IsJobEvent reports whether eventType is one of the job event types
+func IsJobEvent(eventType string) bool {
+	switch eventType {
+	case EventJobCreated, EventJobUpdated, EventJobPublished, EventJobClosed, EventJobDeleted, EventJobRestored:
+		return true
+	}
+	return false
+}
+
+// Event is a domain event describing a change that already happened
+// @Description Notification sent to other services; Payload holds the job snapshot after the change
+// @Description (before the change for job.deleted).
+type Event struct {
+	ID            string          `json:"id"`                           // Unique event ID, used by consumers to discard duplicates
+	Type          string          `json:"type"`                         // Event type (e.g., "job.created")
+	AggregateType string          `json:"aggregate_type"`               // Type of the changed entity (e.g., "job")
+	AggregateID   int             `json:"aggregate_id"`                 // ID of the changed entity
+	OccurredAt    time.Time       `json:"occurred_at"`                  // When the change was made
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"` // Entity snapshot
+}
+
+// OutboxMessage is an event waiting in the outbox to be delivered
+type OutboxMessage struct {
+	Seq           int64     // Position in the outbox, delivery follows this order
+	Event         Event     // The event to deliver
+	Attempts      int       // Number of failed delivery attempts
+	NextAttemptAt time.Time // Earliest time of the next attempt
+	LastError     string    // Error of the last failed attempt
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotentResponse is the stored response of a request made with an Idempotency-Key
+type IdempotentResponse struct {
+	StatusCode int               `json:"status_code"` // HTTP status of the response
+	Header     map[string]string `json:"header"`      // Response headers replayed with the body (e.g., Content-Type)
+	Body       []byte            `json:"body"`        // Response body
+}
+
+// IdempotencyRecord tracks a request made with an Idempotency-Key
+type IdempotencyRecord struct {
+	Scope       string              // Subject of the caller; keys of different callers never collide
+	Key         string              // Idempotency-Key header value
+	RequestHash string              // SHA-256 of the method, path and body of the request
+	Response    *IdempotentResponse // Stored response, nil while the request is being processed
+	CreatedAt   time.Time           // When the key was first used
+	ExpiresAt   time.Time           // When the key can be reused for another request
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Supported job import formats
+const (
+	ImportFormatCSV    = "csv"    // Comma separated values with a header row
+	ImportFormatNDJSON = "ndjson" // One JSON object per line
+)
+
+// ImportRowError describes why one row of an import was rejected
+// @Description Row number (1-based, the CSV header being row 1) and the reason it was rejected.
+type ImportRowError struct {
+	Row     int    `json:"row"`             // Line of the row in the uploaded file
+	Field   string `json:"field,omitempty"` // Offending field, empty when the whole row is malformed
+	Message string `json:"message"`         // Why the row was rejected
+}
+
+// ImportReport summarises a bulk job import
+// @Description Outcome of a job import. Rows are only imported when every row is valid.
+type ImportReport struct {
+	DryRun   bool             `json:"dry_run"`           // True if nothing was written
+	Total    int              `json:"total"`             // Number of data rows read
+	Valid    int              `json:"valid"`             // Number of rows that passed validation
+	Invalid  int              `json:"invalid"`           // Number of rejected rows
+	Imported int              `json:"imported"`          // Number of jobs created
+	JobIDs   []int            `json:"job_ids,omitempty"` // IDs of the created jobs, in row order
+	Errors   []ImportRowError `json:"errors"`            // Rejected rows, capped at the first 100
+}
@@ -2,12 +2,108 @@ package domain
 
 import "time"
 
+// Job statuses
+const (
+	JobStatusDraft     = "draft"     // Being written, not visible to candidates
+	JobStatusPublished = "published" // Open for applications
+	JobStatusClosed    = "closed"    // No longer accepting applications
+)
+
+// IsJobStatus reports whether status is one of the supported job statuses
+func IsJobStatus(status string) bool {
+	return status == JobStatusDraft || status == JobStatusPublished || status == JobStatusClosed
+}
+
 // Job represents a job in the system
 type Job struct {
-	ID          int       `json:"id"`           // Job ID
-	Title       string    `json:"title"`        // Job title
-	Description string    `json:"description"`  // Job description
-	SalaryRange string    `json:"salary_range"` // Salary range
-	CreatedAt   time.Time `json:"created_at"`   // Creation timestamp
-	UpdatedAt   time.Time `json:"updated_at"`   // Last update timestamp
+	ID             int        `json:"id"`                   // Job ID
+	Title          string     `json:"title"`                // Job title
+	Description    string     `json:"description"`          // Job description
+	SalaryRange    string     `json:"salary_range"`         // Salary range
+	EmploymentType string     `json:"employment_type"`      // Employment type (e.g., full-time, contract)
+	Seniority      string     `json:"seniority"`            // Seniority level (e.g., junior, senior)
+	Department     string     `json:"department"`           // Department the position belongs to
+	Status         string     `json:"status"`               // Publication status (draft, published, closed)
+	Version        int        `json:"version"`              // Incremented on every change; sent as the ETag of the job
+	PublishAt      *time.Time `json:"publish_at,omitempty"` // When a draft goes live; set in the future to schedule the publication
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"` // When the job is closed automatically
+	Skills         []JobSkill `json:"skills,omitempty"`     // Skills tagged on the job; managed through /jobs/{id}/skills
+	CreatedAt      time.Time  `json:"created_at"`           // Creation timestamp
+	UpdatedAt      time.Time  `json:"updated_at"`           // Last update timestamp
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"` // Soft deletion timestamp, nil while the job is live
+}
+
+// ScheduledStatus returns the status the publication schedule moves the job to at the given time,
+// and false if the schedule requires no change
+// A draft goes live once its publish_at has passed and a published job closes once its expires_at
+// has passed; a draft whose whole schedule has passed goes straight to closed.
+func (j *Job) ScheduledStatus(now time.Time) (string, bool) {
+	if j.DeletedAt != nil {
+		return "", false
+	}
+	due := func(t *time.Time) bool { return t != nil && !t.After(now) }
+	switch {
+	case j.Status == JobStatusDraft && due(j.PublishAt) && due(j.ExpiresAt):
+		return JobStatusClosed, true
+	case j.Status == JobStatusDraft && due(j.PublishAt):
+		return JobStatusPublished, true
+	case j.Status == JobStatusPublished && due(j.ExpiresAt):
+		return JobStatusClosed, true
+	}
+	return "", false
+}
+
+// IsLive reports whether the job is open to candidates at the given time
+// It must be published and not deleted, with its publish_at (if any) passed and its expires_at (if any)
+// still ahead, so jobs the scheduler has not moved yet are treated as already moved.
+func (j *Job) IsLive(now time.Time) bool {
+	return j.DeletedAt == nil && j.Status == JobStatusPublished &&
+		(j.PublishAt == nil || !j.PublishAt.After(now)) &&
+		(j.ExpiresAt == nil || j.ExpiresAt.After(now))
+}
+
+// PostedAt returns when the job went, or goes, live: its publish_at if set, otherwise its creation time
+func (j *Job) PostedAt() time.Time {
+	if j.PublishAt != nil {
+		return *j.PublishAt
+	}
+	return j.CreatedAt
+}
+
+// JobFilter holds the optional criteria used to narrow down a job listing
+// @Description Query parameters accepted by the job listing endpoint.
+type JobFilter struct {
+	EmploymentType string   `form:"employment_type"` // Only jobs with this employment type
+	Seniority      string   `form:"seniority"`       // Only jobs with this seniority level
+	Department     string   `form:"department"`      // Only jobs in this department
+	Status         string   `form:"status"`          // Only jobs with this status
+	Skills         []string `form:"skills"`          // Only jobs tagged with these skills
+	SkillMatch     string   `form:"skill_match"`     // "any" (default) or "all" of Skills
+	IncludeDeleted bool     `form:"include_deleted"` // Also return soft-deleted jobs (admin only)
+}
+
+// IsEmpty reports whether the filter has no criteria set
+func (f JobFilter) IsEmpty() bool {
+	return f.EmploymentType == "" && f.Seniority == "" && f.Department == "" && f.Status == "" && len(f.Skills) == 0 && !f.IncludeDeleted
+}
+
+// JobFacets holds the number of matching jobs for each classification value
+// @Description Facet counts per taxonomy value, keyed by the term value.
+type JobFacets struct {
+	EmploymentType map[string]int `json:"employment_type"` // Job count per employment type
+	Seniority      map[string]int `json:"seniority"`       // Job count per seniority level
+	Department     map[string]int `json:"department"`      // Job count per department
+}
+
+// JobList is the listing response returned when facet counts are requested
+// @Description A page of jobs together with the facet counts for the applied filter.
+type JobList struct {
+	Jobs   []*Job     `json:"jobs"`   // Jobs matching the filter
+	Facets *JobFacets `json:"facets"` // Facet counts for the filter
+}
+
+// JobStatusRequest is the payload for changing the status of a job
+// @Description The request body for moving a job between draft, published and closed.
+type JobStatusRequest struct {
+	Status string `json:"status" binding:"required"` // The new status
 }
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// JobPostingProfile holds the employer details that jobs do not carry themselves
+// They are configured once for the careers site and added to every JobPosting.
+type JobPostingProfile struct {
+	OrganizationName string // Name of the hiring organization
+	OrganizationURL  string // Careers or company website
+	OrganizationLogo string // URL of the company logo
+	CareersURL       string // Base URL of the careers site; job pages live at <CareersURL>/jobs/<id>
+	Locality         string // City of the workplace
+	Region           string // State or region of the workplace
+	Country          string // ISO 3166-1 alpha-2 country code of the workplace
+	Currency         string // ISO 4217 currency assumed when the salary range names none
+}
+
+// JobURL returns the careers site page of a job, empty when no careers site is configured
+func (p JobPostingProfile) JobURL(id int) string {
+	if p.CareersURL == "" {
+		return ""
+	}
+	return strings.TrimRight(p.CareersURL, "/") + "/jobs/" + strconv.Itoa(id)
+}
+
+// JobPosting is a job rendered as a schema.org JobPosting for search engines
+// @Description schema.org JobPosting JSON-LD, returned by GET /jobs/{id} for Accept: application/ld+json.
+type JobPosting struct {
+	Context            string          `json:"@context"`                     // Always https://schema.org/
+	Type               string          `json:"@type"`                        // Always JobPosting
+	Identifier         *PropertyValue  `json:"identifier,omitempty"`         // Job ID within the hiring organization
+	Title              string          `json:"title"`                        // Job title
+	Description        string          `json:"description"`                  // Job description
+	DatePosted         string          `json:"datePosted"`                   // Publication date (RFC 3339)
+	ValidThrough       string          `json:"validThrough,omitempty"`       // Date after which the posting is no longer open (RFC 3339)
+	EmploymentType     string          `json:"employmentType,omitempty"`     // FULL_TIME, PART_TIME, CONTRACTOR, TEMPORARY, INTERN, ...
+	BaseSalary         *MonetaryAmount `json:"baseSalary,omitempty"`         // Salary parsed from the salary range
+	HiringOrganization *Organization   `json:"hiringOrganization,omitempty"` // Employer
+	JobLocation        *Place          `json:"jobLocation,omitempty"`        // Workplace
+	Skills             string          `json:"skills,omitempty"`             // Comma separated skill names
+	Department         string          `json:"industry,omitempty"`           // Department the position belongs to
+}
+
+// PropertyValue is a schema.org PropertyValue
+type PropertyValue struct {
+	Type  string `json:"@type"` // Always PropertyValue
+	Name  string `json:"name"`  // Issuer of the identifier
+	Value string `json:"value"` // Identifier value
+}
+
+// MonetaryAmount is a schema.org MonetaryAmount
+type MonetaryAmount struct {
+	Type     string             `json:"@type"`    // Always MonetaryAmount
+	Currency string             `json:"currency"` // ISO 4217 currency code
+	Value    *QuantitativeValue `json:"value"`    // Amount or range
+}
+
+// QuantitativeValue is a schema.org QuantitativeValue
+// Either Value or MinValue and MaxValue are set.
+type QuantitativeValue struct {
+	Type     string   `json:"@type"`              // Always QuantitativeValue
+	Value    *float64 `json:"value,omitempty"`    // Single amount
+	MinValue *float64 `json:"minValue,omitempty"` // Lower bound of the range
+	MaxValue *float64 `json:"maxValue,omitempty"` // Upper bound of the range
+	UnitText string   `json:"unitText"`           // HOUR, DAY, WEEK, MONTH or YEAR
+}
+
+// Organization is a schema.org Organization
+type Organization struct {
+	Type   string `json:"@type"`            // Always Organization
+	Name   string `json:"name"`             // Organization name
+	SameAs string `json:"sameAs,omitempty"` // Organization website
+	Logo   string `json:"logo,omitempty"`   // Logo URL
+}
+
+// Place is a schema.org Place
+type Place struct {
+	Type    string         `json:"@type"`   // Always Place
+	Address *PostalAddress `json:"address"` // Address of the place
+}
+
+// PostalAddress is a schema.org PostalAddress
+type PostalAddress struct {
+	Type            string `json:"@type"`                     // Always PostalAddress
+	AddressLocality string `json:"addressLocality,omitempty"` // City
+	AddressRegion   string `json:"addressRegion,omitempty"`   // State or region
+	AddressCountry  string `json:"addressCountry,omitempty"`  // ISO 3166-1 alpha-2 country code
+}
@@ -0,0 +1,28 @@
+package domain
+
+// CandidateProfile describes what a candidate brings and is looking for
+// @Description Query parameters accepted by the job recommendation endpoint.
+type CandidateProfile struct {
+	Skills          []string `form:"skills"`           // Candidate skills (names or aliases)
+	Keywords        []string `form:"keywords"`         // Extra terms to look for in titles and descriptions
+	ExperienceYears *int     `form:"experience_years"` // Years of professional experience
+	Location        string   `form:"location"`         // Preferred location (e.g., "remote", "Madrid")
+	DesiredSalary   int      `form:"desired_salary"`   // Expected salary, in the unit used by job salary ranges
+}
+
+// MatchFactor is the contribution of a single criterion to a match score
+// @Description Score of one matching criterion, between 0 and 1, and the weight it carries.
+type MatchFactor struct {
+	Name   string  `json:"name"`   // Criterion name (e.g., "skills", "salary")
+	Weight float64 `json:"weight"` // Relative weight of the criterion
+	Score  float64 `json:"score"`  // Criterion score between 0 and 1
+	Detail string  `json:"detail"` // Human readable explanation of the score
+}
+
+// JobMatch is a job together with how well it fits a candidate
+// @Description A recommended job with its overall score and per-factor breakdown.
+type JobMatch struct {
+	Job     *Job          `json:"job"`     // The recommended job
+	Score   float64       `json:"score"`   // Weighted score between 0 and 1
+	Factors []MatchFactor `json:"factors"` // Criteria that could be evaluated for this job
+}
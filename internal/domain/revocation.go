@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// TokenRevocationRequest is the payload for revoking a single token
+// @Description The jti of the token and, if known, its expiry.
+type TokenRevocationRequest struct {
+	JTI       string     `json:"jti" binding:"required"` // The "jti" claim of the token
+	ExpiresAt *time.Time `json:"expires_at"`             // The "exp" claim of the token; defaults to the longest token lifetime
+}
+
+// TokenRevocation is a revoked token
+// @Description A token rejected until it expires.
+type TokenRevocation struct {
+	JTI       string    `json:"jti"`        // The "jti" claim of the token
+	ExpiresAt time.Time `json:"expires_at"` // When the revocation is forgotten, as the token has expired
+}
+
+// SubjectRevocationRequest is the payload for revoking every token of a subject
+// @Description The subject and the cutoff time; tokens issued at or before it are revoked.
+type SubjectRevocationRequest struct {
+	Subject string     `json:"subject" binding:"required"` // The subject of the tokens (e.g., a user ID)
+	Before  *time.Time `json:"before"`                     // Cutoff time; defaults to now
+}
+
+// SubjectRevocation is the revocation of the tokens of a subject
+// @Description Tokens of the subject issued at or before RevokedBefore are rejected until ExpiresAt.
+type SubjectRevocation struct {
+	Subject       string    `json:"subject"`        // The subject of the tokens
+	RevokedBefore time.Time `json:"revoked_before"` // Tokens issued at or before this time are revoked
+	ExpiresAt     time.Time `json:"expires_at"`     // When the revocation is forgotten, as the tokens it covers have expired
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Skill requirement levels on a job
+const (
+	SkillRequired   = "required"     // The candidate must have the skill
+	SkillNiceToHave = "nice_to_have" // The skill is a plus
+)
+
+// Skill filter match modes
+const (
+	SkillMatchAny = "any" // Jobs tagged with at least one of the skills
+	SkillMatchAll = "all" // Jobs tagged with every skill
+)
+
+// Skill represents an entry of the skills dictionary
+// @Description A canonical skill name (e.g., "Go") with the aliases that resolve to it (e.g., "golang").
+type Skill struct {
+	ID        int       `json:"id"`                      // Skill ID
+	Name      string    `json:"name" binding:"required"` // Canonical skill name
+	Aliases   []string  `json:"aliases"`                 // Alternative spellings resolved to the canonical name
+	CreatedAt time.Time `json:"created_at"`              // Creation timestamp
+}
+
+// JobSkill represents a skill tagged on a job
+// @Description A skill required by, or nice to have for, a job.
+type JobSkill struct {
+	SkillID     int    `json:"skill_id"`                // Skill ID
+	Name        string `json:"name" binding:"required"` // Skill name; aliases are accepted on input
+	Requirement string `json:"requirement"`             // "required" (default) or "nice_to_have"
+}
+
+// NormalizeSkillKey returns the lookup key used to match skill names and aliases
+func NormalizeSkillKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
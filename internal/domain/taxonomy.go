@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Taxonomy kinds used to classify jobs
+const (
+	TaxonomyEmploymentType = "employment_type" // Full-time, part-time, contract, internship...
+	TaxonomySeniority      = "seniority"       // Junior, mid, senior...
+	TaxonomyDepartment     = "department"      // Engineering, sales, HR...
+)
+
+// TaxonomyKinds lists every supported taxonomy kind
+var TaxonomyKinds = []string{TaxonomyEmploymentType, TaxonomySeniority, TaxonomyDepartment}
+
+// IsTaxonomyKind reports whether kind is one of the supported taxonomy kinds
+func IsTaxonomyKind(kind string) bool {
+	for _, k := range TaxonomyKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxonomyTerm represents an allowed value in one of the job classification vocabularies
+// @Description A controlled vocabulary entry (e.g., "full-time" for employment_type).
+type TaxonomyTerm struct {
+	ID        int       `json:"id"`                       // Term ID
+	Kind      string    `json:"kind"`                     // Vocabulary the term belongs to
+	Value     string    `json:"value" binding:"required"` // Machine value stored on jobs
+	Label     string    `json:"label"`                    // Human readable label
+	CreatedAt time.Time `json:"created_at"`               // Creation timestamp
+}
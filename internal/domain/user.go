@@ -5,11 +5,68 @@ import "time"
 // User represents a user in the system
 // @Description Represents a user entity in the system with all associated details.
 type User struct {
-	ID           int       `json:"id"`            // User ID
-	Username     string    `json:"username"`      // User's username
-	Email        string    `json:"email"`         // User's email
-	PasswordHash string    `json:"password_hash"` // Hashed password of the user
-	Role         string    `json:"role"`          // User's role (e.g., admin, user)
-	CreatedAt    time.Time `json:"created_at"`    // Timestamp when the user was created
-	UpdatedAt    time.Time `json:"updated_at"`    // Timestamp when the user was last updated
+	ID           int       `json:"id"`         // User ID
+	Username     string    `json:"username"`   // User's username
+	Email        string    `json:"email"`      // User's email
+	PasswordHash string    `json:"-"`          // Hashed password of the user, never serialised
+	Role         string    `json:"role"`       // User's role (e.g., admin, user)
+	CreatedAt    time.Time `json:"created_at"` // Timestamp when the user was created
+	UpdatedAt    time.Time `json:"updated_at"` // Timestamp when the user was last updated
+}
+
+// User roles; the access tokens of a user are granted the scopes of their role
+const (
+	RoleUser      = "user"      // Self-registered accounts; read only until an admin grants another role
+	RoleRecruiter = "recruiter" // Creates and manages jobs
+	RoleAdmin     = "admin"     // Manages jobs and the service
+)
+
+// UserRoles lists the roles a user can be given
+var UserRoles = []string{RoleUser, RoleRecruiter, RoleAdmin}
+
+// RoleScopes returns the scopes granted to the access tokens of a role
+// Unknown roles are only granted read access.
+func RoleScopes(role string) []string {
+	switch role {
+	case RoleRecruiter, RoleAdmin:
+		return []string{ScopeJobsRead, ScopeJobsWrite}
+	default:
+		return []string{ScopeJobsRead}
+	}
+}
+
+// UserRoleRequest represents the payload for changing the role of a user
+// @Description The new role of the user (user, recruiter or admin).
+type UserRoleRequest struct {
+	Role string `json:"role" binding:"required"` // The new role
+}
+
+// RefreshRequest represents the payload for exchanging a refresh token
+// @Description The refresh token returned by the last login or refresh.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // The refresh token to exchange
+}
+
+// AuthTokens is the pair of tokens returned by a login or a refresh
+// @Description A short-lived access token for the Authorization header and a refresh token to renew it.
+// The refresh token can only be used once; each refresh returns a new one.
+type AuthTokens struct {
+	AccessToken      string `json:"access_token"`       // JWT to send as "Authorization: Bearer <token>"
+	TokenType        string `json:"token_type"`         // Always "Bearer"
+	ExpiresIn        int    `json:"expires_in"`         // Lifetime of the access token in seconds
+	RefreshToken     string `json:"refresh_token"`      // Opaque token for POST /auth/refresh
+	RefreshExpiresIn int    `json:"refresh_expires_in"` // Lifetime of the refresh token in seconds
+}
+
+// RefreshToken is a stored refresh token
+type RefreshToken struct {
+	ID             int        // Refresh token ID
+	UserID         int        // Owner of the token
+	FamilyID       string     // Shared by the tokens descending from the same login
+	FamilyIssuedAt time.Time  // When that login happened; zero for families older than its column
+	TokenHash      string     // SHA-256 of the token
+	ExpiresAt      time.Time  // When the token stops working
+	UsedAt         *time.Time // When the token was exchanged, nil while unused
+	RevokedAt      *time.Time // When the family was revoked, nil while valid
+	CreatedAt      time.Time  // Creation timestamp
 }
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"   // Waiting for its first or next attempt
+	DeliveryDelivered = "delivered" // Acknowledged by the receiver with a 2xx response
+	DeliveryDead      = "dead"      // Gave up after the maximum number of attempts
+)
+
+// WebhookSubscription represents an endpoint notified of job events
+// @Description Endpoint of a client company that receives the job events, signed with the subscription secret.
+type WebhookSubscription struct {
+	ID        int       `json:"id"`               // Subscription ID
+	Owner     string    `json:"-"`                // Subject of the token that created the subscription
+	URL       string    `json:"url"`              // Endpoint receiving the events
+	Events    []string  `json:"events"`           // Event types delivered; empty means all
+	Secret    string    `json:"secret,omitempty"` // HMAC-SHA256 signing key, only returned on creation
+	Active    bool      `json:"active"`           // Inactive subscriptions receive no new deliveries
+	CreatedAt time.Time `json:"created_at"`       // Creation timestamp
+	UpdatedAt time.Time `json:"updated_at"`       // Last update timestamp
+}
+
+// Accepts reports whether the subscription wants events of the given type
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, accepted := range s.Events {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscriptionRequest is the payload for creating or updating a webhook subscription
+// @Description Endpoint and event types of a webhook subscription.
+type WebhookSubscriptionRequest struct {
+	URL    string   `json:"url" binding:"required"` // Absolute http(s) URL of the endpoint
+	Events []string `json:"events"`                 // Event types to deliver; empty means all
+	Active *bool    `json:"active"`                 // Defaults to true
+}
+
+// WebhookDelivery represents one event sent, or to be sent, to a subscription
+// @Description Delivery log entry of a webhook subscription.
+type WebhookDelivery struct {
+	ID             int             `json:"id"`                           // Delivery ID, sent in the X-Webhook-Delivery header
+	SubscriptionID int             `json:"subscription_id"`              // The receiving subscription
+	EventID        string          `json:"event_id"`                     // ID of the delivered event
+	EventType      string          `json:"event_type"`                   // Type of the delivered event
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // Request body sent to the endpoint
+	Status         string          `json:"status"`                       // pending, delivered or dead
+	Attempts       int             `json:"attempts"`                     // Number of attempts made
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`    // When the next attempt is due, while pending
+	LastStatusCode int             `json:"last_status_code,omitempty"`   // HTTP status of the last attempt, 0 if none
+	LastError      string          `json:"last_error,omitempty"`         // Error of the last failed attempt
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`       // When the receiver acknowledged the event
+	CreatedAt      time.Time       `json:"created_at"`                   // When the event was queued
+}
+
+// WebhookDispatch is a claimed delivery together with the endpoint it goes to
+type WebhookDispatch struct {
+	Delivery WebhookDelivery // The delivery to attempt
+	URL      string          // Endpoint of the subscription
+	Secret   string          // Signing key of the subscription
+}
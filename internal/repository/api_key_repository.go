@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// APIKeyRepository defines methods for accessing the api_keys table
+type APIKeyRepository interface {
+	// Create inserts a new API key and sets its ID and creation time
+	// @param key *domain.APIKey - The key to be stored, with its prefix and hash
+	// @return error - An error if the query fails
+	Create(key *domain.APIKey) error
+
+	// FindAll retrieves every API key, revoked ones included
+	// @return []*domain.APIKey - The keys, oldest first
+	// @return error - An error if the query fails
+	FindAll() ([]*domain.APIKey, error)
+
+	// FindByID retrieves an API key
+	// @param id int - The ID of the key
+	// @return *domain.APIKey - The key
+	// @return error - domain.ErrAPIKeyNotFound if it does not exist, or an error if the query fails
+	FindByID(id int) (*domain.APIKey, error)
+
+	// FindByPrefix retrieves the API key whose current or previous key has the given prefix
+	// @param prefix string - The public part of a key
+	// @return *domain.APIKey - The key
+	// @return error - domain.ErrAPIKeyNotFound if no key has the prefix, or an error if the query fails
+	FindByPrefix(prefix string) (*domain.APIKey, error)
+
+	// Rotate stores the new prefix and hash of an API key together with the replaced ones
+	// @param key *domain.APIKey - The key, identified by its ID, with its new and previous credentials
+	// @return error - domain.ErrAPIKeyNotFound if it does not exist or is revoked, or an error if the query fails
+	Rotate(key *domain.APIKey) error
+
+	// Revoke disables an API key
+	// @param id int - The ID of the key
+	// @param at time.Time - The revocation time
+	// @return error - domain.ErrAPIKeyNotFound if it does not exist or is already revoked, or an error if the query fails
+	Revoke(id int, at time.Time) error
+
+	// TouchLastUsed records when an API key was last used
+	// @param id int - The ID of the key
+	// @param at time.Time - The time of the request
+	// @return error - An error if the query fails
+	TouchLastUsed(id int, at time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return APIKeyRepository - The implementation of the repository
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+// apiKeyColumns lists the columns read by scanAPIKey, in scan order
+const apiKeyColumns = "id, name, prefix, key_hash, scopes, created_by, previous_prefix, previous_key_hash, previous_expires_at," +
+	" expires_at, last_used_at, revoked_at, created_at, rotated_at"
+
+// scanAPIKey reads one api_keys row selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes []uint8
+	var previousPrefix, previousHash sql.NullString
+	var previousExpiresAt, expiresAt, lastUsedAt, revokedAt, createdAt, rotatedAt []uint8
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &key.CreatedBy, &previousPrefix, &previousHash,
+		&previousExpiresAt, &expiresAt, &lastUsedAt, &revokedAt, &createdAt, &rotatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(scopes, &key.Scopes); err != nil {
+		return nil, err
+	}
+	key.PreviousPrefix = previousPrefix.String
+	key.PreviousKeyHash = previousHash.String
+	key.PreviousExpiresAt = parseNullTimestamp(previousExpiresAt)
+	key.ExpiresAt = parseNullTimestamp(expiresAt)
+	key.LastUsedAt = parseNullTimestamp(lastUsedAt)
+	key.RevokedAt = parseNullTimestamp(revokedAt)
+	key.CreatedAt = parseTimestamp(createdAt)
+	key.RotatedAt = parseNullTimestamp(rotatedAt)
+	return &key, nil
+}
+
+// Create inserts a new API key and sets its ID and creation time
+// @param key *domain.APIKey - The key to be stored, with its prefix and hash
+// @return error - An error if the query fails
+func (r *apiKeyRepositoryImpl) Create(key *domain.APIKey) error {
+	scopes, err := json.Marshal(nonNilStrings(key.Scopes))
+	if err != nil {
+		return err
+	}
+	key.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	query := "INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, key.Name, key.Prefix, key.KeyHash, scopes, key.CreatedBy,
+		nullTimestamp(key.ExpiresAt), key.CreatedAt.Format(mysqlTimeLayout))
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	key.ID = int(id)
+	return nil
+}
+
+// FindAll retrieves every API key, revoked ones included
+// @return []*domain.APIKey - The keys, oldest first
+// @return error - An error if the query fails
+func (r *apiKeyRepositoryImpl) FindAll() ([]*domain.APIKey, error) {
+	rows, err := r.db.Query("SELECT " + apiKeyColumns + " FROM api_keys ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// FindByID retrieves an API key
+// @param id int - The ID of the key
+// @return *domain.APIKey - The key
+// @return error - domain.ErrAPIKeyNotFound if it does not exist, or an error if the query fails
+func (r *apiKeyRepositoryImpl) FindByID(id int) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// FindByPrefix retrieves the API key whose current or previous key has the given prefix
+// @param prefix string - The public part of a key
+// @return *domain.APIKey - The key
+// @return error - domain.ErrAPIKeyNotFound if no key has the prefix, or an error if the query fails
+func (r *apiKeyRepositoryImpl) FindByPrefix(prefix string) (*domain.APIKey, error) {
+	query := "SELECT " + apiKeyColumns + " FROM api_keys WHERE prefix = ? OR previous_prefix = ? LIMIT 1"
+	key, err := scanAPIKey(r.db.QueryRow(query, prefix, prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// Rotate stores the new prefix and hash of an API key together with the replaced ones
+// @param key *domain.APIKey - The key, identified by its ID, with its new and previous credentials
+// @return error - domain.ErrAPIKeyNotFound if it does not exist or is revoked, or an error if the query fails
+func (r *apiKeyRepositoryImpl) Rotate(key *domain.APIKey) error {
+	query := "UPDATE api_keys SET prefix = ?, key_hash = ?, previous_prefix = ?, previous_key_hash = ?, previous_expires_at = ?," +
+		" rotated_at = ? WHERE id = ? AND revoked_at IS NULL"
+	return r.execOne(query, key.Prefix, key.KeyHash, key.PreviousPrefix, key.PreviousKeyHash,
+		nullTimestamp(key.PreviousExpiresAt), nullTimestamp(key.RotatedAt), key.ID)
+}
+
+// Revoke disables an API key
+// @param id int - The ID of the key
+// @param at time.Time - The revocation time
+// @return error - domain.ErrAPIKeyNotFound if it does not exist or is already revoked, or an error if the query fails
+func (r *apiKeyRepositoryImpl) Revoke(id int, at time.Time) error {
+	return r.execOne("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", at.UTC().Format(mysqlTimeLayout), id)
+}
+
+// TouchLastUsed records when an API key was last used
+// @param id int - The ID of the key
+// @param at time.Time - The time of the request
+// @return error - An error if the query fails
+func (r *apiKeyRepositoryImpl) TouchLastUsed(id int, at time.Time) error {
+	_, err := r.db.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", at.UTC().Format(mysqlTimeLayout), id)
+	return err
+}
+
+// execOne runs an UPDATE expected to change exactly one API key
+func (r *apiKeyRepositoryImpl) execOne(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository for testing
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockAPIKeyRepository) Create(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// FindAll mocks the FindAll method
+func (m *MockAPIKeyRepository) FindAll() ([]*domain.APIKey, error) {
+	args := m.Called()
+	if keys, ok := args.Get(0).([]*domain.APIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockAPIKeyRepository) FindByID(id int) (*domain.APIKey, error) {
+	args := m.Called(id)
+	if key, ok := args.Get(0).(*domain.APIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindByPrefix mocks the FindByPrefix method
+func (m *MockAPIKeyRepository) FindByPrefix(prefix string) (*domain.APIKey, error) {
+	args := m.Called(prefix)
+	if key, ok := args.Get(0).(*domain.APIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Rotate mocks the Rotate method
+func (m *MockAPIKeyRepository) Rotate(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// Revoke mocks the Revoke method
+func (m *MockAPIKeyRepository) Revoke(id int, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+// TouchLastUsed mocks the TouchLastUsed method
+func (m *MockAPIKeyRepository) TouchLastUsed(id int, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
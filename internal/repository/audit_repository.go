@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// Bounds of the number of audit entries returned by a query
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// AuditRepository defines methods for accessing the audit_log table
+// The log is append-only: entries can be added and queried, never changed or removed.
+type AuditRepository interface {
+	// Append records a new audit entry
+	// @param entry *domain.AuditEntry - The entry to be recorded
+	// @return error - An error if the query fails
+	Append(entry *domain.AuditEntry) error
+
+	// Find retrieves the audit entries matching a filter, newest first
+	// @param filter domain.AuditFilter - The criteria the entries must match
+	// @return []*domain.AuditEntry - A slice of matching entries
+	// @return error - An error if the query fails
+	Find(filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
+
+type auditRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewAuditRepository creates a new AuditRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return AuditRepository - The implementation of the repository
+func NewAuditRepository(db *sql.DB) AuditRepository {
+	return &auditRepositoryImpl{db: db}
+}
+
+// Append records a new audit entry
+// @param entry *domain.AuditEntry - The entry to be recorded
+// @return error - An error if the query fails
+func (r *auditRepositoryImpl) Append(entry *domain.AuditEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO audit_log (entity_type, entity_id, action, actor, request_id, before_data, after_data, changes)" +
+		" VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, entry.EntityType, entry.EntityID, entry.Action, entry.Actor, entry.RequestID,
+		nullJSON(entry.Before), nullJSON(entry.After), changes)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = int(id)
+	return nil
+}
+
+// Find retrieves the audit entries matching a filter, newest first
+// @param filter domain.AuditFilter - The criteria the entries must match
+// @return []*domain.AuditEntry - A slice of matching entries
+// @return error - An error if the query fails
+func (r *auditRepositoryImpl) Find(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, value)
+	}
+	if filter.EntityType != "" {
+		add("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		add("entity_id = ?", filter.EntityID)
+	}
+	if filter.Action != "" {
+		add("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		add("actor = ?", filter.Actor)
+	}
+	if filter.RequestID != "" {
+		add("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= ?", filter.From.UTC().Format(mysqlTimeLayout))
+	}
+	if !filter.To.IsZero() {
+		add("created_at < ?", filter.To.UTC().Format(mysqlTimeLayout))
+	}
+
+	query := "SELECT id, entity_type, entity_id, action, actor, request_id, before_data, after_data, changes, created_at FROM audit_log"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	query += " ORDER BY id DESC LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var before, after, changes, createdAt []uint8
+		if err := rows.Scan(&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &entry.Actor,
+			&entry.RequestID, &before, &after, &changes, &createdAt); err != nil {
+			return nil, err
+		}
+		if before != nil {
+			entry.Before = json.RawMessage(before)
+		}
+		if after != nil {
+			entry.After = json.RawMessage(after)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, err
+		}
+		entry.CreatedAt = parseTimestamp(createdAt)
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// nullJSON stores empty snapshots as SQL NULL
+func nullJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return []byte(raw)
+}
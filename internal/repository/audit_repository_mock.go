@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository is a mock implementation of AuditRepository for testing
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+// Append mocks the Append method
+func (m *MockAuditRepository) Append(entry *domain.AuditEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+// Find mocks the Find method
+func (m *MockAuditRepository) Find(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	args := m.Called(filter)
+	if entries, ok := args.Get(0).([]*domain.AuditEntry); ok {
+		return entries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// IdempotencyRepository defines methods for accessing the idempotency_keys table
+type IdempotencyRepository interface {
+	// Reserve claims an idempotency key for a request about to be processed
+	// @param record *domain.IdempotencyRecord - The key, its scope, request hash, creation and expiry times
+	// @param lockedUntil time.Time - When an unfinished reservation is considered abandoned
+	// @return *domain.IdempotencyRecord - The record already holding the key, nil if the key was reserved
+	// @return error - An error if the query fails
+	Reserve(record *domain.IdempotencyRecord, lockedUntil time.Time) (*domain.IdempotencyRecord, error)
+
+	// Complete stores the response of a reserved key
+	// @param scope string - The scope of the key
+	// @param key string - The idempotency key
+	// @param response domain.IdempotentResponse - The response to replay
+	// @return error - An error if the query fails
+	Complete(scope, key string, response domain.IdempotentResponse) error
+
+	// Release drops an unfinished reservation so the request can be retried
+	// @param scope string - The scope of the key
+	// @param key string - The idempotency key
+	// @return error - An error if the query fails
+	Release(scope, key string) error
+
+	// DeleteExpired removes the keys past their expiry
+	// @param now time.Time - The current time
+	// @return int64 - The number of removed keys
+	// @return error - An error if the query fails
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type idempotencyRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return IdempotencyRepository - The implementation of the repository
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencyRepositoryImpl{db: db}
+}
+
+// Reserve claims an idempotency key for a request about to be processed
+// Expired keys and reservations abandoned past their lock are replaced; the unique key on
+// (scope, idempotency_key) lets a single concurrent request win.
+// @param record *domain.IdempotencyRecord - The key, its scope, request hash, creation and expiry times
+// @param lockedUntil time.Time - When an unfinished reservation is considered abandoned
+// @return *domain.IdempotencyRecord - The record already holding the key, nil if the key was reserved
+// @return error - An error if the query fails
+func (r *idempotencyRepositoryImpl) Reserve(record *domain.IdempotencyRecord, lockedUntil time.Time) (*domain.IdempotencyRecord, error) {
+	now := record.CreatedAt.UTC().Format(mysqlTimeLayout)
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ?"+
+		" AND (expires_at <= ? OR (status_code IS NULL AND locked_until <= ?))", record.Scope, record.Key, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.Exec("INSERT IGNORE INTO idempotency_keys (scope, idempotency_key, request_hash, locked_until, created_at, expires_at)"+
+		" VALUES (?, ?, ?, ?, ?, ?)", record.Scope, record.Key, record.RequestHash,
+		lockedUntil.UTC().Format(mysqlTimeLayout), now, record.ExpiresAt.UTC().Format(mysqlTimeLayout))
+	if err != nil {
+		return nil, err
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted > 0 {
+		return nil, err
+	}
+
+	var existing domain.IdempotencyRecord
+	var statusCode sql.NullInt64
+	var header, body, createdAt, expiresAt []uint8
+	err = r.db.QueryRow("SELECT scope, idempotency_key, request_hash, status_code, response_headers, response_body, created_at, expires_at"+
+		" FROM idempotency_keys WHERE scope = ? AND idempotency_key = ?", record.Scope, record.Key).
+		Scan(&existing.Scope, &existing.Key, &existing.RequestHash, &statusCode, &header, &body, &createdAt, &expiresAt)
+	if err == sql.ErrNoRows {
+		// Released between the insert and the select; report it as still in progress
+		return &domain.IdempotencyRecord{Scope: record.Scope, Key: record.Key, RequestHash: record.RequestHash}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	existing.CreatedAt = parseTimestamp(createdAt)
+	existing.ExpiresAt = parseTimestamp(expiresAt)
+	if statusCode.Valid {
+		existing.Response = &domain.IdempotentResponse{StatusCode: int(statusCode.Int64), Body: body}
+		if header != nil {
+			if err := json.Unmarshal(header, &existing.Response.Header); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &existing, nil
+}
+
+// Complete stores the response of a reserved key
+// @param scope string - The scope of the key
+// @param key string - The idempotency key
+// @param response domain.IdempotentResponse - The response to replay
+// @return error - An error if the query fails
+func (r *idempotencyRepositoryImpl) Complete(scope, key string, response domain.IdempotentResponse) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec("UPDATE idempotency_keys SET status_code = ?, response_headers = ?, response_body = ? WHERE scope = ? AND idempotency_key = ?",
+		response.StatusCode, header, response.Body, scope, key)
+	return err
+}
+
+// Release drops an unfinished reservation so the request can be retried
+// @param scope string - The scope of the key
+// @param key string - The idempotency key
+// @return error - An error if the query fails
+func (r *idempotencyRepositoryImpl) Release(scope, key string) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ? AND status_code IS NULL", scope, key)
+	return err
+}
+
+// DeleteExpired removes the keys past their expiry
+// @param now time.Time - The current time
+// @return int64 - The number of removed keys
+// @return error - An error if the query fails
+func (r *idempotencyRepositoryImpl) DeleteExpired(now time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", now.UTC().Format(mysqlTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyRepository is a mock implementation of IdempotencyRepository for testing
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+// Reserve mocks the Reserve method
+func (m *MockIdempotencyRepository) Reserve(record *domain.IdempotencyRecord, lockedUntil time.Time) (*domain.IdempotencyRecord, error) {
+	args := m.Called(record, lockedUntil)
+	if existing, ok := args.Get(0).(*domain.IdempotencyRecord); ok {
+		return existing, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Complete mocks the Complete method
+func (m *MockIdempotencyRepository) Complete(scope, key string, response domain.IdempotentResponse) error {
+	args := m.Called(scope, key, response)
+	return args.Error(0)
+}
+
+// Release mocks the Release method
+func (m *MockIdempotencyRepository) Release(scope, key string) error {
+	args := m.Called(scope, key)
+	return args.Error(0)
+}
+
+// DeleteExpired mocks the DeleteExpired method
+func (m *MockIdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -2,26 +2,103 @@ package repository
 
 import (
 	"database/sql"
-	"github.com/poolcamacho/jobs-service/internal/domain"
+	"errors"
+	"strings"
 	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
 )
 
 // JobRepository defines methods for accessing the jobs table
 // This interface abstracts database operations for the jobs table.
 type JobRepository interface {
-	// FindAll retrieves all jobs from the database
+	// FindAll retrieves all jobs from the database, soft-deleted ones excluded
 	// @return []*domain.Job - A slice of jobs
 	// @return error - An error if the query fails
 	FindAll() ([]*domain.Job, error)
 
+	// FindByFilter retrieves the jobs matching the given filter
+	// @param filter domain.JobFilter - The criteria the jobs must match
+	// @return []*domain.Job - A slice of matching jobs
+	// @return error - An error if the query fails
+	FindByFilter(filter domain.JobFilter) ([]*domain.Job, error)
+
+	// StreamByFilter calls fn for each job matching a filter, in ID order, without loading them all
+	// @param filter domain.JobFilter - The criteria the jobs must match
+	// @param fn func(job *domain.Job) error - Called for each job; an error stops the iteration
+	// @return error - The error returned by fn, or an error if the query fails
+	StreamByFilter(filter domain.JobFilter, fn func(job *domain.Job) error) error
+
+	// FindByID retrieves a single job by its ID
+	// @param id int - The ID of the job
+	// @return *domain.Job - The job
+	// @return error - domain.ErrJobNotFound if the job does not exist or is soft-deleted, or an error if the query fails
+	FindByID(id int) (*domain.Job, error)
+
+	// FindByIDIncludingDeleted retrieves a single job by its ID, even if it is soft-deleted
+	// @param id int - The ID of the job
+	// @return *domain.Job - The job
+	// @return error - domain.ErrJobNotFound if the job does not exist, or an error if the query fails
+	FindByIDIncludingDeleted(id int) (*domain.Job, error)
+
+	// CountFacets counts the jobs per classification value for the given filter
+	// @param filter domain.JobFilter - The criteria the counted jobs must match
+	// @return *domain.JobFacets - The facet counts
+	// @return error - An error if the query fails
+	CountFacets(filter domain.JobFilter) (*domain.JobFacets, error)
+
 	// Create inserts a new job into the database
 	// @param job *domain.Job - The job data to be inserted
 	// @return error - An error if the query fails
 	Create(job *domain.Job) error
+
+	// CreateBatch inserts several jobs with one statement and sets their IDs
+	// @param jobs []*domain.Job - The jobs to be inserted
+	// @return error - An error if the query fails
+	CreateBatch(jobs []*domain.Job) error
+
+	// FindScheduleDue retrieves the live jobs whose publication schedule requires a status change
+	// @param now time.Time - The current time
+	// @param limit int - The maximum number of jobs returned
+	// @return []*domain.Job - Drafts past their publish_at and published jobs past their expires_at, in ID order
+	// @return error - An error if the query fails
+	FindScheduleDue(now time.Time, limit int) ([]*domain.Job, error)
+
+	// Update modifies an existing job in the database if it is still at the version the caller read
+	// @param job *domain.Job - The job data to be stored, identified by its ID; a zero Version skips the check
+	// @return error - domain.ErrJobNotFound if the job does not exist, domain.ErrVersionConflict if its version differs, or an error if the query fails
+	Update(job *domain.Job) error
+
+	// UpdateStatus changes the status of a job if it is still at the version the caller read
+	// @param id int - The ID of the job
+	// @param status string - The new status
+	// @param version int - The version the job must be at; 0 skips the check
+	// @return error - domain.ErrJobNotFound if the job does not exist, domain.ErrVersionConflict if its version differs, or an error if the query fails
+	UpdateStatus(id int, status string, version int) error
+
+	// Delete soft-deletes a job by setting its deleted_at timestamp, if it is still at the version the caller read
+	// @param id int - The ID of the job
+	// @param version int - The version the job must be at; 0 skips the check
+	// @return error - domain.ErrJobNotFound if the job does not exist or is already deleted, domain.ErrVersionConflict if its version differs, or an error if the query fails
+	Delete(id int, version int) error
+
+	// Restore clears the deleted_at timestamp of a soft-deleted job
+	// @param id int - The ID of the job
+	// @return error - domain.ErrJobNotFound if the job does not exist or is not deleted, or an error if the query fails
+	Restore(id int) error
+
+	// PurgeDeleted permanently removes the jobs soft-deleted before a point in time
+	// @param before time.Time - Jobs deleted before this instant are removed
+	// @return int64 - The number of removed jobs
+	// @return error - An error if the query fails
+	PurgeDeleted(before time.Time) (int64, error)
 }
 
+// jobColumns lists the columns selected for every job query, in scan order
+const jobColumns = "id, title, description, salary_range, employment_type, seniority, department, status, publish_at, expires_at, version, created_at, updated_at, deleted_at"
+
 type jobRepositoryImpl struct {
-	db *sql.DB // Database connection instance
+	db dbtx // Database connection or transaction
 }
 
 // NewJobRepository creates a new JobRepository instance
@@ -31,13 +108,23 @@ func NewJobRepository(db *sql.DB) JobRepository {
 	return &jobRepositoryImpl{db: db}
 }
 
-// FindAll retrieves all jobs from the database
-// Executes a SELECT query on the jobs table and maps the results to a slice of Job structs.
+// FindAll retrieves all jobs from the database, soft-deleted ones excluded
+// Equivalent to FindByFilter with an empty filter.
 // @return []*domain.Job - A slice of jobs
 // @return error - An error if the query fails
 func (r *jobRepositoryImpl) FindAll() ([]*domain.Job, error) {
-	query := "SELECT id, title, description, salary_range, created_at, updated_at FROM jobs"
-	rows, err := r.db.Query(query)
+	return r.FindByFilter(domain.JobFilter{})
+}
+
+// FindByFilter retrieves the jobs matching the given filter
+// Executes a SELECT query on the jobs table and maps the results to a slice of Job structs.
+// @param filter domain.JobFilter - The criteria the jobs must match
+// @return []*domain.Job - A slice of matching jobs
+// @return error - An error if the query fails
+func (r *jobRepositoryImpl) FindByFilter(filter domain.JobFilter) ([]*domain.Job, error) {
+	where, args := jobFilterClause(filter, "")
+	query := "SELECT " + jobColumns + " FROM jobs" + where
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err // Return error if the query fails
 	}
@@ -45,26 +132,405 @@ func (r *jobRepositoryImpl) FindAll() ([]*domain.Job, error) {
 
 	var jobs []*domain.Job
 	for rows.Next() {
-		var job domain.Job
-		var createdAt, updatedAt []uint8 // Temporary variables to handle MySQL DATETIME/TIMESTAMP as []uint8
-		// Scan values into variables
-		if err := rows.Scan(&job.ID, &job.Title, &job.Description, &job.SalaryRange, &createdAt, &updatedAt); err != nil {
+		job, err := scanJob(rows)
+		if err != nil {
 			return nil, err // Return error if scanning fails
 		}
-		// Convert []uint8 to time.Time
-		job.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", string(createdAt))
-		job.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", string(updatedAt))
-		jobs = append(jobs, &job)
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.attachSkills(jobs); err != nil {
+		return nil, err
 	}
 	return jobs, nil
 }
 
+// StreamByFilter calls fn for each job matching a filter, in ID order, without loading them all
+// Rows are scanned one at a time while the query is open; skill tags are not loaded.
+// @param filter domain.JobFilter - The criteria the jobs must match
+// @param fn func(job *domain.Job) error - Called for each job; an error stops the iteration
+// @return error - The error returned by fn, or an error if the query fails
+func (r *jobRepositoryImpl) StreamByFilter(filter domain.JobFilter, fn func(job *domain.Job) error) error {
+	where, args := jobFilterClause(filter, "")
+	rows, err := r.db.Query("SELECT "+jobColumns+" FROM jobs"+where+" ORDER BY id", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(job); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// FindByID retrieves a single job by its ID
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - domain.ErrJobNotFound if the job does not exist or is soft-deleted, or an error if the query fails
+func (r *jobRepositoryImpl) FindByID(id int) (*domain.Job, error) {
+	return r.findOne("SELECT "+jobColumns+" FROM jobs WHERE id = ? AND deleted_at IS NULL", id)
+}
+
+// FindByIDIncludingDeleted retrieves a single job by its ID, even if it is soft-deleted
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - domain.ErrJobNotFound if the job does not exist, or an error if the query fails
+func (r *jobRepositoryImpl) FindByIDIncludingDeleted(id int) (*domain.Job, error) {
+	return r.findOne("SELECT "+jobColumns+" FROM jobs WHERE id = ?", id)
+}
+
+// findOne runs a query selecting a single job and loads its skills
+func (r *jobRepositoryImpl) findOne(query string, args ...interface{}) (*domain.Job, error) {
+	job, err := scanJob(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.attachSkills([]*domain.Job{job}); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// attachSkills loads the skill tags of the given jobs with a single query
+func (r *jobRepositoryImpl) attachSkills(jobs []*domain.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domain.Job, len(jobs))
+	args := make([]interface{}, 0, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+		args = append(args, job.ID)
+	}
+
+	query := "SELECT js.job_id, s.id, s.name, js.requirement FROM job_skills js JOIN skills s ON s.id = js.skill_id" +
+		" WHERE js.job_id IN (" + placeholders(len(args)) + ") ORDER BY js.requirement DESC, s.name"
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int
+		var skill domain.JobSkill
+		if err := rows.Scan(&jobID, &skill.SkillID, &skill.Name, &skill.Requirement); err != nil {
+			return err
+		}
+		if job, ok := byID[jobID]; ok {
+			job.Skills = append(job.Skills, skill)
+		}
+	}
+	return rows.Err()
+}
+
+// CountFacets counts the jobs per classification value for the given filter
+// Each facet ignores its own dimension of the filter, so selecting a department
+// still reports the counts of the other departments.
+// @param filter domain.JobFilter - The criteria the counted jobs must match
+// @return *domain.JobFacets - The facet counts
+// @return error - An error if the query fails
+func (r *jobRepositoryImpl) CountFacets(filter domain.JobFilter) (*domain.JobFacets, error) {
+	facets := &domain.JobFacets{}
+	var err error
+	if facets.EmploymentType, err = r.countFacet(filter, "employment_type"); err != nil {
+		return nil, err
+	}
+	if facets.Seniority, err = r.countFacet(filter, "seniority"); err != nil {
+		return nil, err
+	}
+	if facets.Department, err = r.countFacet(filter, "department"); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+// countFacet groups the jobs matching filter by the given column
+func (r *jobRepositoryImpl) countFacet(filter domain.JobFilter, column string) (map[string]int, error) {
+	where, args := jobFilterClause(filter, column)
+	if where == "" {
+		where = " WHERE "
+	} else {
+		where += " AND "
+	}
+	query := "SELECT " + column + ", COUNT(*) FROM jobs" + where + column + " <> '' GROUP BY " + column
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var value string
+		var count int
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, err
+		}
+		counts[value] = count
+	}
+	return counts, rows.Err()
+}
+
 // Create inserts a new job into the database
 // Executes an INSERT query to add a new job record to the jobs table.
 // @param job *domain.Job - The job data to be inserted
 // @return error - An error if the query fails
 func (r *jobRepositoryImpl) Create(job *domain.Job) error {
-	query := "INSERT INTO jobs (title, description, salary_range) VALUES (?, ?, ?)"
-	_, err := r.db.Exec(query, job.Title, job.Description, job.SalaryRange)
-	return err // Return error if the query fails
+	query := "INSERT INTO jobs (title, description, salary_range, employment_type, seniority, department, status, publish_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, job.Title, job.Description, job.SalaryRange, job.EmploymentType, job.Seniority, job.Department, job.Status,
+		nullTimestamp(job.PublishAt), nullTimestamp(job.ExpiresAt))
+	if err != nil {
+		return err // Return error if the query fails
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	job.ID = int(id) // Expose the generated ID to the caller
+	return nil
+}
+
+// CreateBatch inserts several jobs with one multi-row INSERT and sets their IDs
+// A multi-row INSERT with a known row count is a "simple insert" for InnoDB, which reserves
+// consecutive auto-increment values for it, so the IDs follow the first generated one.
+// @param jobs []*domain.Job - The jobs to be inserted
+// @return error - An error if the query fails
+func (r *jobRepositoryImpl) CreateBatch(jobs []*domain.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	query := "INSERT INTO jobs (title, description, salary_range, employment_type, seniority, department, status, publish_at, expires_at) VALUES "
+	args := make([]interface{}, 0, len(jobs)*9)
+	for i, job := range jobs {
+		if i > 0 {
+			query += ", "
+		}
+		query += "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args, job.Title, job.Description, job.SalaryRange, job.EmploymentType, job.Seniority, job.Department, job.Status,
+			nullTimestamp(job.PublishAt), nullTimestamp(job.ExpiresAt))
+	}
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	first, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, job := range jobs {
+		job.ID = int(first) + i
+	}
+	return nil
+}
+
+// FindScheduleDue retrieves the live jobs whose publication schedule requires a status change
+// Skill tags are not loaded.
+// @param now time.Time - The current time
+// @param limit int - The maximum number of jobs returned
+// @return []*domain.Job - Drafts past their publish_at and published jobs past their expires_at, in ID order
+// @return error - An error if the query fails
+func (r *jobRepositoryImpl) FindScheduleDue(now time.Time, limit int) ([]*domain.Job, error) {
+	current := now.UTC().Format(mysqlTimeLayout)
+	query := "SELECT " + jobColumns + " FROM jobs WHERE deleted_at IS NULL" +
+		" AND ((status = ? AND publish_at <= ?) OR (status = ? AND expires_at <= ?)) ORDER BY id LIMIT ?"
+	rows, err := r.db.Query(query, domain.JobStatusDraft, current, domain.JobStatusPublished, current, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*domain.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Update modifies an existing job in the database if it is still at the version the caller read
+// Skill tags and status are not touched; they are managed through the SkillRepository and UpdateStatus.
+// Executes an UPDATE query on the row identified by job.ID and job.Version, incrementing the version,
+// and sets job.Version to the new version; soft-deleted jobs cannot be updated.
+// @param job *domain.Job - The job data to be stored, identified by its ID; a zero Version skips the check
+// @return error - domain.ErrJobNotFound if the job does not exist, domain.ErrVersionConflict if its version differs, or an error if the query fails
+func (r *jobRepositoryImpl) Update(job *domain.Job) error {
+	query := "UPDATE jobs SET title = ?, description = ?, salary_range = ?, employment_type = ?, seniority = ?, department = ?," +
+		" publish_at = ?, expires_at = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL"
+	args := []interface{}{job.Title, job.Description, job.SalaryRange, job.EmploymentType, job.Seniority, job.Department,
+		nullTimestamp(job.PublishAt), nullTimestamp(job.ExpiresAt), job.ID}
+	if job.Version > 0 {
+		query += " AND version = ?"
+		args = append(args, job.Version)
+	}
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// The version always changes, so no row means a missing job or a stale version
+		if _, err := r.FindByID(job.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+	if job.Version > 0 {
+		job.Version++
+		return nil
+	}
+	return r.db.QueryRow("SELECT version FROM jobs WHERE id = ?", job.ID).Scan(&job.Version)
+}
+
+// UpdateStatus changes the status of a job if it is still at the version the caller read
+// @param id int - The ID of the job
+// @param status string - The new status
+// @param version int - The version the job must be at; 0 skips the check
+// @return error - domain.ErrJobNotFound if the job does not exist, domain.ErrVersionConflict if its version differs, or an error if the query fails
+func (r *jobRepositoryImpl) UpdateStatus(id int, status string, version int) error {
+	return r.execVersioned(id, version,
+		"UPDATE jobs SET status = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", status, id)
+}
+
+// Delete soft-deletes a job by setting its deleted_at timestamp, if it is still at the version the caller read
+// The row and its skill tags are kept until PurgeDeleted removes them.
+// @param id int - The ID of the job
+// @param version int - The version the job must be at; 0 skips the check
+// @return error - domain.ErrJobNotFound if the job does not exist or is already deleted, domain.ErrVersionConflict if its version differs, or an error if the query fails
+func (r *jobRepositoryImpl) Delete(id int, version int) error {
+	return r.execVersioned(id, version,
+		"UPDATE jobs SET deleted_at = CURRENT_TIMESTAMP, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", id)
+}
+
+// Restore clears the deleted_at timestamp of a soft-deleted job
+// @param id int - The ID of the job
+// @return error - domain.ErrJobNotFound if the job does not exist or is not deleted, or an error if the query fails
+func (r *jobRepositoryImpl) Restore(id int) error {
+	return r.execOne("UPDATE jobs SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NOT NULL", id)
+}
+
+// PurgeDeleted permanently removes the jobs soft-deleted before a point in time
+// Skill tags go with them through the ON DELETE CASCADE foreign key. deleted_at is set by the
+// server, so the comparison relies on the session running in UTC, as db.Connect sets it.
+// @param before time.Time - Jobs deleted before this instant are removed
+// @return int64 - The number of removed jobs
+// @return error - An error if the query fails
+func (r *jobRepositoryImpl) PurgeDeleted(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM jobs WHERE deleted_at IS NOT NULL AND deleted_at < ?", before.UTC().Format(mysqlTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// execVersioned runs a statement changing one live job, restricted to the given version unless it is 0
+// It returns domain.ErrVersionConflict when the job exists at another version and domain.ErrJobNotFound when it does not.
+func (r *jobRepositoryImpl) execVersioned(id, version int, query string, args ...interface{}) error {
+	if version > 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	err := r.execOne(query, args...)
+	if version > 0 && errors.Is(err, domain.ErrJobNotFound) {
+		if _, err := r.FindByID(id); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+	return err
+}
+
+// execOne runs a statement expected to change exactly one job, returning domain.ErrJobNotFound otherwise
+func (r *jobRepositoryImpl) execOne(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrJobNotFound
+	}
+	return nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanJob maps a row selected with jobColumns to a Job struct
+func scanJob(row rowScanner) (*domain.Job, error) {
+	var job domain.Job
+	var publishAt, expiresAt, createdAt, updatedAt, deletedAt []uint8 // Temporary variables to handle MySQL DATETIME/TIMESTAMP as []uint8
+	if err := row.Scan(&job.ID, &job.Title, &job.Description, &job.SalaryRange, &job.EmploymentType, &job.Seniority,
+		&job.Department, &job.Status, &publishAt, &expiresAt, &job.Version, &createdAt, &updatedAt, &deletedAt); err != nil {
+		return nil, err
+	}
+	// Convert []uint8 to time.Time
+	job.PublishAt = parseNullTimestamp(publishAt)
+	job.ExpiresAt = parseNullTimestamp(expiresAt)
+	job.CreatedAt = parseTimestamp(createdAt)
+	job.UpdatedAt = parseTimestamp(updatedAt)
+	job.DeletedAt = parseNullTimestamp(deletedAt)
+	return &job, nil
+}
+
+// jobFilterClause builds the WHERE clause and its arguments for a job filter
+// The column named by skip is left out, which is how facets ignore their own dimension.
+func jobFilterClause(filter domain.JobFilter, skip string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	add := func(column, value string) {
+		if value == "" || column == skip {
+			return
+		}
+		conditions = append(conditions, column+" = ?")
+		args = append(args, value)
+	}
+	add("employment_type", filter.EmploymentType)
+	add("seniority", filter.Seniority)
+	add("department", filter.Department)
+	add("status", filter.Status)
+
+	if len(filter.Skills) > 0 {
+		// Skill names are expected in their canonical form; the service resolves aliases
+		subquery := "SELECT js.job_id FROM job_skills js JOIN skills s ON s.id = js.skill_id WHERE s.name IN (" + placeholders(len(filter.Skills)) + ")"
+		for _, skill := range filter.Skills {
+			args = append(args, skill)
+		}
+		if filter.SkillMatch == domain.SkillMatchAll {
+			subquery += " GROUP BY js.job_id HAVING COUNT(DISTINCT s.id) = ?"
+			args = append(args, len(filter.Skills))
+		}
+		conditions = append(conditions, "id IN ("+subquery+")")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/stretchr/testify/mock"
 )
@@ -26,3 +28,108 @@ func (m *MockJobRepository) Create(job *domain.Job) error {
 	args := m.Called(job)
 	return args.Error(0)
 }
+
+// StreamByFilter mocks the StreamByFilter method
+// Calls fn for each job passed as first return value.
+func (m *MockJobRepository) StreamByFilter(filter domain.JobFilter, fn func(job *domain.Job) error) error {
+	args := m.Called(filter, fn)
+	if jobs, ok := args.Get(0).([]*domain.Job); ok {
+		for _, job := range jobs {
+			if err := fn(job); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+// CreateBatch mocks the CreateBatch method
+func (m *MockJobRepository) CreateBatch(jobs []*domain.Job) error {
+	args := m.Called(jobs)
+	return args.Error(0)
+}
+
+// FindByFilter mocks the FindByFilter method
+// Simulates the retrieval of the jobs matching a filter
+func (m *MockJobRepository) FindByFilter(filter domain.JobFilter) ([]*domain.Job, error) {
+	args := m.Called(filter)
+	if jobs, ok := args.Get(0).([]*domain.Job); ok {
+		return jobs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindByID mocks the FindByID method
+// Simulates the retrieval of a single job
+func (m *MockJobRepository) FindByID(id int) (*domain.Job, error) {
+	args := m.Called(id)
+	if job, ok := args.Get(0).(*domain.Job); ok {
+		return job, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// CountFacets mocks the CountFacets method
+// Simulates the facet counts of a filter
+func (m *MockJobRepository) CountFacets(filter domain.JobFilter) (*domain.JobFacets, error) {
+	args := m.Called(filter)
+	if facets, ok := args.Get(0).(*domain.JobFacets); ok {
+		return facets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindScheduleDue mocks the FindScheduleDue method
+// Simulates the retrieval of the jobs due for a scheduled status change
+func (m *MockJobRepository) FindScheduleDue(now time.Time, limit int) ([]*domain.Job, error) {
+	args := m.Called(now, limit)
+	if jobs, ok := args.Get(0).([]*domain.Job); ok {
+		return jobs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Update mocks the Update method
+// Simulates the modification of an existing job
+func (m *MockJobRepository) Update(job *domain.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+// FindByIDIncludingDeleted mocks the FindByIDIncludingDeleted method
+// Simulates the retrieval of a single job, soft-deleted or not
+func (m *MockJobRepository) FindByIDIncludingDeleted(id int) (*domain.Job, error) {
+	args := m.Called(id)
+	if job, ok := args.Get(0).(*domain.Job); ok {
+		return job, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Delete mocks the Delete method
+// Simulates the soft deletion of a job
+func (m *MockJobRepository) Delete(id int, version int) error {
+	args := m.Called(id, version)
+	return args.Error(0)
+}
+
+// Restore mocks the Restore method
+// Simulates the restoration of a soft-deleted job
+func (m *MockJobRepository) Restore(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// PurgeDeleted mocks the PurgeDeleted method
+// Simulates the permanent removal of old soft-deleted jobs
+func (m *MockJobRepository) PurgeDeleted(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// UpdateStatus mocks the UpdateStatus method
+// Simulates the status change of a job
+func (m *MockJobRepository) UpdateStatus(id int, status string, version int) error {
+	args := m.Called(id, status, version)
+	return args.Error(0)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+// LeaseRepository defines methods for accessing the leases table
+// A lease elects the single replica running a background task; it must be renewed before it
+// expires, otherwise another replica takes it over.
+type LeaseRepository interface {
+	// TryAcquire takes or renews a lease
+	// @param name string - The name of the lease
+	// @param holder string - The identity of the caller
+	// @param now time.Time - The current time
+	// @param ttl time.Duration - How long the lease is held unless renewed
+	// @return bool - True if the caller holds the lease until now+ttl
+	// @return error - An error if the query fails
+	TryAcquire(name, holder string, now time.Time, ttl time.Duration) (bool, error)
+
+	// Release gives up a lease held by the caller so another replica can take it at once
+	// @param name string - The name of the lease
+	// @param holder string - The identity of the caller
+	// @return error - An error if the query fails
+	Release(name, holder string) error
+}
+
+type leaseRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewLeaseRepository creates a new LeaseRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return LeaseRepository - The implementation of the repository
+func NewLeaseRepository(db *sql.DB) LeaseRepository {
+	return &leaseRepositoryImpl{db: db}
+}
+
+// TryAcquire takes or renews a lease
+// The lease is taken over only once it has expired, so a single replica holds it at any time
+// provided the clocks of the replicas agree to well within the ttl.
+// @param name string - The name of the lease
+// @param holder string - The identity of the caller
+// @param now time.Time - The current time
+// @param ttl time.Duration - How long the lease is held unless renewed
+// @return bool - True if the caller holds the lease until now+ttl
+// @return error - An error if the query fails
+func (r *leaseRepositoryImpl) TryAcquire(name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	until := now.Add(ttl).UTC().Format(mysqlTimeLayout)
+	result, err := r.db.Exec("UPDATE leases SET holder = ?, expires_at = ? WHERE name = ? AND (holder = ? OR expires_at <= ?)",
+		holder, until, name, holder, now.UTC().Format(mysqlTimeLayout))
+	if err != nil {
+		return false, err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated > 0 {
+		return updated > 0, err
+	}
+
+	// Either the lease is held by another replica, or it was never taken
+	result, err = r.db.Exec("INSERT IGNORE INTO leases (name, holder, expires_at) VALUES (?, ?, ?)", name, holder, until)
+	if err != nil {
+		return false, err
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted > 0 {
+		return inserted > 0, err
+	}
+
+	// MySQL counts unchanged rows as not affected, so a renewal within the same second looks like a miss
+	var current string
+	err = r.db.QueryRow("SELECT holder FROM leases WHERE name = ?", name).Scan(&current)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil && current == holder, err
+}
+
+// Release gives up a lease held by the caller so another replica can take it at once
+// @param name string - The name of the lease
+// @param holder string - The identity of the caller
+// @return error - An error if the query fails
+func (r *leaseRepositoryImpl) Release(name, holder string) error {
+	_, err := r.db.Exec("UPDATE leases SET expires_at = '1970-01-01 00:00:00' WHERE name = ? AND holder = ?", name, holder)
+	return err
+}
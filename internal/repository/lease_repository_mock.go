@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLeaseRepository is a mock implementation of LeaseRepository for testing
+type MockLeaseRepository struct {
+	mock.Mock
+}
+
+// TryAcquire mocks the TryAcquire method
+func (m *MockLeaseRepository) TryAcquire(name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	args := m.Called(name, holder, now, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+// Release mocks the Release method
+func (m *MockLeaseRepository) Release(name, holder string) error {
+	args := m.Called(name, holder)
+	return args.Error(0)
+}
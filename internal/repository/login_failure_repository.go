@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/pkg/loginguard"
+)
+
+// loginFailureSweepInterval is how often the expired failure records are removed
+const loginFailureSweepInterval = time.Hour
+
+// LoginFailureRepository defines methods for accessing the login_failures table
+// It implements loginguard.Store, so an account locked on one replica is locked on all of them.
+type LoginFailureRepository interface {
+	// Reserve counts an attempt under key, unless allow rejects the current record
+	// @param key string - The account or IP key
+	// @param now time.Time - The time of the attempt
+	// @param ttl time.Duration - How long the record is kept after now
+	// @param allow func(loginguard.Record) bool - Tells whether the attempt may proceed given the current record
+	// @return loginguard.Record - The updated record, or the current one if the attempt was rejected
+	// @return bool - True if the attempt was counted
+	// @return error - An error if the query fails
+	Reserve(key string, now time.Time, ttl time.Duration, allow func(loginguard.Record) bool) (loginguard.Record, bool, error)
+
+	// Release uncounts an attempt reserved under key
+	// @param key string - The account or IP key
+	// @return error - An error if the query fails
+	Release(key string) error
+
+	// Reset forgets the failures counted under key
+	// @param key string - The account or IP key
+	// @return error - An error if the query fails
+	Reset(key string) error
+}
+
+type loginFailureRepositoryImpl struct {
+	db *sql.DB // Database connection; each Reserve runs in its own transaction
+
+	mu        sync.Mutex
+	lastSweep time.Time // When expired records were last removed by this replica
+}
+
+// NewLoginFailureRepository creates a new LoginFailureRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return LoginFailureRepository - The implementation of the repository
+func NewLoginFailureRepository(db *sql.DB) LoginFailureRepository {
+	return &loginFailureRepositoryImpl{db: db}
+}
+
+// Reserve counts an attempt under key, unless allow rejects the current record
+// The row is created if missing and locked with SELECT ... FOR UPDATE until the transaction ends,
+// so concurrent attempts on any replica are checked one after the other.
+// @param key string - The account or IP key
+// @param now time.Time - The time of the attempt
+// @param ttl time.Duration - How long the record is kept after now
+// @param allow func(loginguard.Record) bool - Tells whether the attempt may proceed given the current record
+// @return loginguard.Record - The updated record, or the current one if the attempt was rejected
+// @return bool - True if the attempt was counted
+// @return error - An error if the query fails
+func (r *loginFailureRepositoryImpl) Reserve(key string, now time.Time, ttl time.Duration, allow func(loginguard.Record) bool) (loginguard.Record, bool, error) {
+	r.sweep(now)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return loginguard.Record{}, false, err
+	}
+	defer tx.Rollback() // No-op once committed
+
+	// An empty, already expired row stands for a missing record, so there is always a row to lock
+	if _, err := tx.Exec("INSERT INTO login_failures (failure_key, failures, last_failure, expires_at) VALUES (?, 0, 0, 0)"+
+		" ON DUPLICATE KEY UPDATE failure_key = failure_key", key); err != nil {
+		return loginguard.Record{}, false, err
+	}
+	var record loginguard.Record
+	var lastFailure, expiresAt int64
+	if err := tx.QueryRow("SELECT failures, last_failure, expires_at FROM login_failures WHERE failure_key = ? FOR UPDATE",
+		key).Scan(&record.Failures, &lastFailure, &expiresAt); err != nil {
+		return loginguard.Record{}, false, err
+	}
+	if expiresAt <= now.UnixMicro() {
+		record = loginguard.Record{}
+	} else {
+		record.LastFailure = time.UnixMicro(lastFailure)
+	}
+	if !allow(record) {
+		return record, false, tx.Commit()
+	}
+
+	record.Failures++
+	record.LastFailure = now
+	if _, err := tx.Exec("UPDATE login_failures SET failures = ?, last_failure = ?, expires_at = ? WHERE failure_key = ?",
+		record.Failures, now.UnixMicro(), now.Add(ttl).UnixMicro(), key); err != nil {
+		return loginguard.Record{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return loginguard.Record{}, false, err
+	}
+	return record, true, nil
+}
+
+// Release uncounts an attempt reserved under key
+// @param key string - The account or IP key
+// @return error - An error if the query fails
+func (r *loginFailureRepositoryImpl) Release(key string) error {
+	_, err := r.db.Exec("UPDATE login_failures SET failures = failures - 1 WHERE failure_key = ? AND failures > 0", key)
+	return err
+}
+
+// Reset forgets the failures counted under key
+// @param key string - The account or IP key
+// @return error - An error if the query fails
+func (r *loginFailureRepositoryImpl) Reset(key string) error {
+	_, err := r.db.Exec("DELETE FROM login_failures WHERE failure_key = ?", key)
+	return err
+}
+
+// sweep removes the expired records, at most once per loginFailureSweepInterval
+// Failures are ignored; the next sweep retries.
+func (r *loginFailureRepositoryImpl) sweep(now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.lastSweep) < loginFailureSweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	r.mu.Unlock()
+
+	r.db.Exec("DELETE FROM login_failures WHERE expires_at <= ?", now.UnixMicro())
+}
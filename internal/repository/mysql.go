@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlTimeLayout is the layout MySQL uses for DATETIME/TIMESTAMP columns
+const mysqlTimeLayout = "2006-01-02 15:04:05"
+
+// mysqlDuplicateEntry is the MySQL error number for unique key violations
+const mysqlDuplicateEntry = 1062
+
+// parseTimestamp converts a raw MySQL DATETIME/TIMESTAMP value to time.Time
+// The driver returns these columns as []uint8 because the DSN does not enable parseTime.
+func parseTimestamp(raw []uint8) time.Time {
+	t, _ := time.Parse(mysqlTimeLayout, string(raw))
+	return t
+}
+
+// parseNullTimestamp converts a nullable raw MySQL DATETIME/TIMESTAMP value to *time.Time
+// NULL columns scan as a nil slice and are returned as nil.
+func parseNullTimestamp(raw []uint8) *time.Time {
+	if raw == nil {
+		return nil
+	}
+	t := parseTimestamp(raw)
+	return &t
+}
+
+// nullTimestamp formats an optional time as a MySQL DATETIME argument in UTC, nil becoming NULL
+func nullTimestamp(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(mysqlTimeLayout)
+}
+
+// isDuplicateEntry reports whether err is a MySQL unique key violation
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// placeholders returns n comma separated "?" placeholders for an IN clause
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// OutboxRepository defines methods for accessing the outbox_events table
+// Events are enqueued in the transaction of the change that produced them and
+// claimed by the relay, which marks them delivered or schedules a retry.
+type OutboxRepository interface {
+	// Enqueue stores an event for delivery
+	// @param event *domain.Event - The event to be delivered
+	// @return error - An error if the query fails
+	Enqueue(event *domain.Event) error
+
+	// Claim leases up to limit pending events whose next attempt is due, oldest first
+	// Claimed events are hidden from other relays until the lease expires, so an event
+	// whose relay crashes is delivered again.
+	// @param now time.Time - The current time
+	// @param limit int - Maximum number of events to claim
+	// @param lease time.Duration - How long the events stay claimed
+	// @return []*domain.OutboxMessage - The claimed events
+	// @return error - An error if the query fails
+	Claim(now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error)
+
+	// MarkDelivered records the successful delivery of an event
+	// @param seq int64 - The outbox position of the event
+	// @param now time.Time - The delivery time
+	// @return error - An error if the query fails
+	MarkDelivered(seq int64, now time.Time) error
+
+	// MarkFailed records a failed delivery and schedules the next attempt
+	// @param seq int64 - The outbox position of the event
+	// @param reason string - The delivery error
+	// @param next time.Time - Earliest time of the next attempt
+	// @return error - An error if the query fails
+	MarkFailed(seq int64, reason string, next time.Time) error
+}
+
+type outboxRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return OutboxRepository - The implementation of the repository
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return &outboxRepositoryImpl{db: db}
+}
+
+// Enqueue stores an event for delivery
+// The event is due immediately.
+// @param event *domain.Event - The event to be delivered
+// @return error - An error if the query fails
+func (r *outboxRepositoryImpl) Enqueue(event *domain.Event) error {
+	query := "INSERT INTO outbox_events (event_id, event_type, aggregate_type, aggregate_id, payload, occurred_at, next_attempt_at)" +
+		" VALUES (?, ?, ?, ?, ?, ?, ?)"
+	occurredAt := event.OccurredAt.UTC().Format(mysqlTimeLayout)
+	_, err := r.db.Exec(query, event.ID, event.Type, event.AggregateType, event.AggregateID, []byte(event.Payload),
+		occurredAt, occurredAt)
+	return err
+}
+
+// Claim leases up to limit pending events whose next attempt is due, oldest first
+// The rows are tagged with a random claim token in a single UPDATE, so concurrent relays never claim the same event.
+// @param now time.Time - The current time
+// @param limit int - Maximum number of events to claim
+// @param lease time.Duration - How long the events stay claimed
+// @return []*domain.OutboxMessage - The claimed events
+// @return error - An error if the query fails
+func (r *outboxRepositoryImpl) Claim(now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	token, err := claimToken()
+	if err != nil {
+		return nil, err
+	}
+	current := now.UTC().Format(mysqlTimeLayout)
+	claim := "UPDATE outbox_events SET claim_token = ?, locked_until = ?" +
+		" WHERE delivered_at IS NULL AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until <= ?)" +
+		" ORDER BY id LIMIT ?"
+	result, err := r.db.Exec(claim, token, now.Add(lease).UTC().Format(mysqlTimeLayout), current, current, limit)
+	if err != nil {
+		return nil, err
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return []*domain.OutboxMessage{}, err
+	}
+
+	query := "SELECT id, event_id, event_type, aggregate_type, aggregate_id, payload, occurred_at, attempts, next_attempt_at, last_error" +
+		" FROM outbox_events WHERE claim_token = ? ORDER BY id"
+	rows, err := r.db.Query(query, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*domain.OutboxMessage{}
+	for rows.Next() {
+		var message domain.OutboxMessage
+		var payload, occurredAt, nextAttemptAt []uint8
+		if err := rows.Scan(&message.Seq, &message.Event.ID, &message.Event.Type, &message.Event.AggregateType,
+			&message.Event.AggregateID, &payload, &occurredAt, &message.Attempts, &nextAttemptAt, &message.LastError); err != nil {
+			return nil, err
+		}
+		message.Event.Payload = payload
+		message.Event.OccurredAt = parseTimestamp(occurredAt)
+		message.NextAttemptAt = parseTimestamp(nextAttemptAt)
+		messages = append(messages, &message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkDelivered records the successful delivery of an event
+// @param seq int64 - The outbox position of the event
+// @param now time.Time - The delivery time
+// @return error - An error if the query fails
+func (r *outboxRepositoryImpl) MarkDelivered(seq int64, now time.Time) error {
+	query := "UPDATE outbox_events SET delivered_at = ?, claim_token = NULL, locked_until = NULL WHERE id = ?"
+	_, err := r.db.Exec(query, now.UTC().Format(mysqlTimeLayout), seq)
+	return err
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt
+// @param seq int64 - The outbox position of the event
+// @param reason string - The delivery error
+// @param next time.Time - Earliest time of the next attempt
+// @return error - An error if the query fails
+func (r *outboxRepositoryImpl) MarkFailed(seq int64, reason string, next time.Time) error {
+	query := "UPDATE outbox_events SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?," +
+		" claim_token = NULL, locked_until = NULL WHERE id = ?"
+	_, err := r.db.Exec(query, reason, next.UTC().Format(mysqlTimeLayout), seq)
+	return err
+}
+
+// claimToken returns a random token identifying one claim of queued rows
+func claimToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOutboxRepository is a mock implementation of OutboxRepository for testing
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+// Enqueue mocks the Enqueue method
+func (m *MockOutboxRepository) Enqueue(event *domain.Event) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+// Claim mocks the Claim method
+func (m *MockOutboxRepository) Claim(now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	args := m.Called(now, limit, lease)
+	if messages, ok := args.Get(0).([]*domain.OutboxMessage); ok {
+		return messages, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MarkDelivered mocks the MarkDelivered method
+func (m *MockOutboxRepository) MarkDelivered(seq int64, now time.Time) error {
+	args := m.Called(seq, now)
+	return args.Error(0)
+}
+
+// MarkFailed mocks the MarkFailed method
+func (m *MockOutboxRepository) MarkFailed(seq int64, reason string, next time.Time) error {
+	args := m.Called(seq, reason, next)
+	return args.Error(0)
+}
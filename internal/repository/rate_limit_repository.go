@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/pkg/ratelimit"
+)
+
+// Stale buckets are removed every rateLimitSweepInterval once idle for rateLimitIdle
+const (
+	rateLimitSweepInterval = time.Hour
+	rateLimitIdle          = 24 * time.Hour
+)
+
+// RateLimitRepository defines methods for accessing the rate_limit_buckets table
+// It implements ratelimit.Store, so the replicas share the request budget of each client.
+type RateLimitRepository interface {
+	// Take takes a token from the bucket stored under key
+	// @param key string - The bucket
+	// @param limit ratelimit.Limit - The size and refill rate of the bucket
+	// @param now time.Time - The time of the request
+	// @return ratelimit.Result - Whether the request is allowed
+	// @return error - An error if the query fails
+	Take(key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error)
+}
+
+type rateLimitRepositoryImpl struct {
+	db *sql.DB // Database connection; each Take runs in its own transaction
+
+	mu        sync.Mutex
+	lastSweep time.Time // When stale buckets were last removed by this replica
+}
+
+// NewRateLimitRepository creates a new RateLimitRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return RateLimitRepository - The implementation of the repository
+func NewRateLimitRepository(db *sql.DB) RateLimitRepository {
+	return &rateLimitRepositoryImpl{db: db}
+}
+
+// Take takes a token from the bucket stored under key
+// The bucket row is locked for the duration of the transaction, so concurrent requests of a
+// client on different replicas are counted one after the other.
+// @param key string - The bucket
+// @param limit ratelimit.Limit - The size and refill rate of the bucket
+// @param now time.Time - The time of the request
+// @return ratelimit.Result - Whether the request is allowed
+// @return error - An error if the query fails
+func (r *rateLimitRepositoryImpl) Take(key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	r.sweep(now)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	defer tx.Rollback() // No-op once committed
+
+	// A new bucket starts full; INSERT IGNORE leaves an existing one untouched
+	if _, err := tx.Exec("INSERT IGNORE INTO rate_limit_buckets (bucket_key, tokens, updated_at) VALUES (?, ?, 0)",
+		key, float64(limit.Requests)); err != nil {
+		return ratelimit.Result{}, err
+	}
+	var state ratelimit.State
+	var updatedAt int64
+	err = tx.QueryRow("SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = ? FOR UPDATE", key).
+		Scan(&state.Tokens, &updatedAt)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	if updatedAt > 0 {
+		state.UpdatedAt = time.UnixMicro(updatedAt)
+	}
+
+	state, result := limit.Take(state, now)
+	if _, err := tx.Exec("UPDATE rate_limit_buckets SET tokens = ?, updated_at = ? WHERE bucket_key = ?",
+		state.Tokens, state.UpdatedAt.UnixMicro(), key); err != nil {
+		return ratelimit.Result{}, err
+	}
+	return result, tx.Commit()
+}
+
+// sweep removes the buckets idle for longer than rateLimitIdle, at most once per rateLimitSweepInterval
+// Such buckets have refilled for any limit with a period up to a day, so dropping them changes nothing.
+// Failures are ignored; the next sweep retries.
+func (r *rateLimitRepositoryImpl) sweep(now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.lastSweep) < rateLimitSweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	r.mu.Unlock()
+
+	r.db.Exec("DELETE FROM rate_limit_buckets WHERE updated_at < ?", now.Add(-rateLimitIdle).UnixMicro())
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// RefreshTokenRepository defines methods for accessing the refresh_tokens table
+type RefreshTokenRepository interface {
+	// Create inserts a new refresh token and sets its ID and creation time
+	// @param token *domain.RefreshToken - The token to be stored, with its hash and family
+	// @return error - An error if the query fails
+	Create(token *domain.RefreshToken) error
+
+	// FindByHash retrieves a refresh token by the hash of its value
+	// @param hash string - The SHA-256 of the token
+	// @return *domain.RefreshToken - The token
+	// @return error - domain.ErrInvalidRefreshToken if no token has the hash, or an error if the query fails
+	FindByHash(hash string) (*domain.RefreshToken, error)
+
+	// MarkUsed records that a refresh token was exchanged, unless it already was or is revoked
+	// @param id int - The ID of the token
+	// @param at time.Time - The time of the exchange
+	// @return error - domain.ErrInvalidRefreshToken if the token was already used or is revoked, or an error if the query fails
+	MarkUsed(id int, at time.Time) error
+
+	// RevokeFamily revokes every token descending from the same login
+	// @param familyID string - The family of the tokens
+	// @param at time.Time - The revocation time
+	// @return error - An error if the query fails
+	RevokeFamily(familyID string, at time.Time) error
+
+	// RevokeUser revokes the families of a user started at or before a cutoff
+	// @param userID int - The owner of the tokens
+	// @param issuedBefore time.Time - Families whose login happened at or before this time are revoked
+	// @param at time.Time - The revocation time
+	// @return error - An error if the query fails
+	RevokeUser(userID int, issuedBefore, at time.Time) error
+
+	// DeleteExpired removes the tokens past their expiry
+	// @param before time.Time - Tokens expired before this time are removed
+	// @return int64 - The number of tokens removed
+	// @return error - An error if the query fails
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type refreshTokenRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return RefreshTokenRepository - The implementation of the repository
+func NewRefreshTokenRepository(db *sql.DB) RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{db: db}
+}
+
+// Create inserts a new refresh token and sets its ID and creation time
+// @param token *domain.RefreshToken - The token to be stored, with its hash, family and family issue time
+// @return error - An error if the query fails
+func (r *refreshTokenRepositoryImpl) Create(token *domain.RefreshToken) error {
+	token.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	var familyIssuedAt *time.Time // NULL when unknown
+	if !token.FamilyIssuedAt.IsZero() {
+		familyIssuedAt = &token.FamilyIssuedAt
+	}
+	query := "INSERT INTO refresh_tokens (user_id, family_id, family_issued_at, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, token.UserID, token.FamilyID, nullTimestamp(familyIssuedAt), token.TokenHash,
+		nullTimestamp(&token.ExpiresAt), token.CreatedAt.Format(mysqlTimeLayout))
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	token.ID = int(id)
+	return nil
+}
+
+// FindByHash retrieves a refresh token by the hash of its value
+// @param hash string - The SHA-256 of the token
+// @return *domain.RefreshToken - The token
+// @return error - domain.ErrInvalidRefreshToken if no token has the hash, or an error if the query fails
+func (r *refreshTokenRepositoryImpl) FindByHash(hash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	var familyIssuedAt, expiresAt, usedAt, revokedAt, createdAt []uint8
+	query := "SELECT id, user_id, family_id, family_issued_at, token_hash, expires_at, used_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = ?"
+	err := r.db.QueryRow(query, hash).Scan(&token.ID, &token.UserID, &token.FamilyID, &familyIssuedAt, &token.TokenHash,
+		&expiresAt, &usedAt, &revokedAt, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	token.FamilyIssuedAt = parseTimestamp(familyIssuedAt)
+	token.ExpiresAt = parseTimestamp(expiresAt)
+	token.UsedAt = parseNullTimestamp(usedAt)
+	token.RevokedAt = parseNullTimestamp(revokedAt)
+	token.CreatedAt = parseTimestamp(createdAt)
+	return &token, nil
+}
+
+// MarkUsed records that a refresh token was exchanged, unless it already was or is revoked
+// The condition makes concurrent refreshes with the same token succeed at most once.
+// @param id int - The ID of the token
+// @param at time.Time - The time of the exchange
+// @return error - domain.ErrInvalidRefreshToken if the token was already used or is revoked, or an error if the query fails
+func (r *refreshTokenRepositoryImpl) MarkUsed(id int, at time.Time) error {
+	result, err := r.db.Exec("UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL",
+		nullTimestamp(&at), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+// RevokeFamily revokes every token descending from the same login
+// @param familyID string - The family of the tokens
+// @param at time.Time - The revocation time
+// @return error - An error if the query fails
+func (r *refreshTokenRepositoryImpl) RevokeFamily(familyID string, at time.Time) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL",
+		nullTimestamp(&at), familyID)
+	return err
+}
+
+// RevokeUser revokes the families of a user started at or before a cutoff
+// Families without a known start are revoked too.
+// @param userID int - The owner of the tokens
+// @param issuedBefore time.Time - Families whose login happened at or before this time are revoked
+// @param at time.Time - The revocation time
+// @return error - An error if the query fails
+func (r *refreshTokenRepositoryImpl) RevokeUser(userID int, issuedBefore, at time.Time) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? "+
+		"AND (family_issued_at IS NULL OR family_issued_at <= ?) AND revoked_at IS NULL",
+		nullTimestamp(&at), userID, nullTimestamp(&issuedBefore))
+	return err
+}
+
+// DeleteExpired removes the tokens past their expiry
+// @param before time.Time - Tokens expired before this time are removed
+// @return int64 - The number of tokens removed
+// @return error - An error if the query fails
+func (r *refreshTokenRepositoryImpl) DeleteExpired(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM refresh_tokens WHERE expires_at < ?", nullTimestamp(&before))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
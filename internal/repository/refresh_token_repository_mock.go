@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRefreshTokenRepository is a mock implementation of RefreshTokenRepository for testing
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockRefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+// FindByHash mocks the FindByHash method
+func (m *MockRefreshTokenRepository) FindByHash(hash string) (*domain.RefreshToken, error) {
+	args := m.Called(hash)
+	if token, ok := args.Get(0).(*domain.RefreshToken); ok {
+		return token, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MarkUsed mocks the MarkUsed method
+func (m *MockRefreshTokenRepository) MarkUsed(id int, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+// RevokeFamily mocks the RevokeFamily method
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	args := m.Called(familyID, at)
+	return args.Error(0)
+}
+
+// RevokeUser mocks the RevokeUser method
+func (m *MockRefreshTokenRepository) RevokeUser(userID int, issuedBefore, at time.Time) error {
+	args := m.Called(userID, issuedBefore, at)
+	return args.Error(0)
+}
+
+// DeleteExpired mocks the DeleteExpired method
+func (m *MockRefreshTokenRepository) DeleteExpired(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+)
+
+// revocationSweepInterval is how often the revocations past their expiry are removed
+const revocationSweepInterval = time.Hour
+
+// RevocationRepository defines methods for accessing the revoked_tokens and revoked_subjects tables
+// It implements revocation.Store, so a token revoked on one replica is rejected by all of them.
+type RevocationRepository interface {
+	// RevokeToken revokes the token with the given jti until it expires
+	// @param jti string - The "jti" claim of the token
+	// @param until time.Time - When the token expires
+	// @return error - An error if the query fails
+	RevokeToken(jti string, until time.Time) error
+
+	// RevokeSubject revokes the tokens of a subject issued at or before the given time, until they expire
+	// @param subject string - The subject of the tokens
+	// @param before time.Time - The cutoff time, replacing an earlier revocation of the subject
+	// @param until time.Time - When the tokens covered by the cutoff have expired
+	// @return error - An error if the query fails
+	RevokeSubject(subject string, before, until time.Time) error
+
+	// IsRevoked reports whether a token is revoked at now
+	// @param jti string - The "jti" claim of the token, empty if none
+	// @param subject string - The subject of the token
+	// @param issuedAt time.Time - The "iat" claim of the token, zero if none
+	// @param now time.Time - The time of the request
+	// @return bool - True if the token is revoked
+	// @return error - An error if the query fails
+	IsRevoked(jti, subject string, issuedAt, now time.Time) (bool, error)
+}
+
+type revocationRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+
+	mu        sync.Mutex
+	lastSweep time.Time // When expired revocations were last removed by this replica
+}
+
+// NewRevocationRepository creates a new RevocationRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return RevocationRepository - The implementation of the repository
+func NewRevocationRepository(db *sql.DB) RevocationRepository {
+	return &revocationRepositoryImpl{db: db}
+}
+
+// RevokeToken revokes the token with the given jti until it expires
+// Revoking a token twice keeps the later expiry.
+// @param jti string - The "jti" claim of the token
+// @param until time.Time - When the token expires
+// @return error - An error if the query fails
+func (r *revocationRepositoryImpl) RevokeToken(jti string, until time.Time) error {
+	r.sweep(time.Now())
+
+	_, err := r.db.Exec(`INSERT INTO revoked_tokens (jti, expires_at) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE expires_at = GREATEST(expires_at, VALUES(expires_at))`,
+		jti, nullTimestamp(&until))
+	return err
+}
+
+// RevokeSubject revokes the tokens of a subject issued at or before the given time, until they expire
+// @param subject string - The subject of the tokens
+// @param before time.Time - The cutoff time, replacing an earlier revocation of the subject
+// @param until time.Time - When the tokens covered by the cutoff have expired
+// @return error - An error if the query fails
+func (r *revocationRepositoryImpl) RevokeSubject(subject string, before, until time.Time) error {
+	r.sweep(time.Now())
+
+	_, err := r.db.Exec(`INSERT INTO revoked_subjects (subject, revoked_before, expires_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE revoked_before = VALUES(revoked_before), expires_at = VALUES(expires_at)`,
+		subject, nullTimestamp(&before), nullTimestamp(&until))
+	return err
+}
+
+// IsRevoked reports whether a token is revoked at now
+// Both revocation kinds are checked in a single round trip.
+// @param jti string - The "jti" claim of the token, empty if none
+// @param subject string - The subject of the token
+// @param issuedAt time.Time - The "iat" claim of the token, zero if none
+// @param now time.Time - The time of the request
+// @return bool - True if the token is revoked
+// @return error - An error if the query fails
+func (r *revocationRepositoryImpl) IsRevoked(jti, subject string, issuedAt, now time.Time) (bool, error) {
+	at := nullTimestamp(&now)
+	var tokenRevoked bool
+	var revokedBefore []uint8
+	err := r.db.QueryRow(`SELECT
+			EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = ? AND expires_at > ?),
+			(SELECT revoked_before FROM revoked_subjects WHERE subject = ? AND expires_at > ?)`,
+		jti, at, subject, at).Scan(&tokenRevoked, &revokedBefore)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if tokenRevoked && jti != "" {
+		return true, nil
+	}
+	if revokedBefore == nil {
+		return false, nil
+	}
+	return revocation.SubjectCutoff{Before: parseTimestamp(revokedBefore)}.Covers(issuedAt), nil
+}
+
+// sweep removes the revocations past their expiry, at most once per revocationSweepInterval
+// Expired rows are already ignored by IsRevoked, so failures are ignored; the next sweep retries.
+func (r *revocationRepositoryImpl) sweep(now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.lastSweep) < revocationSweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	r.mu.Unlock()
+
+	at := nullTimestamp(&now)
+	r.db.Exec("DELETE FROM revoked_tokens WHERE expires_at <= ?", at)
+	r.db.Exec("DELETE FROM revoked_subjects WHERE expires_at <= ?", at)
+}
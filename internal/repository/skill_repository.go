@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// SkillRepository defines methods for accessing the skills dictionary and the job_skills table
+// This interface abstracts database operations for skills, their aliases and the skills tagged on jobs.
+type SkillRepository interface {
+	// FindAll retrieves the whole skills dictionary
+	// @return []*domain.Skill - A slice of skills with their aliases, ordered by name
+	// @return error - An error if the query fails
+	FindAll() ([]*domain.Skill, error)
+
+	// Resolve looks up skills by canonical name or alias, case-insensitively
+	// @param names []string - The names to resolve
+	// @return map[string]*domain.Skill - The resolved skills keyed by domain.NormalizeSkillKey(name); unknown names are absent
+	// @return error - An error if the query fails
+	Resolve(names []string) (map[string]*domain.Skill, error)
+
+	// Create inserts a new skill together with its aliases
+	// @param skill *domain.Skill - The skill to be inserted
+	// @return error - domain.ErrSkillExists if the name or an alias is taken, or an error if the query fails
+	Create(skill *domain.Skill) error
+
+	// AddAlias adds an alias to an existing skill
+	// @param skillID int - The ID of the skill
+	// @param alias string - The alias to add
+	// @return error - domain.ErrSkillNotFound, domain.ErrSkillExists, or an error if the query fails
+	AddAlias(skillID int, alias string) error
+
+	// Delete removes a skill, its aliases and its job tags
+	// @param id int - The ID of the skill
+	// @return error - domain.ErrSkillNotFound if the skill does not exist, or an error if the query fails
+	Delete(id int) error
+
+	// FindByJob retrieves the skills tagged on a job
+	// @param jobID int - The ID of the job
+	// @return []domain.JobSkill - The job's skills
+	// @return error - An error if the query fails
+	FindByJob(jobID int) ([]domain.JobSkill, error)
+
+	// ReplaceJobSkills replaces the skills tagged on a job in a single transaction
+	// @param jobID int - The ID of the job
+	// @param skills []domain.JobSkill - The new set of skills, identified by SkillID
+	// @return error - An error if the query fails
+	ReplaceJobSkills(jobID int, skills []domain.JobSkill) error
+
+	// RemoveJobSkill removes a single skill from a job
+	// @param jobID int - The ID of the job
+	// @param skillID int - The ID of the skill
+	// @return error - domain.ErrSkillNotFound if the job is not tagged with the skill, or an error if the query fails
+	RemoveJobSkill(jobID, skillID int) error
+}
+
+type skillRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewSkillRepository creates a new SkillRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return SkillRepository - The implementation of the repository
+func NewSkillRepository(db *sql.DB) SkillRepository {
+	return &skillRepositoryImpl{db: db}
+}
+
+// FindAll retrieves the whole skills dictionary
+// @return []*domain.Skill - A slice of skills with their aliases, ordered by name
+// @return error - An error if the query fails
+func (r *skillRepositoryImpl) FindAll() ([]*domain.Skill, error) {
+	query := "SELECT s.id, s.name, s.created_at, a.alias FROM skills s" +
+		" LEFT JOIN skill_aliases a ON a.skill_id = s.id ORDER BY s.name, a.alias"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []*domain.Skill
+	var current *domain.Skill
+	for rows.Next() {
+		var skill domain.Skill
+		var createdAt []uint8
+		var alias sql.NullString
+		if err := rows.Scan(&skill.ID, &skill.Name, &createdAt, &alias); err != nil {
+			return nil, err
+		}
+		// Rows are ordered by skill, so aliases of the same skill are adjacent
+		if current == nil || current.ID != skill.ID {
+			skill.CreatedAt = parseTimestamp(createdAt)
+			skill.Aliases = []string{}
+			current = &skill
+			skills = append(skills, current)
+		}
+		if alias.Valid {
+			current.Aliases = append(current.Aliases, alias.String)
+		}
+	}
+	return skills, rows.Err()
+}
+
+// Resolve looks up skills by canonical name or alias, case-insensitively
+// A canonical name takes priority over an alias spelled the same, which Create and AddAlias refuse.
+// @param names []string - The names to resolve
+// @return map[string]*domain.Skill - The resolved skills keyed by domain.NormalizeSkillKey(name); unknown names are absent
+// @return error - An error if the query fails
+func (r *skillRepositoryImpl) Resolve(names []string) (map[string]*domain.Skill, error) {
+	resolved := make(map[string]*domain.Skill, len(names))
+	if len(names) == 0 {
+		return resolved, nil
+	}
+	keys := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, domain.NormalizeSkillKey(name))
+	}
+
+	// Canonical names come first and win over an alias spelled the same
+	in := placeholders(len(keys))
+	query := "SELECT LOWER(s.name), s.id, s.name, 1 AS canonical FROM skills s WHERE LOWER(s.name) IN (" + in + ")" +
+		" UNION SELECT a.alias, s.id, s.name, 0 FROM skill_aliases a JOIN skills s ON s.id = a.skill_id WHERE a.alias IN (" + in + ")" +
+		" ORDER BY canonical DESC"
+	rows, err := r.db.Query(query, append(keys, keys...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var canonical bool
+		var skill domain.Skill
+		if err := rows.Scan(&key, &skill.ID, &skill.Name, &canonical); err != nil {
+			return nil, err
+		}
+		if _, ok := resolved[key]; !ok {
+			resolved[key] = &skill
+		}
+	}
+	return resolved, rows.Err()
+}
+
+// Create inserts a new skill together with its aliases
+// Aliases are stored normalised with domain.NormalizeSkillKey. Neither the name nor an alias may
+// match an existing name or alias, so every key resolves to a single skill.
+// @param skill *domain.Skill - The skill to be inserted
+// @return error - domain.ErrSkillExists if the name or an alias is taken, or an error if the query fails
+func (r *skillRepositoryImpl) Create(skill *domain.Skill) error {
+	var id int64
+	err := inTx(r.db, func(tx dbtx) error {
+		if err := checkSkillKeyFree(tx, domain.NormalizeSkillKey(skill.Name), 0); err != nil {
+			return err
+		}
+		for _, alias := range skill.Aliases {
+			if err := checkSkillKeyFree(tx, domain.NormalizeSkillKey(alias), 0); err != nil {
+				return err
+			}
+		}
+		result, err := tx.Exec("INSERT INTO skills (name) VALUES (?)", skill.Name)
+		if isDuplicateEntry(err) {
+			return domain.ErrSkillExists
+		}
+		if err != nil {
+			return err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		for _, alias := range skill.Aliases {
+			_, err := tx.Exec("INSERT INTO skill_aliases (alias, skill_id) VALUES (?, ?)", domain.NormalizeSkillKey(alias), id)
+			if isDuplicateEntry(err) {
+				return domain.ErrSkillExists
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	skill.ID = int(id)
+	return nil
+}
+
+// AddAlias adds an alias to an existing skill
+// The alias may not be the canonical name of another skill.
+// @param skillID int - The ID of the skill
+// @param alias string - The alias to add
+// @return error - domain.ErrSkillNotFound, domain.ErrSkillExists, or an error if the query fails
+func (r *skillRepositoryImpl) AddAlias(skillID int, alias string) error {
+	key := domain.NormalizeSkillKey(alias)
+	return inTx(r.db, func(tx dbtx) error {
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM skills WHERE id = ?)", skillID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrSkillNotFound
+		}
+		if err := checkSkillKeyFree(tx, key, skillID); err != nil {
+			return err
+		}
+		_, err := tx.Exec("INSERT INTO skill_aliases (alias, skill_id) VALUES (?, ?)", key, skillID)
+		if isDuplicateEntry(err) {
+			return domain.ErrSkillExists
+		}
+		return err
+	})
+}
+
+// checkSkillKeyFree returns domain.ErrSkillExists if a key is the canonical name of a skill other
+// than skillID (0 for none) or an alias of any skill
+func checkSkillKeyFree(tx dbtx, key string, skillID int) error {
+	query := "SELECT EXISTS(SELECT 1 FROM skills WHERE LOWER(name) = ? AND id <> ?)" +
+		" OR EXISTS(SELECT 1 FROM skill_aliases WHERE alias = ?)"
+	var taken bool
+	if err := tx.QueryRow(query, key, skillID, key).Scan(&taken); err != nil {
+		return err
+	}
+	if taken {
+		return domain.ErrSkillExists
+	}
+	return nil
+}
+
+// Delete removes a skill, its aliases and its job tags
+// Aliases and job tags are removed by the ON DELETE CASCADE foreign keys; the jobs that were
+// tagged with the skill get a new version in the same transaction.
+// @param id int - The ID of the skill
+// @return error - domain.ErrSkillNotFound if the skill does not exist, or an error if the query fails
+func (r *skillRepositoryImpl) Delete(id int) error {
+	return inTx(r.db, func(tx dbtx) error {
+		query := "UPDATE jobs SET version = version + 1, updated_at = CURRENT_TIMESTAMP" +
+			" WHERE id IN (SELECT job_id FROM job_skills WHERE skill_id = ?)"
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+		result, err := tx.Exec("DELETE FROM skills WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrSkillNotFound
+		}
+		return nil
+	})
+}
+
+// FindByJob retrieves the skills tagged on a job
+// @param jobID int - The ID of the job
+// @return []domain.JobSkill - The job's skills, required ones first
+// @return error - An error if the query fails
+func (r *skillRepositoryImpl) FindByJob(jobID int) ([]domain.JobSkill, error) {
+	query := "SELECT s.id, s.name, js.requirement FROM job_skills js JOIN skills s ON s.id = js.skill_id" +
+		" WHERE js.job_id = ? ORDER BY js.requirement DESC, s.name"
+	rows, err := r.db.Query(query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []domain.JobSkill{}
+	for rows.Next() {
+		var skill domain.JobSkill
+		if err := rows.Scan(&skill.SkillID, &skill.Name, &skill.Requirement); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+// ReplaceJobSkills replaces the skills tagged on a job in a single transaction
+// The job gets a new version in the same transaction, since its skills are part of its representation.
+// @param jobID int - The ID of the job
+// @param skills []domain.JobSkill - The new set of skills, identified by SkillID
+// @return error - An error if the query fails
+func (r *skillRepositoryImpl) ReplaceJobSkills(jobID int, skills []domain.JobSkill) error {
+	return inTx(r.db, func(tx dbtx) error {
+		if _, err := tx.Exec("DELETE FROM job_skills WHERE job_id = ?", jobID); err != nil {
+			return err
+		}
+		if len(skills) > 0 {
+			values := make([]string, 0, len(skills))
+			args := make([]interface{}, 0, 3*len(skills))
+			for _, skill := range skills {
+				values = append(values, "(?, ?, ?)")
+				args = append(args, jobID, skill.SkillID, skill.Requirement)
+			}
+			query := "INSERT INTO job_skills (job_id, skill_id, requirement) VALUES " + strings.Join(values, ", ")
+			if _, err := tx.Exec(query, args...); err != nil {
+				return err
+			}
+		}
+		return touchJob(tx, jobID)
+	})
+}
+
+// RemoveJobSkill removes a single skill from a job
+// The job gets a new version in the same transaction.
+// @param jobID int - The ID of the job
+// @param skillID int - The ID of the skill
+// @return error - domain.ErrSkillNotFound if the job is not tagged with the skill, or an error if the query fails
+func (r *skillRepositoryImpl) RemoveJobSkill(jobID, skillID int) error {
+	return inTx(r.db, func(tx dbtx) error {
+		result, err := tx.Exec("DELETE FROM job_skills WHERE job_id = ? AND skill_id = ?", jobID, skillID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrSkillNotFound
+		}
+		return touchJob(tx, jobID)
+	})
+}
+
+// touchJob increments the version of a job whose skills changed, so its ETag changes too
+func touchJob(tx dbtx, jobID int) error {
+	_, err := tx.Exec("UPDATE jobs SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?", jobID)
+	return err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSkillRepository is a mock implementation of SkillRepository for testing
+type MockSkillRepository struct {
+	mock.Mock
+}
+
+// FindAll mocks the FindAll method
+func (m *MockSkillRepository) FindAll() ([]*domain.Skill, error) {
+	args := m.Called()
+	if skills, ok := args.Get(0).([]*domain.Skill); ok {
+		return skills, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Resolve mocks the Resolve method
+func (m *MockSkillRepository) Resolve(names []string) (map[string]*domain.Skill, error) {
+	args := m.Called(names)
+	if resolved, ok := args.Get(0).(map[string]*domain.Skill); ok {
+		return resolved, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockSkillRepository) Create(skill *domain.Skill) error {
+	args := m.Called(skill)
+	return args.Error(0)
+}
+
+// AddAlias mocks the AddAlias method
+func (m *MockSkillRepository) AddAlias(skillID int, alias string) error {
+	args := m.Called(skillID, alias)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockSkillRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// FindByJob mocks the FindByJob method
+func (m *MockSkillRepository) FindByJob(jobID int) ([]domain.JobSkill, error) {
+	args := m.Called(jobID)
+	if skills, ok := args.Get(0).([]domain.JobSkill); ok {
+		return skills, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ReplaceJobSkills mocks the ReplaceJobSkills method
+func (m *MockSkillRepository) ReplaceJobSkills(jobID int, skills []domain.JobSkill) error {
+	args := m.Called(jobID, skills)
+	return args.Error(0)
+}
+
+// RemoveJobSkill mocks the RemoveJobSkill method
+func (m *MockSkillRepository) RemoveJobSkill(jobID, skillID int) error {
+	args := m.Called(jobID, skillID)
+	return args.Error(0)
+}
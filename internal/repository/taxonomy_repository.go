@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// TaxonomyRepository defines methods for accessing the job_taxonomy_terms table
+// This interface abstracts database operations for the controlled vocabularies used to classify jobs.
+type TaxonomyRepository interface {
+	// FindByKind retrieves all terms of a taxonomy kind
+	// @param kind string - The taxonomy kind (e.g., domain.TaxonomyDepartment)
+	// @return []*domain.TaxonomyTerm - A slice of terms ordered by value
+	// @return error - An error if the query fails
+	FindByKind(kind string) ([]*domain.TaxonomyTerm, error)
+
+	// Exists reports whether a value is part of a taxonomy kind
+	// @param kind string - The taxonomy kind
+	// @param value string - The value to look up
+	// @return bool - True if the term exists
+	// @return error - An error if the query fails
+	Exists(kind, value string) (bool, error)
+
+	// Create inserts a new term
+	// @param term *domain.TaxonomyTerm - The term to be inserted
+	// @return error - domain.ErrTaxonomyTermExists on duplicates, or an error if the query fails
+	Create(term *domain.TaxonomyTerm) error
+
+	// Delete removes a term
+	// @param kind string - The taxonomy kind
+	// @param value string - The value to remove
+	// @return error - domain.ErrTaxonomyTermNotFound if the term does not exist, or an error if the query fails
+	Delete(kind, value string) error
+}
+
+type taxonomyRepositoryImpl struct {
+	db *sql.DB // Database connection instance
+}
+
+// NewTaxonomyRepository creates a new TaxonomyRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return TaxonomyRepository - The implementation of the repository
+func NewTaxonomyRepository(db *sql.DB) TaxonomyRepository {
+	return &taxonomyRepositoryImpl{db: db}
+}
+
+// FindByKind retrieves all terms of a taxonomy kind
+// @param kind string - The taxonomy kind (e.g., domain.TaxonomyDepartment)
+// @return []*domain.TaxonomyTerm - A slice of terms ordered by value
+// @return error - An error if the query fails
+func (r *taxonomyRepositoryImpl) FindByKind(kind string) ([]*domain.TaxonomyTerm, error) {
+	query := "SELECT id, kind, value, label, created_at FROM job_taxonomy_terms WHERE kind = ? ORDER BY value"
+	rows, err := r.db.Query(query, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var terms []*domain.TaxonomyTerm
+	for rows.Next() {
+		var term domain.TaxonomyTerm
+		var createdAt []uint8
+		if err := rows.Scan(&term.ID, &term.Kind, &term.Value, &term.Label, &createdAt); err != nil {
+			return nil, err
+		}
+		term.CreatedAt = parseTimestamp(createdAt)
+		terms = append(terms, &term)
+	}
+	return terms, rows.Err()
+}
+
+// Exists reports whether a value is part of a taxonomy kind
+// @param kind string - The taxonomy kind
+// @param value string - The value to look up
+// @return bool - True if the term exists
+// @return error - An error if the query fails
+func (r *taxonomyRepositoryImpl) Exists(kind, value string) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS(SELECT 1 FROM job_taxonomy_terms WHERE kind = ? AND value = ?)"
+	err := r.db.QueryRow(query, kind, value).Scan(&exists)
+	return exists, err
+}
+
+// Create inserts a new term
+// @param term *domain.TaxonomyTerm - The term to be inserted
+// @return error - domain.ErrTaxonomyTermExists on duplicates, or an error if the query fails
+func (r *taxonomyRepositoryImpl) Create(term *domain.TaxonomyTerm) error {
+	query := "INSERT INTO job_taxonomy_terms (kind, value, label) VALUES (?, ?, ?)"
+	result, err := r.db.Exec(query, term.Kind, term.Value, term.Label)
+	if isDuplicateEntry(err) {
+		return domain.ErrTaxonomyTermExists
+	}
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	term.ID = int(id)
+	return nil
+}
+
+// Delete removes a term
+// Jobs already classified with the value keep it; only new writes are validated.
+// @param kind string - The taxonomy kind
+// @param value string - The value to remove
+// @return error - domain.ErrTaxonomyTermNotFound if the term does not exist, or an error if the query fails
+func (r *taxonomyRepositoryImpl) Delete(kind, value string) error {
+	result, err := r.db.Exec("DELETE FROM job_taxonomy_terms WHERE kind = ? AND value = ?", kind, value)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTaxonomyTermNotFound
+	}
+	return nil
+}
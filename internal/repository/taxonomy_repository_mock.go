@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaxonomyRepository is a mock implementation of TaxonomyRepository for testing
+type MockTaxonomyRepository struct {
+	mock.Mock
+}
+
+// FindByKind mocks the FindByKind method
+func (m *MockTaxonomyRepository) FindByKind(kind string) ([]*domain.TaxonomyTerm, error) {
+	args := m.Called(kind)
+	if terms, ok := args.Get(0).([]*domain.TaxonomyTerm); ok {
+		return terms, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Exists mocks the Exists method
+func (m *MockTaxonomyRepository) Exists(kind, value string) (bool, error) {
+	args := m.Called(kind, value)
+	return args.Bool(0), args.Error(1)
+}
+
+// Create mocks the Create method
+func (m *MockTaxonomyRepository) Create(term *domain.TaxonomyTerm) error {
+	args := m.Called(term)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockTaxonomyRepository) Delete(kind, value string) error {
+	args := m.Called(kind, value)
+	return args.Error(0)
+}
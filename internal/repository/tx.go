@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+// dbtx is the subset of *sql.DB and *sql.Tx used by the repositories
+// Repositories built on it run their queries either directly or inside a transaction.
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// TxRepositories groups the repositories whose writes must commit together
+// Every repository in the group is bound to the same transaction.
+type TxRepositories struct {
+	Jobs   JobRepository    // Jobs table
+	Skills SkillRepository  // Skills dictionary and job tags
+	Audit  AuditRepository  // Append-only audit log; nil when auditing is disabled
+	Outbox OutboxRepository // Events awaiting delivery; nil when events are disabled
+}
+
+// Transactor runs a unit of work inside a database transaction
+type Transactor interface {
+	// WithinTx runs fn with repositories bound to a single transaction
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	// @param fn func(repos TxRepositories) error - The unit of work
+	// @return error - The error returned by fn, or an error if the transaction fails
+	WithinTx(fn func(repos TxRepositories) error) error
+}
+
+type transactorImpl struct {
+	db *sql.DB // Database connection instance
+}
+
+// NewTransactor creates a new Transactor instance
+// @param db *sql.DB - The database connection used to begin transactions
+// @return Transactor - The implementation of the transactor
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactorImpl{db: db}
+}
+
+// WithinTx runs fn with repositories bound to a single transaction
+// @param fn func(repos TxRepositories) error - The unit of work
+// @return error - The error returned by fn, or an error if the transaction fails
+func (t *transactorImpl) WithinTx(fn func(repos TxRepositories) error) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op once committed
+
+	repos := TxRepositories{
+		Jobs:   &jobRepositoryImpl{db: tx},
+		Skills: &skillRepositoryImpl{db: tx},
+		Audit:  &auditRepositoryImpl{db: tx},
+		Outbox: &outboxRepositoryImpl{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// inTx runs fn in a transaction of its own, or directly when db is already a transaction
+// Repositories use it for multi-statement writes, so they stay atomic both alone and within WithinTx.
+func inTx(db dbtx, fn func(tx dbtx) error) error {
+	conn, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
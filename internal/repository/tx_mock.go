@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactor is a mock implementation of Transactor for testing
+// WithinTx calls fn with Repos, so expectations are set on the mocked repositories.
+type MockTransactor struct {
+	mock.Mock
+	Repos TxRepositories // Repositories handed to the unit of work
+}
+
+// WithinTx mocks the WithinTx method
+func (m *MockTransactor) WithinTx(fn func(repos TxRepositories) error) error {
+	m.Called()
+	return fn(m.Repos)
+}
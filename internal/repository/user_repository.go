@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// UserRepository defines methods for accessing the users table
+type UserRepository interface {
+	// Create inserts a new user and sets its ID and timestamps
+	// @param user *domain.User - The user to be stored, with its password hash
+	// @return error - domain.ErrUserExists if the username or email is taken, or an error if the query fails
+	Create(user *domain.User) error
+
+	// FindByEmail retrieves a user by email
+	// @param email string - The email address, compared as stored
+	// @return *domain.User - The user
+	// @return error - domain.ErrUserNotFound if no user has the email, or an error if the query fails
+	FindByEmail(email string) (*domain.User, error)
+
+	// FindByUsername retrieves a user by username
+	// @param username string - The username, compared with the collation of the column
+	// @return *domain.User - The user
+	// @return error - domain.ErrUserNotFound if no user has the username, or an error if the query fails
+	FindByUsername(username string) (*domain.User, error)
+
+	// FindByID retrieves a user
+	// @param id int - The ID of the user
+	// @return *domain.User - The user
+	// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+	FindByID(id int) (*domain.User, error)
+
+	// UpdatePasswordHash replaces the password hash of a user
+	// @param id int - The ID of the user
+	// @param passwordHash string - The new hash
+	// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+	UpdatePasswordHash(id int, passwordHash string) error
+
+	// UpdateRole replaces the role of a user
+	// @param id int - The ID of the user
+	// @param role string - The new role
+	// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+	UpdateRole(id int, role string) error
+}
+
+type userRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewUserRepository creates a new UserRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return UserRepository - The implementation of the repository
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepositoryImpl{db: db}
+}
+
+// userColumns lists the columns read by scanUser, in scan order
+const userColumns = "id, username, email, password_hash, role, created_at, updated_at"
+
+// scanUser reads one users row selected with userColumns
+func scanUser(row rowScanner) (*domain.User, error) {
+	var user domain.User
+	var createdAt, updatedAt []uint8
+	if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	user.CreatedAt = parseTimestamp(createdAt)
+	user.UpdatedAt = parseTimestamp(updatedAt)
+	return &user, nil
+}
+
+// Create inserts a new user and sets its ID and timestamps
+// @param user *domain.User - The user to be stored, with its password hash
+// @return error - domain.ErrUserExists if the username or email is taken, or an error if the query fails
+func (r *userRepositoryImpl) Create(user *domain.User) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := "INSERT INTO users (username, email, password_hash, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, user.Username, user.Email, user.PasswordHash, user.Role,
+		now.Format(mysqlTimeLayout), now.Format(mysqlTimeLayout))
+	if isDuplicateEntry(err) {
+		return domain.ErrUserExists
+	}
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	user.ID, user.CreatedAt, user.UpdatedAt = int(id), now, now
+	return nil
+}
+
+// FindByEmail retrieves a user by email
+// @param email string - The email address, compared as stored
+// @return *domain.User - The user
+// @return error - domain.ErrUserNotFound if no user has the email, or an error if the query fails
+func (r *userRepositoryImpl) FindByEmail(email string) (*domain.User, error) {
+	return r.findOne("SELECT "+userColumns+" FROM users WHERE email = ?", email)
+}
+
+// FindByUsername retrieves a user by username
+// @param username string - The username, compared with the collation of the column
+// @return *domain.User - The user
+// @return error - domain.ErrUserNotFound if no user has the username, or an error if the query fails
+func (r *userRepositoryImpl) FindByUsername(username string) (*domain.User, error) {
+	return r.findOne("SELECT "+userColumns+" FROM users WHERE username = ?", username)
+}
+
+// FindByID retrieves a user
+// @param id int - The ID of the user
+// @return *domain.User - The user
+// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+func (r *userRepositoryImpl) FindByID(id int) (*domain.User, error) {
+	return r.findOne("SELECT "+userColumns+" FROM users WHERE id = ?", id)
+}
+
+// UpdatePasswordHash replaces the password hash of a user
+// @param id int - The ID of the user
+// @param passwordHash string - The new hash
+// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+func (r *userRepositoryImpl) UpdatePasswordHash(id int, passwordHash string) error {
+	now := time.Now().UTC().Format(mysqlTimeLayout)
+	result, err := r.db.Exec("UPDATE users SET password_hash = ?, updated_at = ? WHERE id = ?", passwordHash, now, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateRole replaces the role of a user
+// @param id int - The ID of the user
+// @param role string - The new role
+// @return error - domain.ErrUserNotFound if it does not exist, or an error if the query fails
+func (r *userRepositoryImpl) UpdateRole(id int, role string) error {
+	now := time.Now().UTC().Format(mysqlTimeLayout)
+	result, err := r.db.Exec("UPDATE users SET role = ?, updated_at = ? WHERE id = ?", role, now, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// findOne runs a query selecting at most one user
+func (r *userRepositoryImpl) findOne(query string, args ...interface{}) (*domain.User, error) {
+	user, err := scanUser(r.db.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, err
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserRepository is a mock implementation of UserRepository for testing
+type MockUserRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method
+func (m *MockUserRepository) Create(user *domain.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+// FindByEmail mocks the FindByEmail method
+func (m *MockUserRepository) FindByEmail(email string) (*domain.User, error) {
+	args := m.Called(email)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindByUsername mocks the FindByUsername method
+func (m *MockUserRepository) FindByUsername(username string) (*domain.User, error) {
+	args := m.Called(username)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockUserRepository) FindByID(id int) (*domain.User, error) {
+	args := m.Called(id)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdatePasswordHash mocks the UpdatePasswordHash method
+func (m *MockUserRepository) UpdatePasswordHash(id int, passwordHash string) error {
+	args := m.Called(id, passwordHash)
+	return args.Error(0)
+}
+
+// UpdateRole mocks the UpdateRole method
+func (m *MockUserRepository) UpdateRole(id int, role string) error {
+	args := m.Called(id, role)
+	return args.Error(0)
+}
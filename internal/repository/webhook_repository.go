@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// WebhookRepository defines methods for accessing the webhook_subscriptions and webhook_deliveries tables
+// Subscription lookups are scoped to their owner; a subscription of another owner is reported as not found.
+type WebhookRepository interface {
+	// CreateSubscription inserts a new subscription and sets its ID
+	// @param sub *domain.WebhookSubscription - The subscription to be stored
+	// @return error - An error if the query fails
+	CreateSubscription(sub *domain.WebhookSubscription) error
+
+	// FindSubscription retrieves a subscription of an owner
+	// @param id int - The ID of the subscription
+	// @param owner string - The owner of the subscription
+	// @return *domain.WebhookSubscription - The subscription
+	// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+	FindSubscription(id int, owner string) (*domain.WebhookSubscription, error)
+
+	// FindSubscriptions retrieves every subscription of an owner
+	// @param owner string - The owner of the subscriptions
+	// @return []*domain.WebhookSubscription - The subscriptions, oldest first
+	// @return error - An error if the query fails
+	FindSubscriptions(owner string) ([]*domain.WebhookSubscription, error)
+
+	// FindActiveSubscriptions retrieves the active subscriptions of every owner
+	// @return []*domain.WebhookSubscription - The active subscriptions
+	// @return error - An error if the query fails
+	FindActiveSubscriptions() ([]*domain.WebhookSubscription, error)
+
+	// UpdateSubscription stores the URL, events and active flag of a subscription
+	// @param sub *domain.WebhookSubscription - The subscription, identified by its ID and owner
+	// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+	UpdateSubscription(sub *domain.WebhookSubscription) error
+
+	// DeleteSubscription removes a subscription and its delivery log
+	// @param id int - The ID of the subscription
+	// @param owner string - The owner of the subscription
+	// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+	DeleteSubscription(id int, owner string) error
+
+	// EnqueueDeliveries queues deliveries; a delivery of an event already queued for the same subscription is skipped
+	// @param deliveries []*domain.WebhookDelivery - The deliveries to queue, NextAttemptAt must be set
+	// @return error - An error if the query fails
+	EnqueueDeliveries(deliveries []*domain.WebhookDelivery) error
+
+	// ClaimDeliveries leases up to limit pending deliveries whose next attempt is due, oldest first
+	// @param now time.Time - The current time
+	// @param limit int - Maximum number of deliveries to claim
+	// @param lease time.Duration - How long the deliveries stay claimed
+	// @return []*domain.WebhookDispatch - The claimed deliveries with their endpoint
+	// @return error - An error if the query fails
+	ClaimDeliveries(now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDispatch, error)
+
+	// MarkDelivered records a successful attempt
+	// @param id int - The ID of the delivery
+	// @param statusCode int - The HTTP status returned by the endpoint
+	// @param now time.Time - The delivery time
+	// @return error - An error if the query fails
+	MarkDelivered(id, statusCode int, now time.Time) error
+
+	// MarkFailed records a failed attempt; the delivery is retried at next, or dead-lettered when next is nil
+	// @param id int - The ID of the delivery
+	// @param statusCode int - The HTTP status returned by the endpoint, 0 if there was no response
+	// @param reason string - The error of the attempt
+	// @param next *time.Time - When to retry, nil to give up
+	// @return error - An error if the query fails
+	MarkFailed(id, statusCode int, reason string, next *time.Time) error
+
+	// FindDeliveries retrieves the delivery log of a subscription, newest first
+	// @param subscriptionID int - The ID of the subscription
+	// @param limit int - Maximum number of deliveries
+	// @param offset int - Number of deliveries to skip
+	// @return []*domain.WebhookDelivery - The deliveries
+	// @return error - An error if the query fails
+	FindDeliveries(subscriptionID, limit, offset int) ([]*domain.WebhookDelivery, error)
+
+	// ResetDelivery queues a delivery of a subscription again, due immediately
+	// @param subscriptionID int - The ID of the subscription
+	// @param id int - The ID of the delivery
+	// @param now time.Time - The current time
+	// @return error - domain.ErrDeliveryNotFound if it does not exist, or an error if the query fails
+	ResetDelivery(subscriptionID, id int, now time.Time) error
+}
+
+type webhookRepositoryImpl struct {
+	db dbtx // Database connection or transaction
+}
+
+// NewWebhookRepository creates a new WebhookRepository instance
+// @param db *sql.DB - The database connection to be used for queries
+// @return WebhookRepository - The implementation of the repository
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepositoryImpl{db: db}
+}
+
+// subscriptionColumns lists the columns read by scanSubscription, in scan order
+const subscriptionColumns = "id, owner, url, events, secret, active, created_at, updated_at"
+
+// scanSubscription reads one webhook_subscriptions row selected with subscriptionColumns
+func scanSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var events, createdAt, updatedAt []uint8
+	if err := row.Scan(&sub.ID, &sub.Owner, &sub.URL, &events, &sub.Secret, &sub.Active, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &sub.Events); err != nil {
+		return nil, err
+	}
+	sub.CreatedAt = parseTimestamp(createdAt)
+	sub.UpdatedAt = parseTimestamp(updatedAt)
+	return &sub, nil
+}
+
+// querySubscriptions runs a query selecting subscriptionColumns
+func (r *webhookRepositoryImpl) querySubscriptions(query string, args ...interface{}) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []*domain.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// CreateSubscription inserts a new subscription and sets its ID
+// @param sub *domain.WebhookSubscription - The subscription to be stored
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) CreateSubscription(sub *domain.WebhookSubscription) error {
+	events, err := json.Marshal(nonNilStrings(sub.Events))
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO webhook_subscriptions (owner, url, events, secret, active) VALUES (?, ?, ?, ?, ?)"
+	result, err := r.db.Exec(query, sub.Owner, sub.URL, events, sub.Secret, sub.Active)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	sub.ID = int(id)
+	return nil
+}
+
+// FindSubscription retrieves a subscription of an owner
+// @param id int - The ID of the subscription
+// @param owner string - The owner of the subscription
+// @return *domain.WebhookSubscription - The subscription
+// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+func (r *webhookRepositoryImpl) FindSubscription(id int, owner string) (*domain.WebhookSubscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM webhook_subscriptions WHERE id = ? AND owner = ?"
+	sub, err := scanSubscription(r.db.QueryRow(query, id, owner))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return sub, err
+}
+
+// FindSubscriptions retrieves every subscription of an owner
+// @param owner string - The owner of the subscriptions
+// @return []*domain.WebhookSubscription - The subscriptions, oldest first
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) FindSubscriptions(owner string) ([]*domain.WebhookSubscription, error) {
+	return r.querySubscriptions("SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE owner = ? ORDER BY id", owner)
+}
+
+// FindActiveSubscriptions retrieves the active subscriptions of every owner
+// @return []*domain.WebhookSubscription - The active subscriptions
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) FindActiveSubscriptions() ([]*domain.WebhookSubscription, error) {
+	return r.querySubscriptions("SELECT " + subscriptionColumns + " FROM webhook_subscriptions WHERE active = TRUE ORDER BY id")
+}
+
+// UpdateSubscription stores the URL, events and active flag of a subscription
+// @param sub *domain.WebhookSubscription - The subscription, identified by its ID and owner
+// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+func (r *webhookRepositoryImpl) UpdateSubscription(sub *domain.WebhookSubscription) error {
+	events, err := json.Marshal(nonNilStrings(sub.Events))
+	if err != nil {
+		return err
+	}
+	// Existence is checked with a SELECT because MySQL reports 0 affected rows when nothing changes
+	if _, err := r.FindSubscription(sub.ID, sub.Owner); err != nil {
+		return err
+	}
+	query := "UPDATE webhook_subscriptions SET url = ?, events = ?, active = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner = ?"
+	_, err = r.db.Exec(query, sub.URL, events, sub.Active, sub.ID, sub.Owner)
+	return err
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+// @param id int - The ID of the subscription
+// @param owner string - The owner of the subscription
+// @return error - domain.ErrWebhookNotFound if it does not exist, or an error if the query fails
+func (r *webhookRepositoryImpl) DeleteSubscription(id int, owner string) error {
+	result, err := r.db.Exec("DELETE FROM webhook_subscriptions WHERE id = ? AND owner = ?", id, owner)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues deliveries; a delivery of an event already queued for the same subscription is skipped
+// Skipping duplicates makes re-publishing an event harmless.
+// @param deliveries []*domain.WebhookDelivery - The deliveries to queue, NextAttemptAt must be set
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) EnqueueDeliveries(deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	query := "INSERT IGNORE INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at) VALUES "
+	args := make([]interface{}, 0, len(deliveries)*6)
+	for i, delivery := range deliveries {
+		if i > 0 {
+			query += ", "
+		}
+		query += "(?, ?, ?, ?, ?, ?)"
+		args = append(args, delivery.SubscriptionID, delivery.EventID, delivery.EventType, []byte(delivery.Payload),
+			domain.DeliveryPending, delivery.NextAttemptAt.UTC().Format(mysqlTimeLayout))
+	}
+	_, err := r.db.Exec(query, args...)
+	return err
+}
+
+// ClaimDeliveries leases up to limit pending deliveries whose next attempt is due, oldest first
+// The rows are tagged with a random claim token in a single UPDATE, so concurrent dispatchers never claim the same delivery.
+// @param now time.Time - The current time
+// @param limit int - Maximum number of deliveries to claim
+// @param lease time.Duration - How long the deliveries stay claimed
+// @return []*domain.WebhookDispatch - The claimed deliveries with their endpoint
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) ClaimDeliveries(now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDispatch, error) {
+	token, err := claimToken()
+	if err != nil {
+		return nil, err
+	}
+	current := now.UTC().Format(mysqlTimeLayout)
+	claim := "UPDATE webhook_deliveries SET claim_token = ?, locked_until = ?" +
+		" WHERE status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until <= ?)" +
+		" ORDER BY id LIMIT ?"
+	result, err := r.db.Exec(claim, token, now.Add(lease).UTC().Format(mysqlTimeLayout), domain.DeliveryPending,
+		current, current, limit)
+	if err != nil {
+		return nil, err
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return []*domain.WebhookDispatch{}, err
+	}
+
+	query := "SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at," +
+		" d.last_status_code, d.last_error, d.delivered_at, d.created_at, s.url, s.secret" +
+		" FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.id = d.subscription_id" +
+		" WHERE d.claim_token = ? ORDER BY d.id"
+	rows, err := r.db.Query(query, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dispatches := []*domain.WebhookDispatch{}
+	for rows.Next() {
+		var dispatch domain.WebhookDispatch
+		delivery, err := scanDelivery(rows, &dispatch.URL, &dispatch.Secret)
+		if err != nil {
+			return nil, err
+		}
+		dispatch.Delivery = *delivery
+		dispatches = append(dispatches, &dispatch)
+	}
+	return dispatches, rows.Err()
+}
+
+// MarkDelivered records a successful attempt
+// @param id int - The ID of the delivery
+// @param statusCode int - The HTTP status returned by the endpoint
+// @param now time.Time - The delivery time
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) MarkDelivered(id, statusCode int, now time.Time) error {
+	query := "UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, last_status_code = ?, last_error = '', delivered_at = ?," +
+		" next_attempt_at = NULL, claim_token = NULL, locked_until = NULL WHERE id = ?"
+	_, err := r.db.Exec(query, domain.DeliveryDelivered, statusCode, now.UTC().Format(mysqlTimeLayout), id)
+	return err
+}
+
+// MarkFailed records a failed attempt; the delivery is retried at next, or dead-lettered when next is nil
+// @param id int - The ID of the delivery
+// @param statusCode int - The HTTP status returned by the endpoint, 0 if there was no response
+// @param reason string - The error of the attempt
+// @param next *time.Time - When to retry, nil to give up
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) MarkFailed(id, statusCode int, reason string, next *time.Time) error {
+	status := domain.DeliveryDead
+	var nextAttempt interface{}
+	if next != nil {
+		status = domain.DeliveryPending
+		nextAttempt = next.UTC().Format(mysqlTimeLayout)
+	}
+	query := "UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, last_status_code = ?, last_error = ?," +
+		" next_attempt_at = ?, claim_token = NULL, locked_until = NULL WHERE id = ?"
+	_, err := r.db.Exec(query, status, statusCode, reason, nextAttempt, id)
+	return err
+}
+
+// FindDeliveries retrieves the delivery log of a subscription, newest first
+// @param subscriptionID int - The ID of the subscription
+// @param limit int - Maximum number of deliveries
+// @param offset int - Number of deliveries to skip
+// @return []*domain.WebhookDelivery - The deliveries
+// @return error - An error if the query fails
+func (r *webhookRepositoryImpl) FindDeliveries(subscriptionID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	query := "SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at," +
+		" last_status_code, last_error, delivered_at, created_at" +
+		" FROM webhook_deliveries WHERE subscription_id = ? ORDER BY id DESC LIMIT ? OFFSET ?"
+	rows, err := r.db.Query(query, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// ResetDelivery queues a delivery of a subscription again, due immediately
+// The attempt counter restarts so the delivery gets the full retry schedule.
+// @param subscriptionID int - The ID of the subscription
+// @param id int - The ID of the delivery
+// @param now time.Time - The current time
+// @return error - domain.ErrDeliveryNotFound if it does not exist, or an error if the query fails
+func (r *webhookRepositoryImpl) ResetDelivery(subscriptionID, id int, now time.Time) error {
+	query := "UPDATE webhook_deliveries SET status = ?, attempts = 0, next_attempt_at = ?, delivered_at = NULL," +
+		" claim_token = NULL, locked_until = NULL WHERE id = ? AND subscription_id = ?"
+	result, err := r.db.Exec(query, domain.DeliveryPending, now.UTC().Format(mysqlTimeLayout), id, subscriptionID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+// scanDelivery reads one webhook_deliveries row, followed by the extra columns in extra
+func scanDelivery(row rowScanner, extra ...interface{}) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload, nextAttemptAt, deliveredAt, createdAt []uint8
+	dest := []interface{}{&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &nextAttemptAt, &delivery.LastStatusCode, &delivery.LastError,
+		&deliveredAt, &createdAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	delivery.NextAttemptAt = parseNullTimestamp(nextAttemptAt)
+	delivery.DeliveredAt = parseNullTimestamp(deliveredAt)
+	delivery.CreatedAt = parseTimestamp(createdAt)
+	return &delivery, nil
+}
+
+// nonNilStrings returns an empty slice for nil, so it is stored as [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
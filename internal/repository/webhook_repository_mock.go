@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookRepository is a mock implementation of WebhookRepository for testing
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+// CreateSubscription mocks the CreateSubscription method
+func (m *MockWebhookRepository) CreateSubscription(sub *domain.WebhookSubscription) error {
+	args := m.Called(sub)
+	return args.Error(0)
+}
+
+// FindSubscription mocks the FindSubscription method
+func (m *MockWebhookRepository) FindSubscription(id int, owner string) (*domain.WebhookSubscription, error) {
+	args := m.Called(id, owner)
+	if sub, ok := args.Get(0).(*domain.WebhookSubscription); ok {
+		return sub, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindSubscriptions mocks the FindSubscriptions method
+func (m *MockWebhookRepository) FindSubscriptions(owner string) ([]*domain.WebhookSubscription, error) {
+	args := m.Called(owner)
+	if subs, ok := args.Get(0).([]*domain.WebhookSubscription); ok {
+		return subs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// FindActiveSubscriptions mocks the FindActiveSubscriptions method
+func (m *MockWebhookRepository) FindActiveSubscriptions() ([]*domain.WebhookSubscription, error) {
+	args := m.Called()
+	if subs, ok := args.Get(0).([]*domain.WebhookSubscription); ok {
+		return subs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateSubscription mocks the UpdateSubscription method
+func (m *MockWebhookRepository) UpdateSubscription(sub *domain.WebhookSubscription) error {
+	args := m.Called(sub)
+	return args.Error(0)
+}
+
+// DeleteSubscription mocks the DeleteSubscription method
+func (m *MockWebhookRepository) DeleteSubscription(id int, owner string) error {
+	args := m.Called(id, owner)
+	return args.Error(0)
+}
+
+// EnqueueDeliveries mocks the EnqueueDeliveries method
+func (m *MockWebhookRepository) EnqueueDeliveries(deliveries []*domain.WebhookDelivery) error {
+	args := m.Called(deliveries)
+	return args.Error(0)
+}
+
+// ClaimDeliveries mocks the ClaimDeliveries method
+func (m *MockWebhookRepository) ClaimDeliveries(now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDispatch, error) {
+	args := m.Called(now, limit, lease)
+	if dispatches, ok := args.Get(0).([]*domain.WebhookDispatch); ok {
+		return dispatches, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// MarkDelivered mocks the MarkDelivered method
+func (m *MockWebhookRepository) MarkDelivered(id, statusCode int, now time.Time) error {
+	args := m.Called(id, statusCode, now)
+	return args.Error(0)
+}
+
+// MarkFailed mocks the MarkFailed method
+func (m *MockWebhookRepository) MarkFailed(id, statusCode int, reason string, next *time.Time) error {
+	args := m.Called(id, statusCode, reason, next)
+	return args.Error(0)
+}
+
+// FindDeliveries mocks the FindDeliveries method
+func (m *MockWebhookRepository) FindDeliveries(subscriptionID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(subscriptionID, limit, offset)
+	if deliveries, ok := args.Get(0).([]*domain.WebhookDelivery); ok {
+		return deliveries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ResetDelivery mocks the ResetDelivery method
+func (m *MockWebhookRepository) ResetDelivery(subscriptionID, id int, now time.Time) error {
+	args := m.Called(subscriptionID, id, now)
+	return args.Error(0)
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// apiKeyTag starts every API key, so leaked keys are easy to recognise (e.g., by secret scanners)
+const apiKeyTag = "jk_"
+
+// maxRotationGrace bounds how long a rotated API key keeps working alongside its replacement
+const maxRotationGrace = 7 * 24 * time.Hour
+
+// lastUsedResolution is how stale the last use of an API key may get before it is written again
+const lastUsedResolution = time.Minute
+
+// APIKeyService defines methods for issuing API keys to machine clients and authenticating them
+type APIKeyService interface {
+	IssueKey(ctx context.Context, req domain.APIKeyRequest) (*domain.IssuedAPIKey, error)     // Creates a key
+	ListKeys() ([]*domain.APIKey, error)                                                      // Retrieves every key
+	RotateKey(ctx context.Context, id int, grace time.Duration) (*domain.IssuedAPIKey, error) // Replaces the secret of a key
+	RevokeKey(ctx context.Context, id int) error                                              // Disables a key
+	Authenticate(key string) (*domain.APIKey, error)                                          // Looks up the key sent by a client
+}
+
+type apiKeyServiceImpl struct {
+	repo repository.APIKeyRepository // Dependency on the APIKeyRepository
+	now  func() time.Time            // Clock, replaced in tests
+}
+
+// NewAPIKeyService creates a new APIKeyService instance
+// @param repo repository.APIKeyRepository - The repository storing the key hashes
+// @return APIKeyService - The implementation of the service interface
+func NewAPIKeyService(repo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyServiceImpl{repo: repo, now: time.Now}
+}
+
+// IssueKey creates an API key for the actor in ctx
+// Only a hash of the key is stored; the returned key cannot be retrieved again.
+// @param ctx context.Context - Carries the admin issuing the key
+// @param req domain.APIKeyRequest - The name, scopes and expiry of the key
+// @return *domain.IssuedAPIKey - The key together with its secret
+// @return error - A *domain.ValidationError for an invalid name, scope or expiry, or an error if the creation fails
+func (s *apiKeyServiceImpl) IssueKey(ctx context.Context, req domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	scopes, err := s.validateKeyRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	plain, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &domain.APIKey{
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(plain),
+		Scopes:    scopes,
+		CreatedBy: domain.ActorFromContext(ctx).Subject,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(key); err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: *key, Key: plain}, nil
+}
+
+// ListKeys retrieves every API key, revoked ones included
+// @return []*domain.APIKey - The keys, without their secrets
+// @return error - An error if the retrieval fails
+func (s *apiKeyServiceImpl) ListKeys() ([]*domain.APIKey, error) {
+	return s.repo.FindAll()
+}
+
+// RotateKey replaces the secret of an API key, keeping its ID, name and scopes
+// During the grace period both the old and the new key are accepted, so the client can be
+// redeployed without downtime; a zero grace disables the old key at once.
+// @param ctx context.Context - Carries the admin rotating the key
+// @param id int - The ID of the key
+// @param grace time.Duration - How long the old key keeps working, up to 7 days
+// @return *domain.IssuedAPIKey - The key together with its new secret
+// @return error - A *domain.ValidationError for an invalid grace period, domain.ErrAPIKeyNotFound if the key
+// does not exist or is revoked, or an error if the rotation fails
+func (s *apiKeyServiceImpl) RotateKey(ctx context.Context, id int, grace time.Duration) (*domain.IssuedAPIKey, error) {
+	if grace < 0 || grace > maxRotationGrace {
+		return nil, &domain.ValidationError{Field: "grace", Message: "must be between 0 and 168h"}
+	}
+	key, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	plain, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	key.PreviousPrefix, key.PreviousKeyHash, key.PreviousExpiresAt = "", "", nil
+	if grace > 0 {
+		until := now.Add(grace)
+		key.PreviousPrefix, key.PreviousKeyHash, key.PreviousExpiresAt = key.Prefix, key.KeyHash, &until
+	}
+	key.Prefix, key.KeyHash, key.RotatedAt = prefix, hashAPIKey(plain), &now
+	if err := s.repo.Rotate(key); err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: *key, Key: plain}, nil
+}
+
+// RevokeKey disables an API key, including a rotated key still in its grace period
+// @param ctx context.Context - Carries the admin revoking the key
+// @param id int - The ID of the key
+// @return error - domain.ErrAPIKeyNotFound if the key does not exist or is already revoked, or an error if the revocation fails
+func (s *apiKeyServiceImpl) RevokeKey(ctx context.Context, id int) error {
+	return s.repo.Revoke(id, s.now().UTC())
+}
+
+// Authenticate looks up the API key sent by a client
+// The key is matched by its prefix and compared by hash in constant time. A key replaced by a
+// rotation is accepted until its grace period ends.
+// @param key string - The X-API-Key header value
+// @return *domain.APIKey - The matching key
+// @return error - domain.ErrInvalidAPIKey if the key is unknown, expired or revoked, or an error if the lookup fails
+func (s *apiKeyServiceImpl) Authenticate(key string) (*domain.APIKey, error) {
+	prefix, ok := apiKeyPrefix(key)
+	if !ok {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	stored, err := s.repo.FindByPrefix(prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	hash := hashAPIKey(key)
+	current := prefix == stored.Prefix && hashesEqual(hash, stored.KeyHash)
+	previous := prefix == stored.PreviousPrefix && hashesEqual(hash, stored.PreviousKeyHash) &&
+		stored.PreviousExpiresAt != nil && now.Before(*stored.PreviousExpiresAt)
+	if !current && !previous {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if stored.RevokedAt != nil || (stored.ExpiresAt != nil && !now.Before(*stored.ExpiresAt)) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.TouchLastUsed(stored.ID, now); err != nil {
+			log.Printf("Failed to record the use of api key %d: %v", stored.ID, err)
+		}
+	}
+	return stored, nil
+}
+
+// validateKeyRequest checks the name, scopes and expiry of a new key and returns the deduplicated scopes
+func (s *apiKeyServiceImpl) validateKeyRequest(req domain.APIKeyRequest) ([]string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, &domain.ValidationError{Field: "name", Message: "must be between 1 and 100 characters"}
+	}
+	if len(req.Scopes) == 0 {
+		return nil, &domain.ValidationError{Field: "scopes", Message: "must grant at least one of " + strings.Join(domain.APIKeyScopes, ", ")}
+	}
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, scope := range req.Scopes {
+		if !isAPIKeyScope(scope) {
+			return nil, &domain.ValidationError{Field: "scopes", Message: "unknown scope " + scope}
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, &domain.ValidationError{Field: "expires_at", Message: "must be in the future"}
+	}
+	return scopes, nil
+}
+
+// isAPIKeyScope reports whether a scope can be granted to an API key
+func isAPIKeyScope(scope string) bool {
+	for _, known := range domain.APIKeyScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIKey returns a random key and its prefix
+// Keys look like "jk_<16 hex prefix>_<64 hex secret>"; the prefix is stored in clear to find the key.
+func newAPIKey() (key, prefix string, err error) {
+	b := make([]byte, 40)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(b[:8])
+	return apiKeyTag + prefix + "_" + hex.EncodeToString(b[8:]), prefix, nil
+}
+
+// apiKeyPrefix extracts the prefix of a key, false if the key is malformed
+func apiKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyTag)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	return prefix, ok && len(prefix) == 16 && secret != ""
+}
+
+// hashAPIKey returns the hex SHA-256 of a key
+// Keys carry 256 random bits, so a fast unsalted hash is enough to make a leaked table useless.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashesEqual compares two key hashes in constant time
+func hashesEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
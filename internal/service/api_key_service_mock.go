@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAPIKeyService is a mock implementation of APIKeyService for testing
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+// IssueKey mocks the IssueKey method
+func (m *MockAPIKeyService) IssueKey(ctx context.Context, req domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	args := m.Called(ctx, req)
+	if key, ok := args.Get(0).(*domain.IssuedAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListKeys mocks the ListKeys method
+func (m *MockAPIKeyService) ListKeys() ([]*domain.APIKey, error) {
+	args := m.Called()
+	if keys, ok := args.Get(0).([]*domain.APIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RotateKey mocks the RotateKey method
+func (m *MockAPIKeyService) RotateKey(ctx context.Context, id int, grace time.Duration) (*domain.IssuedAPIKey, error) {
+	args := m.Called(ctx, id, grace)
+	if key, ok := args.Get(0).(*domain.IssuedAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RevokeKey mocks the RevokeKey method
+func (m *MockAPIKeyService) RevokeKey(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Authenticate mocks the Authenticate method
+func (m *MockAPIKeyService) Authenticate(key string) (*domain.APIKey, error) {
+	args := m.Called(key)
+	if apiKey, ok := args.Get(0).(*domain.APIKey); ok {
+		return apiKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestAPIKeyService returns a service with a clock fixed at now
+func newTestAPIKeyService(repo repository.APIKeyRepository, now time.Time) APIKeyService {
+	return &apiKeyServiceImpl{repo: repo, now: func() time.Time { return now }}
+}
+
+func TestIssueKey(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockAPIKeyRepository)
+	apiKeyService := newTestAPIKeyService(mockRepo, time.Now())
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "admin-1"})
+
+	// Mock behavior
+	var stored *domain.APIKey
+	mockRepo.On("Create", mock.AnythingOfType("*domain.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*domain.APIKey) }).Return(nil)
+
+	// Execute
+	issued, err := apiKeyService.IssueKey(ctx, domain.APIKeyRequest{
+		Name:   "Greenhouse sync",
+		Scopes: []string{domain.ScopeJobsRead, domain.ScopeJobsRead, domain.ScopeJobsWrite},
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^jk_[0-9a-f]{16}_[0-9a-f]{64}$`), issued.Key)
+	assert.Equal(t, issued.Key[3:19], stored.Prefix)
+	assert.Equal(t, hashAPIKey(issued.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, issued.Key[20:])
+	assert.Equal(t, []string{domain.ScopeJobsRead, domain.ScopeJobsWrite}, stored.Scopes)
+	assert.Equal(t, "admin-1", stored.CreatedBy)
+}
+
+func TestIssueKey_Invalid(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockAPIKeyRepository)
+	now := time.Now()
+	apiKeyService := newTestAPIKeyService(mockRepo, now)
+	past := now.Add(-time.Hour)
+
+	for _, req := range []domain.APIKeyRequest{
+		{Name: " ", Scopes: []string{domain.ScopeJobsRead}},
+		{Name: "ATS"},
+		{Name: "ATS", Scopes: []string{"jobs:admin"}},
+		{Name: "ATS", Scopes: []string{domain.ScopeJobsRead}, ExpiresAt: &past},
+	} {
+		// Execute
+		_, err := apiKeyService.IssueKey(context.Background(), req)
+
+		// Assertions
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	key, prefix, _ := newAPIKey()
+	recently := now.Add(-30 * time.Second)
+	expired := now.Add(-time.Second)
+	revoked := now.Add(-time.Hour)
+	wrongSecret := key[:len(key)-1] + "0"
+	if wrongSecret == key {
+		wrongSecret = key[:len(key)-1] + "1"
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		stored  *domain.APIKey
+		wantErr error
+	}{
+		{"valid", key, &domain.APIKey{ID: 1, Prefix: prefix, KeyHash: hashAPIKey(key), LastUsedAt: &recently}, nil},
+		{"wrong secret", wrongSecret, &domain.APIKey{ID: 1, Prefix: prefix, KeyHash: hashAPIKey(key)}, domain.ErrInvalidAPIKey},
+		{"expired", key, &domain.APIKey{ID: 1, Prefix: prefix, KeyHash: hashAPIKey(key), ExpiresAt: &expired}, domain.ErrInvalidAPIKey},
+		{"revoked", key, &domain.APIKey{ID: 1, Prefix: prefix, KeyHash: hashAPIKey(key), RevokedAt: &revoked}, domain.ErrInvalidAPIKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRepo := new(repository.MockAPIKeyRepository)
+			apiKeyService := newTestAPIKeyService(mockRepo, now)
+
+			// Mock behavior
+			mockRepo.On("FindByPrefix", prefix).Return(tt.stored, nil)
+
+			// Execute
+			apiKey, err := apiKeyService.Authenticate(tt.key)
+
+			// Assertions
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, apiKey.ID)
+			mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticate_Malformed(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockAPIKeyRepository)
+	apiKeyService := newTestAPIKeyService(mockRepo, time.Now())
+
+	for _, key := range []string{"", "secret", "jk_short_abc", "jk_0123456789abcdef"} {
+		// Execute
+		_, err := apiKeyService.Authenticate(key)
+
+		// Assertions
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, key)
+	}
+	mockRepo.AssertNotCalled(t, "FindByPrefix", mock.Anything)
+}
+
+func TestRotateKey_GracePeriod(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockAPIKeyRepository)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiKeyService := newTestAPIKeyService(mockRepo, now)
+	oldKey, oldPrefix, _ := newAPIKey()
+	stored := &domain.APIKey{ID: 3, Name: "ATS", Prefix: oldPrefix, KeyHash: hashAPIKey(oldKey), Scopes: []string{domain.ScopeJobsRead}}
+
+	// Mock behavior
+	mockRepo.On("FindByID", 3).Return(stored, nil)
+	mockRepo.On("Rotate", stored).Return(nil)
+	mockRepo.On("TouchLastUsed", 3, mock.Anything).Return(nil)
+
+	// Execute
+	issued, err := apiKeyService.RotateKey(context.Background(), 3, time.Hour)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldKey, issued.Key)
+	assert.Equal(t, oldPrefix, stored.PreviousPrefix)
+	assert.Equal(t, now.Add(time.Hour), *stored.PreviousExpiresAt)
+
+	// Both keys are accepted during the grace period, only the new one afterwards
+	for _, key := range []string{oldKey, issued.Key} {
+		mockRepo.On("FindByPrefix", key[3:19]).Return(stored, nil)
+		_, err := apiKeyService.Authenticate(key)
+		assert.NoError(t, err)
+	}
+	later := newTestAPIKeyService(mockRepo, now.Add(2*time.Hour))
+	_, err = later.Authenticate(oldKey)
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	_, err = later.Authenticate(issued.Key)
+	assert.NoError(t, err)
+}
+
+func TestRotateKey_Revoked(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockAPIKeyRepository)
+	apiKeyService := newTestAPIKeyService(mockRepo, time.Now())
+	revoked := time.Now().Add(-time.Hour)
+
+	// Mock behavior
+	mockRepo.On("FindByID", 3).Return(&domain.APIKey{ID: 3, RevokedAt: &revoked}, nil)
+
+	// Execute
+	_, err := apiKeyService.RotateKey(context.Background(), 3, 0)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	mockRepo.AssertNotCalled(t, "Rotate", mock.Anything)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// auditIgnoredFields are snapshot fields maintained by the database, left out of the change set
+var auditIgnoredFields = map[string]bool{"updated_at": true, "version": true}
+
+// recordJobChange appends an audit entry for a job mutation
+// Nothing is recorded when repos has no audit repository.
+// @param ctx context.Context - Carries the actor and request ID
+// @param repos repository.TxRepositories - The repositories of the current transaction
+// @param action string - The audited action (e.g., domain.AuditActionUpdate)
+// @param id int - The ID of the job
+// @param before *domain.Job - The job before the change, nil on create
+// @param after *domain.Job - The job after the change, nil on delete
+// @return error - An error if the entry cannot be encoded or stored
+func recordJobChange(ctx context.Context, repos repository.TxRepositories, action string, id int, before, after *domain.Job) error {
+	if repos.Audit == nil {
+		return nil
+	}
+	beforeJSON, err := snapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+	changes, err := diffSnapshots(beforeJSON, afterJSON)
+	if err != nil {
+		return err
+	}
+
+	actor := domain.ActorFromContext(ctx)
+	return repos.Audit.Append(&domain.AuditEntry{
+		EntityType: domain.AuditEntityJob,
+		EntityID:   id,
+		Action:     action,
+		Actor:      actor.Subject,
+		RequestID:  actor.RequestID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Changes:    changes,
+	})
+}
+
+// snapshot encodes an entity as JSON, returning nil for a nil pointer
+func snapshot(entity *domain.Job) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	return json.Marshal(entity)
+}
+
+// diffSnapshots lists the top-level fields whose value differs between two JSON objects
+// A missing snapshot counts as an object with no fields, so creates and deletes list every field.
+func diffSnapshots(before, after json.RawMessage) (map[string]domain.FieldChange, error) {
+	beforeFields, err := decodeFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := decodeFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]domain.FieldChange)
+	for field, value := range afterFields {
+		if !auditIgnoredFields[field] && !reflect.DeepEqual(beforeFields[field], value) {
+			changes[field] = domain.FieldChange{From: beforeFields[field], To: value}
+		}
+	}
+	for field, value := range beforeFields {
+		if _, ok := afterFields[field]; !ok && !auditIgnoredFields[field] {
+			changes[field] = domain.FieldChange{From: value, To: nil}
+		}
+	}
+	return changes, nil
+}
+
+// decodeFields decodes a JSON object into its top-level fields
+func decodeFields(raw json.RawMessage) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if len(raw) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
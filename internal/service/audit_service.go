@@ -0,0 +1,44 @@
+package service
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// AuditService defines methods for querying the audit log
+// This interface abstracts read access to the recorded job mutations.
+type AuditService interface {
+	GetJobHistory(jobID int) ([]*domain.AuditEntry, error)                  // Retrieves the changes made to a job
+	SearchAuditLog(filter domain.AuditFilter) ([]*domain.AuditEntry, error) // Retrieves the audit entries matching a filter
+}
+
+type auditServiceImpl struct {
+	repo repository.AuditRepository // Dependency on the AuditRepository
+}
+
+// NewAuditService creates a new AuditService instance
+// @param repo repository.AuditRepository - The repository to interact with the database
+// @return AuditService - The implementation of the service interface
+func NewAuditService(repo repository.AuditRepository) AuditService {
+	return &auditServiceImpl{repo: repo}
+}
+
+// GetJobHistory retrieves the changes made to a job, newest first
+// The history remains available after the job is deleted or purged.
+// @param jobID int - The ID of the job
+// @return []*domain.AuditEntry - The audit entries of the job
+// @return error - An error if the retrieval fails
+func (s *auditServiceImpl) GetJobHistory(jobID int) ([]*domain.AuditEntry, error) {
+	return s.repo.Find(domain.AuditFilter{EntityType: domain.AuditEntityJob, EntityID: jobID, Limit: 500})
+}
+
+// SearchAuditLog retrieves the audit entries matching a filter, newest first
+// @param filter domain.AuditFilter - The criteria the entries must match
+// @return []*domain.AuditEntry - The matching entries
+// @return error - A *domain.ValidationError for an inverted time range, or an error if the retrieval fails
+func (s *auditServiceImpl) SearchAuditLog(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, &domain.ValidationError{Field: "from", Message: "must be before to"}
+	}
+	return s.repo.Find(filter)
+}
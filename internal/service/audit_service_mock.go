@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditService is a mock implementation of AuditService for testing
+type MockAuditService struct {
+	mock.Mock
+}
+
+// GetJobHistory mocks the GetJobHistory method
+func (m *MockAuditService) GetJobHistory(jobID int) ([]*domain.AuditEntry, error) {
+	args := m.Called(jobID)
+	if entries, ok := args.Get(0).([]*domain.AuditEntry); ok {
+		return entries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// SearchAuditLog mocks the SearchAuditLog method
+func (m *MockAuditService) SearchAuditLog(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	args := m.Called(filter)
+	if entries, ok := args.Get(0).([]*domain.AuditEntry); ok {
+		return entries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newAuditedJobService builds a JobService whose mutations run through a mocked transactor
+func newAuditedJobService() (JobService, *repository.MockJobRepository, *repository.MockAuditRepository) {
+	mockRepo := new(repository.MockJobRepository)
+	mockAudit := new(repository.MockAuditRepository)
+	mockTx := &repository.MockTransactor{Repos: repository.TxRepositories{Jobs: mockRepo, Audit: mockAudit}}
+	mockTx.On("WithinTx")
+	return NewJobService(mockRepo, WithTransactor(mockTx)), mockRepo, mockAudit
+}
+
+func TestUpdateJob_RecordsAuditEntry(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockAudit := newAuditedJobService()
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "user-7", RequestID: "req-1"})
+
+	// Mock data
+	before := &domain.Job{ID: 5, Title: "Go Developer", Description: "APIs", SalaryRange: "50k", Status: domain.JobStatusPublished}
+	job := &domain.Job{ID: 5, Title: "Senior Go Developer", Description: "APIs", SalaryRange: "50k"}
+
+	// Mock behavior
+	mockRepo.On("FindByID", 5).Return(before, nil)
+	mockRepo.On("Update", job).Return(nil)
+	mockAudit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		_, titleChanged := entry.Changes["title"]
+		return entry.EntityType == domain.AuditEntityJob && entry.EntityID == 5 &&
+			entry.Action == domain.AuditActionUpdate && entry.Actor == "user-7" && entry.RequestID == "req-1" &&
+			titleChanged && len(entry.Changes) == 1
+	})).Return(nil)
+
+	// Execute
+	err := jobService.UpdateJob(ctx, job)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestChangeJobStatus(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockAudit := newAuditedJobService()
+
+	// Mock behavior
+	mockRepo.On("FindByID", 8).Return(&domain.Job{ID: 8, Title: "QA", Status: domain.JobStatusDraft}, nil)
+	mockRepo.On("UpdateStatus", 8, domain.JobStatusPublished, 0).Return(nil)
+	mockAudit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		change := entry.Changes["status"]
+		return entry.Action == domain.AuditActionStatusChange &&
+			change.From == domain.JobStatusDraft && change.To == domain.JobStatusPublished
+	})).Return(nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 8, domain.JobStatusPublished, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestChangeJobStatus_StaleVersion(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockAudit := newAuditedJobService()
+
+	// Mock behavior: the job moved to version 4 since the client read it
+	mockRepo.On("FindByID", 8).Return(&domain.Job{ID: 8, Status: domain.JobStatusDraft, Version: 4}, nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 8, domain.JobStatusPublished, 3)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockAudit.AssertNotCalled(t, "Append", mock.Anything)
+}
+
+func TestChangeJobStatus_InvalidStatus(t *testing.T) {
+	// Setup
+	jobService, mockRepo, _ := newAuditedJobService()
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 8, "archived", 0)
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", 8, "archived", mock.Anything)
+}
+
+func TestDeleteJob_RecordsBeforeSnapshot(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockAudit := newAuditedJobService()
+
+	// Mock behavior
+	mockRepo.On("FindByID", 3).Return(&domain.Job{ID: 3, Title: "Designer"}, nil)
+	mockRepo.On("Delete", 3, 0).Return(nil)
+	mockAudit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.Action == domain.AuditActionDelete && entry.Before != nil && entry.After == nil
+	})).Return(nil)
+
+	// Execute
+	err := jobService.DeleteJob(context.Background(), 3, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestSearchAuditLog_InvertedRange(t *testing.T) {
+	// Setup
+	mockAudit := new(repository.MockAuditRepository)
+	auditService := NewAuditService(mockAudit)
+	now := time.Now()
+
+	// Execute
+	_, err := auditService.SearchAuditLog(domain.AuditFilter{From: now, To: now.Add(-time.Hour)})
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockAudit.AssertNotCalled(t, "Find", mock.Anything)
+}
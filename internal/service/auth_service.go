@@ -0,0 +1,537 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/loginguard"
+	"github.com/poolcamacho/jobs-service/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Default lifetimes of the issued tokens
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AuthService defines methods for registering users and issuing their tokens
+type AuthService interface {
+	Register(req domain.RegisterRequest) error                                      // Creates a user account
+	Login(ctx context.Context, req domain.LoginRequest) (*domain.AuthTokens, error) // Exchanges credentials for tokens
+	Refresh(refreshToken string) (*domain.AuthTokens, error)                        // Exchanges a refresh token for new tokens
+	Unlock(ctx context.Context, userID int) error                                   // Lifts the login lockout of a user
+	SetRole(ctx context.Context, userID int, role string) (*domain.User, error)     // Changes the role of a user
+	PurgeExpired() (int64, error)                                                   // Removes the refresh tokens past their expiry
+}
+
+// AuthOption configures the tokens issued by the auth service
+type AuthOption func(*authServiceImpl)
+
+// WithAccessTokenTTL sets the lifetime of the access tokens
+// @param ttl time.Duration - The lifetime (default: DefaultAccessTokenTTL)
+// @return AuthOption - The option to pass to NewAuthService
+func WithAccessTokenTTL(ttl time.Duration) AuthOption {
+	return func(s *authServiceImpl) {
+		s.accessTTL = ttl
+	}
+}
+
+// WithRefreshTokenTTL sets the lifetime of the refresh tokens
+// @param ttl time.Duration - The lifetime (default: DefaultRefreshTokenTTL)
+// @return AuthOption - The option to pass to NewAuthService
+func WithRefreshTokenTTL(ttl time.Duration) AuthOption {
+	return func(s *authServiceImpl) {
+		s.refreshTTL = ttl
+	}
+}
+
+// WithTokenIssuer sets the "iss" and "aud" claims of the access tokens
+// Needed when the auth middleware only accepts given issuers and audiences.
+// @param issuer string - The "iss" claim, empty to leave it out
+// @param audience []string - The "aud" claim, empty to leave it out
+// @return AuthOption - The option to pass to NewAuthService
+func WithTokenIssuer(issuer string, audience []string) AuthOption {
+	return func(s *authServiceImpl) {
+		s.issuer, s.audience = issuer, audience
+	}
+}
+
+// WithPasswordPolicy sets the policy the passwords of new users must meet
+// @param policy *utils.PasswordPolicy - The policy (default: no policy)
+// @return AuthOption - The option to pass to NewAuthService
+func WithPasswordPolicy(policy *utils.PasswordPolicy) AuthOption {
+	return func(s *authServiceImpl) {
+		s.policy = policy
+	}
+}
+
+// WithLoginGuard throttles and locks out the logins failing too often
+// @param guard *loginguard.Guard - Counts the failures per account and per IP (default: no throttling)
+// @return AuthOption - The option to pass to NewAuthService
+func WithLoginGuard(guard *loginguard.Guard) AuthOption {
+	return func(s *authServiceImpl) {
+		s.guard = guard
+	}
+}
+
+// WithLoginAudit records failed logins, lockouts, unlocks and role changes in the audit log
+// @param audit repository.AuditRepository - The audit log (default: nothing recorded)
+// @return AuthOption - The option to pass to NewAuthService
+func WithLoginAudit(audit repository.AuditRepository) AuthOption {
+	return func(s *authServiceImpl) {
+		s.audit = audit
+	}
+}
+
+// WithRevocationList stops the refreshes of users whose tokens were revoked
+// @param revocations jwtUtil.RevocationList - The revocations checked by the auth middleware (default: none)
+// @return AuthOption - The option to pass to NewAuthService
+func WithRevocationList(revocations jwtUtil.RevocationList) AuthOption {
+	return func(s *authServiceImpl) {
+		s.revocations = revocations
+	}
+}
+
+type authServiceImpl struct {
+	users         repository.UserRepository         // Dependency on the UserRepository
+	refreshTokens repository.RefreshTokenRepository // Dependency on the RefreshTokenRepository
+	passwords     utils.PasswordUtils               // Hashes and checks the passwords
+	policy        *utils.PasswordPolicy             // Checked on registration, nil if none
+	guard         *loginguard.Guard                 // Throttles the failing logins, nil if none
+	audit         repository.AuditRepository        // Records the login failures, lockouts, unlocks and role changes, nil if none
+	revocations   jwtUtil.RevocationList            // Subject revocations also covering the refresh tokens, nil if none
+	secret        string                            // HMAC secret signing the access tokens
+	issuer        string                            // "iss" claim of the access tokens, empty if none
+	audience      []string                          // "aud" claim of the access tokens, empty if none
+	accessTTL     time.Duration                     // Lifetime of the access tokens
+	refreshTTL    time.Duration                     // Lifetime of the refresh tokens
+	now           func() time.Time                  // Clock, replaced in tests
+
+	dummyOnce sync.Once // Computes dummyHash on the first login of an unknown email
+	dummyHash string    // Checked for unknown emails, so they take as long as wrong passwords
+}
+
+// NewAuthService creates a new AuthService instance
+// @param users repository.UserRepository - The repository storing the users
+// @param refreshTokens repository.RefreshTokenRepository - The repository storing the refresh token hashes
+// @param passwords utils.PasswordUtils - Hashes and checks the passwords
+// @param secret string - The HMAC secret signing the access tokens (HS256)
+// @param opts ...AuthOption - Optional token lifetimes, issuer, audience, password policy and login protection
+// @return AuthService - The implementation of the service interface
+func NewAuthService(users repository.UserRepository, refreshTokens repository.RefreshTokenRepository,
+	passwords utils.PasswordUtils, secret string, opts ...AuthOption) AuthService {
+	s := &authServiceImpl{
+		users:         users,
+		refreshTokens: refreshTokens,
+		passwords:     passwords,
+		secret:        secret,
+		accessTTL:     DefaultAccessTokenTTL,
+		refreshTTL:    DefaultRefreshTokenTTL,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register creates a user account with the user role, which can read jobs but not change them
+// Taken usernames are reported before the email is looked at, as they are not used to log in.
+// Past that check, an email that is already registered gets the same answer as a new one and
+// creates nothing, so registration does not reveal which emails exist.
+// @param req domain.RegisterRequest - The username, email and password of the user
+// @return error - A *domain.ValidationError for an invalid username or a password rejected by the policy,
+// domain.ErrUsernameTaken if the username is taken, or an error if the creation fails
+func (s *authServiceImpl) Register(req domain.RegisterRequest) error {
+	username := strings.TrimSpace(req.Username)
+	if len(username) < 3 || len(username) > 100 {
+		return &domain.ValidationError{Field: "username", Message: "must be between 3 and 100 characters"}
+	}
+	email := normalizeEmail(req.Email)
+	if s.policy != nil {
+		if err := s.policy.Check(req.Password, username, email); err != nil {
+			return &domain.ValidationError{Field: "password", Message: err.Error()}
+		}
+	}
+	_, err := s.users.FindByUsername(username)
+	if err == nil {
+		return domain.ErrUsernameTaken
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	hash, err := s.passwords.HashPassword(req.Password)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return &domain.ValidationError{Field: "password", Message: "must be at most 72 bytes"}
+	}
+	if err != nil {
+		return err
+	}
+
+	user := &domain.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: hash,
+		Role:         domain.RoleUser,
+	}
+	// The username was free, so a duplicate is the email (or a username taken since), answered as a success
+	if err := s.users.Create(user); err != nil && !errors.Is(err, domain.ErrUserExists) {
+		return err
+	}
+	return nil
+}
+
+// Login exchanges the credentials of a user for an access and a refresh token
+// A password hashed with another algorithm or other parameters than the current ones is hashed
+// again, so configuration changes reach every user at their next login.
+// With a login guard, each attempt is counted as a failure before the credentials are checked, so
+// parallel guesses cannot all pass the throttle, and attempts on an account or from an IP that
+// failed too often are rejected. A success clears the count of the account. Unknown emails are
+// counted, delayed and locked like registered ones, and cost the same password check and writes,
+// so neither the responses nor their timing reveal which emails are registered.
+// @param ctx context.Context - Carries the client IP and the request ID recorded in the audit log
+// @param req domain.LoginRequest - The email and password of the user
+// @return *domain.AuthTokens - The tokens
+// @return error - A *domain.LoginThrottledError if the attempt comes too soon after failures,
+// domain.ErrInvalidCredentials if the email is unknown or the password wrong, or an error if the lookup fails
+func (s *authServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (*domain.AuthTokens, error) {
+	email := normalizeEmail(req.Email)
+	ip := domain.ActorFromContext(ctx).IP
+	attempt, err := s.reserveAttempt(email, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.FindByEmail(email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// Spend the time of a password check anyway, so response times do not reveal registered emails
+		s.passwords.CheckPassword(s.unknownUserHash(), req.Password)
+		s.loginFailed(ctx, 0, email, ip, attempt)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.passwords.CheckPassword(user.PasswordHash, req.Password); err != nil {
+		s.loginFailed(ctx, user.ID, email, ip, attempt)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if s.guard != nil {
+		if err := s.guard.Succeed(attempt); err != nil {
+			log.Printf("Failed to reset the login failures of user %d: %v", user.ID, err)
+		}
+	}
+	if s.passwords.NeedsRehash(user.PasswordHash) {
+		s.rehash(user, req.Password)
+	}
+
+	familyID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	// Second precision, like the "iat" claim the subject revocations are compared with
+	return s.issueTokens(user, familyID, s.now().UTC().Truncate(time.Second))
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token
+// Each refresh token can be used once. Presenting one again means it was stolen (or the client
+// is misbehaving), so every token descending from the same login is revoked. So is a family
+// whose login happened before the tokens of its user were revoked.
+// @param refreshToken string - The refresh token returned by the last login or refresh
+// @return *domain.AuthTokens - The new tokens
+// @return error - domain.ErrInvalidRefreshToken if the token is unknown, expired, revoked or already used,
+// or an error if the lookup or the revocation check fails
+func (s *authServiceImpl) Refresh(refreshToken string) (*domain.AuthTokens, error) {
+	stored, err := s.refreshTokens.FindByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	if stored.RevokedAt != nil || !now.Before(stored.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if s.revocations != nil {
+		// Like the verifier, reject rather than refresh unchecked when the revocations cannot be read
+		revoked, err := s.revocations.IsRevoked("", strconv.Itoa(stored.UserID), stored.FamilyIssuedAt, now)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			if err := s.refreshTokens.RevokeFamily(stored.FamilyID, now); err != nil {
+				return nil, err
+			}
+			return nil, domain.ErrInvalidRefreshToken
+		}
+	}
+	if stored.UsedAt != nil {
+		return nil, s.revokeFamily(stored, now)
+	}
+	if err := s.refreshTokens.MarkUsed(stored.ID, now); err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			// Used concurrently by another request
+			return nil, s.revokeFamily(stored, now)
+		}
+		return nil, err
+	}
+
+	// Reload the user, so a role change applies at the next refresh
+	user, err := s.users.FindByID(stored.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(user, stored.FamilyID, stored.FamilyIssuedAt)
+}
+
+// Unlock lifts the login lockout and delays of a user
+// The failures counted for the IPs the user logged in from are kept.
+// @param ctx context.Context - Carries the admin recorded in the audit log
+// @param userID int - The ID of the user
+// @return error - domain.ErrUserNotFound if the user does not exist, or an error if the lookup or the reset fails
+func (s *authServiceImpl) Unlock(ctx context.Context, userID int) error {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if s.guard != nil {
+		if err := s.guard.Unlock(user.Email); err != nil {
+			return err
+		}
+	}
+	s.recordUserEvent(ctx, domain.AuditActionUnlock, user.ID, map[string]interface{}{"email": user.Email})
+	return nil
+}
+
+// SetRole changes the role of a user, and so the scopes of their next access tokens
+// The tokens already issued keep their scopes until they expire; the change applies at the next refresh.
+// @param ctx context.Context - Carries the admin recorded in the audit log
+// @param userID int - The ID of the user
+// @param role string - The new role, one of domain.UserRoles
+// @return *domain.User - The user with the new role
+// @return error - A *domain.ValidationError for an unknown role, domain.ErrUserNotFound if the user does not
+// exist, or an error if the lookup or the update fails
+func (s *authServiceImpl) SetRole(ctx context.Context, userID int, role string) (*domain.User, error) {
+	if !slices.Contains(domain.UserRoles, role) {
+		return nil, &domain.ValidationError{Field: "role", Message: "must be one of " + strings.Join(domain.UserRoles, ", ")}
+	}
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	if err := s.users.UpdateRole(user.ID, role); err != nil {
+		return nil, err
+	}
+	s.recordUserEvent(ctx, domain.AuditActionRoleChange, user.ID, map[string]interface{}{"previous_role": user.Role, "role": role})
+	user.Role = role
+	return user, nil
+}
+
+// PurgeExpired removes the refresh tokens past their expiry
+// @return int64 - The number of removed tokens
+// @return error - An error if the query fails
+func (s *authServiceImpl) PurgeExpired() (int64, error) {
+	return s.refreshTokens.DeleteExpired(s.now().UTC())
+}
+
+// reserveAttempt counts a login attempt, or rejects it if too soon after failures of the account or the IP
+// If the guard fails, the login goes ahead: a store outage must not lock every user out.
+func (s *authServiceImpl) reserveAttempt(email, ip string) (loginguard.Attempt, error) {
+	if s.guard == nil {
+		return loginguard.Attempt{}, nil
+	}
+	decision, attempt, err := s.guard.Reserve(email, ip, s.now())
+	if err != nil {
+		log.Printf("Failed to count a login attempt from %s: %v", ip, err)
+		return attempt, nil
+	}
+	if !decision.Allowed() {
+		return attempt, &domain.LoginThrottledError{RetryAfter: decision.RetryAfter, Locked: decision.Locked}
+	}
+	return attempt, nil
+}
+
+// loginFailed records a failed login, and the lockout it may cause, in the audit log
+// The attempt was already counted by reserveAttempt. userID is zero for an unknown email.
+func (s *authServiceImpl) loginFailed(ctx context.Context, userID int, email, ip string, attempt loginguard.Attempt) {
+	details := map[string]interface{}{"email": email, "ip": ip}
+	if s.guard != nil {
+		details["account_failures"], details["ip_failures"] = attempt.AccountFailures, attempt.IPFailures
+	}
+
+	s.recordUserEvent(ctx, domain.AuditActionLoginFailed, userID, details)
+	if attempt.AccountLocked || attempt.IPLocked {
+		details["account_locked"], details["ip_locked"] = attempt.AccountLocked, attempt.IPLocked
+		s.recordUserEvent(ctx, domain.AuditActionLockout, userID, details)
+	}
+}
+
+// recordUserEvent appends a user audit entry whose snapshot holds the details of the event
+// Failures are logged: the audit log must not decide whether a user can log in.
+func (s *authServiceImpl) recordUserEvent(ctx context.Context, action string, userID int, details map[string]interface{}) {
+	if s.audit == nil {
+		return
+	}
+	after, err := json.Marshal(details)
+	if err == nil {
+		var changes map[string]domain.FieldChange
+		if changes, err = diffSnapshots(nil, after); err == nil {
+			actor := domain.ActorFromContext(ctx)
+			err = s.audit.Append(&domain.AuditEntry{
+				EntityType: domain.AuditEntityUser,
+				EntityID:   userID,
+				Action:     action,
+				Actor:      actor.Subject,
+				RequestID:  actor.RequestID,
+				After:      after,
+				Changes:    changes,
+			})
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to record %s of user %d in the audit log: %v", action, userID, err)
+	}
+}
+
+// rehash stores a new hash of the password of a user; failures are logged and do not fail the login
+func (s *authServiceImpl) rehash(user *domain.User, password string) {
+	hash, err := s.passwords.HashPassword(password)
+	if err == nil {
+		err = s.users.UpdatePasswordHash(user.ID, hash)
+	}
+	if err != nil {
+		log.Printf("Failed to rehash the password of user %d: %v", user.ID, err)
+		return
+	}
+	user.PasswordHash = hash
+}
+
+// revokeFamily revokes the tokens of the login a reused refresh token descends from
+func (s *authServiceImpl) revokeFamily(reused *domain.RefreshToken, now time.Time) error {
+	log.Printf("Refresh token %d of user %d reused, revoking its family %s", reused.ID, reused.UserID, reused.FamilyID)
+	if err := s.refreshTokens.RevokeFamily(reused.FamilyID, now); err != nil {
+		return err
+	}
+	return domain.ErrInvalidRefreshToken
+}
+
+// issueTokens signs an access token for the user and stores a new refresh token in the given family
+func (s *authServiceImpl) issueTokens(user *domain.User, familyID string, familyIssuedAt time.Time) (*domain.AuthTokens, error) {
+	now := s.now().UTC()
+	jti, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	claims := &jwtUtil.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.Itoa(user.ID),
+			Issuer:    s.issuer,
+			Audience:  s.audience,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTTL)),
+		},
+		Role:  user.Role,
+		Email: user.Email,
+		Scope: strings.Join(domain.RoleScopes(user.Role), " "),
+	}
+	accessToken, err := jwtUtil.GenerateToken(s.secret, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokens.Create(&domain.RefreshToken{
+		UserID:         user.ID,
+		FamilyID:       familyID,
+		FamilyIssuedAt: familyIssuedAt,
+		TokenHash:      hashRefreshToken(refreshToken),
+		ExpiresAt:      now.Add(s.refreshTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthTokens{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(s.accessTTL / time.Second),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(s.refreshTTL / time.Second),
+	}, nil
+}
+
+// unknownUserHash returns the hash checked for unknown emails, computing it once
+func (s *authServiceImpl) unknownUserHash() string {
+	s.dummyOnce.Do(func() {
+		s.dummyHash, _ = s.passwords.HashPassword("not-a-registered-password")
+	})
+	return s.dummyHash
+}
+
+// normalizeEmail trims and lowercases an email, so each address can only register once
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// hashRefreshToken returns the hex SHA-256 stored instead of a refresh token
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RunRefreshTokenPurger periodically removes the refresh tokens past their expiry
+// It purges once at start-up and then on every interval tick, until ctx is cancelled.
+// Failures are logged and retried on the next tick.
+// @param ctx context.Context - Stops the purger when cancelled
+// @param auth AuthService - The service used to purge the tokens
+// @param interval time.Duration - How often the purge runs
+func RunRefreshTokenPurger(ctx context.Context, auth AuthService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := auth.PurgeExpired()
+		if err != nil {
+			log.Printf("Failed to purge expired refresh tokens: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d expired refresh tokens", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuthService is a mock implementation of AuthService for testing
+type MockAuthService struct {
+	mock.Mock
+}
+
+// Register mocks the Register method
+func (m *MockAuthService) Register(req domain.RegisterRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+// Login mocks the Login method
+func (m *MockAuthService) Login(ctx context.Context, req domain.LoginRequest) (*domain.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if tokens, ok := args.Get(0).(*domain.AuthTokens); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Refresh mocks the Refresh method
+func (m *MockAuthService) Refresh(refreshToken string) (*domain.AuthTokens, error) {
+	args := m.Called(refreshToken)
+	if tokens, ok := args.Get(0).(*domain.AuthTokens); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Unlock mocks the Unlock method
+func (m *MockAuthService) Unlock(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// SetRole mocks the SetRole method
+func (m *MockAuthService) SetRole(ctx context.Context, userID int, role string) (*domain.User, error) {
+	args := m.Called(ctx, userID, role)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// PurgeExpired mocks the PurgeExpired method
+func (m *MockAuthService) PurgeExpired() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
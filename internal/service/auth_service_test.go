@@ -0,0 +1,604 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/loginguard"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+	"github.com/poolcamacho/jobs-service/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const authTestSecret = "auth-test-secret"
+
+// newTestAuthService returns an auth service with mocked dependencies and a clock fixed at now
+func newTestAuthService(now time.Time) (*authServiceImpl, *repository.MockUserRepository, *repository.MockRefreshTokenRepository, *utils.MockPasswordUtils) {
+	users := new(repository.MockUserRepository)
+	refreshTokens := new(repository.MockRefreshTokenRepository)
+	passwords := new(utils.MockPasswordUtils)
+	s := NewAuthService(users, refreshTokens, passwords, authTestSecret,
+		WithTokenIssuer("jobs-service", []string{"jobs-service"})).(*authServiceImpl)
+	s.now = func() time.Time { return now }
+	return s, users, refreshTokens, passwords
+}
+
+func TestRegister(t *testing.T) {
+	// Setup
+	authService, users, _, passwords := newTestAuthService(time.Now())
+
+	// Mock behavior
+	users.On("FindByUsername", "ana").Return(nil, domain.ErrUserNotFound)
+	passwords.On("HashPassword", "s3cret-pass").Return("hashed", nil)
+	users.On("Create", mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "ana@example.com" && user.Username == "ana" && user.PasswordHash == "hashed" && user.Role == "user"
+	})).Return(nil)
+
+	// Execute
+	err := authService.Register(domain.RegisterRequest{Username: " ana ", Email: " Ana@Example.com", Password: "s3cret-pass"})
+
+	// Assertions
+	assert.NoError(t, err)
+	users.AssertExpectations(t)
+}
+
+func TestRegister_UsernameTaken(t *testing.T) {
+	// Setup
+	authService, users, _, passwords := newTestAuthService(time.Now())
+
+	// Mock behavior: "ana" is taken and so is the email of Ana
+	users.On("FindByUsername", "ana").Return(&domain.User{ID: 3, Username: "ana", Email: "ana@example.com"}, nil)
+
+	// Execute: reuse the taken username with a registered and an unregistered email
+	known := authService.Register(domain.RegisterRequest{Username: "ana", Email: "ana@example.com", Password: "s3cret-pass"})
+	unknown := authService.Register(domain.RegisterRequest{Username: "ana", Email: "nobody@example.com", Password: "s3cret-pass"})
+
+	// Assertions: both get the same answer, so the username cannot be used to test emails
+	assert.ErrorIs(t, known, domain.ErrUsernameTaken)
+	assert.Equal(t, known, unknown)
+	passwords.AssertNotCalled(t, "HashPassword", mock.Anything)
+	users.AssertNotCalled(t, "Create", mock.Anything)
+	users.AssertNotCalled(t, "FindByEmail", mock.Anything)
+}
+
+func TestRegister_EmailTakenLooksLikeSuccess(t *testing.T) {
+	// Setup
+	authService, users, _, passwords := newTestAuthService(time.Now())
+
+	// Mock behavior: the username is free, the email is not
+	users.On("FindByUsername", "someone").Return(nil, domain.ErrUserNotFound)
+	passwords.On("HashPassword", mock.Anything).Return("hashed", nil)
+	users.On("Create", mock.Anything).Return(domain.ErrUserExists)
+
+	// Execute
+	err := authService.Register(domain.RegisterRequest{Username: "someone", Email: "Ana@example.com", Password: "s3cret-pass"})
+
+	// Assertions: the answer is the one of a new account
+	assert.NoError(t, err)
+	users.AssertExpectations(t)
+}
+
+func TestRegister_PasswordPolicy(t *testing.T) {
+	// Setup
+	authService, users, _, passwords := newTestAuthService(time.Now())
+	authService.policy = &utils.PasswordPolicy{MinLength: 8, MaxLength: 72}
+
+	tests := map[string]string{
+		"too short":     "short",
+		"too long":      strings.Repeat("a", 73),
+		"username":      "anabelle-1",
+		"email local":   "xx-anabelle",
+		"near username": "anabele1",
+	}
+	for name, password := range tests {
+		// Execute
+		err := authService.Register(domain.RegisterRequest{Username: "anabelle", Email: "anabelle@example.com", Password: password})
+
+		// Assertions
+		var validationErr *domain.ValidationError
+		if assert.ErrorAs(t, err, &validationErr, name) {
+			assert.Equal(t, "password", validationErr.Field, name)
+		}
+	}
+	passwords.AssertNotCalled(t, "HashPassword", mock.Anything)
+	users.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRegister_BreachedPassword(t *testing.T) {
+	// Setup: one plain entry and one SHA-1 entry ("password1") in the downloadable format
+	authService, users, _, _ := newTestAuthService(time.Now())
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	list := "# common passwords\nletmein-please\nE38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D:2413945\n"
+	assert.NoError(t, os.WriteFile(path, []byte(list), 0o600))
+	policy := &utils.PasswordPolicy{MinLength: 8}
+	assert.NoError(t, policy.LoadBreachedPasswords(path))
+	authService.policy = policy
+
+	for _, password := range []string{"letmein-please", "password1"} {
+		// Execute
+		err := authService.Register(domain.RegisterRequest{Username: "ana", Email: "ana@example.com", Password: password})
+
+		// Assertions
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr, password)
+	}
+	assert.Equal(t, 2, policy.BreachedCount())
+	users.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestLogin(t *testing.T) {
+	// Setup
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, users, refreshTokens, passwords := newTestAuthService(now)
+	user := &domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "hashed", Role: "admin"}
+
+	// Mock behavior
+	users.On("FindByEmail", "ana@example.com").Return(user, nil)
+	passwords.On("CheckPassword", "hashed", "s3cret-pass").Return(nil)
+	passwords.On("NeedsRehash", "hashed").Return(false)
+	var stored *domain.RefreshToken
+	refreshTokens.On("Create", mock.AnythingOfType("*domain.RefreshToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*domain.RefreshToken) }).Return(nil)
+
+	// Execute
+	tokens, err := authService.Login(context.Background(), domain.LoginRequest{Email: "ANA@example.com", Password: "s3cret-pass"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, 900, tokens.ExpiresIn)
+	assert.Equal(t, hashRefreshToken(tokens.RefreshToken), stored.TokenHash)
+	assert.Equal(t, now.Add(DefaultRefreshTokenTTL), stored.ExpiresAt)
+	assert.Len(t, stored.FamilyID, 32)
+	assert.Equal(t, now, stored.FamilyIssuedAt)
+
+	// The access token passes the strict validation of the auth middleware
+	verifier, _ := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret(authTestSecret),
+		jwtUtil.WithIssuer("jobs-service"), jwtUtil.WithAudience("jobs-service"), jwtUtil.WithRequiredClaims("role"))
+	claims, err := verifier.Validate(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject)
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, "jobs:read jobs:write", claims.Scope)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestLogin_RehashesOutdatedHash(t *testing.T) {
+	// Setup
+	authService, users, refreshTokens, passwords := newTestAuthService(time.Now())
+	user := &domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "bcrypt-hash", Role: "user"}
+
+	// Mock behavior: the stored hash predates the current hasher
+	users.On("FindByEmail", "ana@example.com").Return(user, nil)
+	passwords.On("CheckPassword", "bcrypt-hash", "s3cret-pass").Return(nil)
+	passwords.On("NeedsRehash", "bcrypt-hash").Return(true)
+	passwords.On("HashPassword", "s3cret-pass").Return("argon2id-hash", nil)
+	users.On("UpdatePasswordHash", 7, "argon2id-hash").Return(nil)
+	refreshTokens.On("Create", mock.Anything).Return(nil)
+
+	// Execute
+	_, err := authService.Login(context.Background(), domain.LoginRequest{Email: "ana@example.com", Password: "s3cret-pass"})
+
+	// Assertions
+	assert.NoError(t, err)
+	users.AssertExpectations(t)
+}
+
+func TestLogin_RehashFailureDoesNotFailLogin(t *testing.T) {
+	// Setup
+	authService, users, refreshTokens, passwords := newTestAuthService(time.Now())
+
+	// Mock behavior
+	users.On("FindByEmail", "ana@example.com").Return(&domain.User{ID: 7, PasswordHash: "bcrypt-hash"}, nil)
+	passwords.On("CheckPassword", "bcrypt-hash", "s3cret-pass").Return(nil)
+	passwords.On("NeedsRehash", "bcrypt-hash").Return(true)
+	passwords.On("HashPassword", "s3cret-pass").Return("argon2id-hash", nil)
+	users.On("UpdatePasswordHash", 7, "argon2id-hash").Return(errors.New("db down"))
+	refreshTokens.On("Create", mock.Anything).Return(nil)
+
+	// Execute
+	tokens, err := authService.Login(context.Background(), domain.LoginRequest{Email: "ana@example.com", Password: "s3cret-pass"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	// Setup
+	authService, users, refreshTokens, passwords := newTestAuthService(time.Now())
+
+	// Mock behavior: an unknown email still costs a password check
+	users.On("FindByEmail", "ana@example.com").Return(&domain.User{ID: 7, PasswordHash: "hashed"}, nil)
+	users.On("FindByEmail", "bob@example.com").Return(nil, domain.ErrUserNotFound)
+	passwords.On("CheckPassword", "hashed", "wrong").Return(errors.New("mismatch"))
+	passwords.On("HashPassword", mock.Anything).Return("dummy", nil).Once()
+	passwords.On("CheckPassword", "dummy", "wrong").Return(errors.New("mismatch"))
+
+	// Execute
+	_, errWrongPassword := authService.Login(context.Background(), domain.LoginRequest{Email: "ana@example.com", Password: "wrong"})
+	_, errUnknownEmail := authService.Login(context.Background(), domain.LoginRequest{Email: "bob@example.com", Password: "wrong"})
+
+	// Assertions
+	assert.ErrorIs(t, errWrongPassword, domain.ErrInvalidCredentials)
+	assert.ErrorIs(t, errUnknownEmail, domain.ErrInvalidCredentials)
+	passwords.AssertExpectations(t)
+	refreshTokens.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// newGuardedAuthService returns a test auth service throttling logins with an in-memory guard and auditing them
+func newGuardedAuthService(now time.Time) (*authServiceImpl, *repository.MockUserRepository, *utils.MockPasswordUtils, *repository.MockAuditRepository) {
+	authService, users, _, passwords := newTestAuthService(now)
+	audit := new(repository.MockAuditRepository)
+	authService.guard = loginguard.NewGuard(loginguard.NewMemoryStore(), loginguard.Policy{
+		MaxAccountFailures: 3,
+		MaxIPFailures:      10,
+		LockoutDuration:    15 * time.Minute,
+		FailureWindow:      15 * time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+	})
+	authService.audit = audit
+	return authService, users, passwords, audit
+}
+
+func TestLogin_ProgressiveDelayAndLockout(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	authService, users, passwords, audit := newGuardedAuthService(now)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{IP: "192.0.2.1", RequestID: "req-1"})
+	req := domain.LoginRequest{Email: "ana@example.com", Password: "wrong"}
+
+	// Mock behavior
+	users.On("FindByEmail", "ana@example.com").Return(&domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "hashed"}, nil)
+	passwords.On("CheckPassword", "hashed", "wrong").Return(errors.New("mismatch"))
+	audit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.EntityType == domain.AuditEntityUser && entry.EntityID == 7 && entry.RequestID == "req-1"
+	})).Return(nil)
+
+	// Execute and assert: the first failure imposes one second, the second two
+	_, err := authService.Login(ctx, req)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = authService.Login(ctx, req)
+	var throttled *domain.LoginThrottledError
+	if assert.ErrorAs(t, err, &throttled) {
+		assert.Equal(t, time.Second, throttled.RetryAfter)
+		assert.False(t, throttled.Locked)
+	}
+
+	now = now.Add(time.Second)
+	authService.now = func() time.Time { return now }
+	_, err = authService.Login(ctx, req)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	now = now.Add(2 * time.Second)
+	_, err = authService.Login(ctx, req)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+	// The third failure locks the account, even for the right password
+	now = now.Add(time.Minute)
+	_, err = authService.Login(ctx, domain.LoginRequest{Email: "ana@example.com", Password: "s3cret-pass"})
+	if assert.ErrorAs(t, err, &throttled) {
+		assert.True(t, throttled.Locked)
+		assert.Equal(t, 14*time.Minute, throttled.RetryAfter)
+	}
+	passwords.AssertNumberOfCalls(t, "CheckPassword", 3)
+	audit.AssertCalled(t, "Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.Action == domain.AuditActionLockout
+	}))
+	audit.AssertNumberOfCalls(t, "Append", 4) // Three failures and the lockout
+}
+
+func TestLogin_UnknownEmailIsThrottledLikeKnownOnes(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	authService, users, passwords, audit := newGuardedAuthService(now)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{IP: "192.0.2.1"})
+
+	// Mock behavior: the unknown email costs a password check and an audit entry
+	users.On("FindByEmail", "bob@example.com").Return(nil, domain.ErrUserNotFound)
+	passwords.On("HashPassword", mock.Anything).Return("dummy", nil).Once()
+	passwords.On("CheckPassword", "dummy", "guess").Return(errors.New("mismatch"))
+	audit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.EntityID == 0 && entry.Action == domain.AuditActionLoginFailed
+	})).Return(nil)
+
+	// Execute
+	_, first := authService.Login(ctx, domain.LoginRequest{Email: "bob@example.com", Password: "guess"})
+	_, second := authService.Login(ctx, domain.LoginRequest{Email: "bob@example.com", Password: "guess"})
+
+	// Assertions
+	assert.ErrorIs(t, first, domain.ErrInvalidCredentials)
+	assert.ErrorIs(t, second, domain.ErrLoginThrottled)
+	passwords.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestLogin_IPLockout(t *testing.T) {
+	// Setup: one IP guessing a different account each time
+	now := time.Now().UTC()
+	authService, users, passwords, audit := newGuardedAuthService(now)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{IP: "192.0.2.1"})
+
+	// Mock behavior
+	users.On("FindByEmail", mock.Anything).Return(nil, domain.ErrUserNotFound)
+	passwords.On("HashPassword", mock.Anything).Return("dummy", nil)
+	passwords.On("CheckPassword", "dummy", mock.Anything).Return(errors.New("mismatch"))
+	audit.On("Append", mock.Anything).Return(nil)
+
+	for i := 0; i < 10; i++ {
+		_, err := authService.Login(ctx, domain.LoginRequest{Email: strconv.Itoa(i) + "@example.com", Password: "guess"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+
+	// Execute: a fresh account from the same IP
+	_, err := authService.Login(ctx, domain.LoginRequest{Email: "fresh@example.com", Password: "guess"})
+
+	// Assertions
+	var throttled *domain.LoginThrottledError
+	if assert.ErrorAs(t, err, &throttled) {
+		assert.True(t, throttled.Locked)
+	}
+}
+
+func TestLogin_ParallelGuessesAreThrottled(t *testing.T) {
+	// Setup: guesses on one account arriving together, before any of them failed
+	now := time.Now().UTC()
+	authService, users, passwords, audit := newGuardedAuthService(now)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{IP: "192.0.2.1"})
+
+	// Mock behavior
+	users.On("FindByEmail", "ana@example.com").Return(&domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "hashed"}, nil)
+	passwords.On("CheckPassword", "hashed", mock.Anything).Return(errors.New("mismatch"))
+	audit.On("Append", mock.Anything).Return(nil)
+
+	// Execute
+	const guesses = 20
+	errs := make([]error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = authService.Login(ctx, domain.LoginRequest{Email: "ana@example.com", Password: "guess-" + strconv.Itoa(i)})
+		}(i)
+	}
+	wg.Wait()
+
+	// Assertions: only one guess reached the password check, the others waited for its delay
+	throttled := 0
+	for _, err := range errs {
+		if errors.Is(err, domain.ErrLoginThrottled) {
+			throttled++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		}
+	}
+	assert.Equal(t, guesses-1, throttled)
+	passwords.AssertNumberOfCalls(t, "CheckPassword", 1)
+}
+
+func TestUnlock(t *testing.T) {
+	// Setup: an account locked by three failures
+	now := time.Now().UTC()
+	authService, users, passwords, audit := newGuardedAuthService(now)
+	for i := 0; i < 3; i++ {
+		authService.guard.Reserve("ana@example.com", "192.0.2.1", now.Add(time.Duration(i-10)*time.Minute))
+	}
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "admin-1", IP: "198.51.100.1"})
+
+	// Mock behavior
+	users.On("FindByID", 7).Return(&domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "hashed"}, nil)
+	users.On("FindByID", 8).Return(nil, domain.ErrUserNotFound)
+	users.On("FindByEmail", "ana@example.com").Return(&domain.User{ID: 7, Email: "ana@example.com", PasswordHash: "hashed"}, nil)
+	passwords.On("CheckPassword", "hashed", "s3cret-pass").Return(nil)
+	passwords.On("NeedsRehash", "hashed").Return(false)
+	authService.refreshTokens.(*repository.MockRefreshTokenRepository).On("Create", mock.Anything).Return(nil)
+	audit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.Action == domain.AuditActionUnlock && entry.EntityID == 7 && entry.Actor == "admin-1"
+	})).Return(nil)
+
+	// Execute
+	err := authService.Unlock(ctx, 7)
+	errMissing := authService.Unlock(ctx, 8)
+	_, errLogin := authService.Login(ctx, domain.LoginRequest{Email: "ana@example.com", Password: "s3cret-pass"})
+
+	// Assertions: the user can log in again from another IP
+	assert.NoError(t, err)
+	assert.ErrorIs(t, errMissing, domain.ErrUserNotFound)
+	assert.NoError(t, errLogin)
+	audit.AssertExpectations(t)
+}
+
+func TestSetRole(t *testing.T) {
+	// Setup
+	authService, users, _, _ := newTestAuthService(time.Now())
+	audit := new(repository.MockAuditRepository)
+	authService.audit = audit
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "admin-1"})
+
+	// Mock behavior
+	users.On("FindByID", 7).Return(&domain.User{ID: 7, Role: domain.RoleUser}, nil)
+	users.On("FindByID", 8).Return(nil, domain.ErrUserNotFound)
+	users.On("UpdateRole", 7, domain.RoleRecruiter).Return(nil)
+	audit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		return entry.Action == domain.AuditActionRoleChange && entry.EntityID == 7 && entry.Actor == "admin-1"
+	})).Return(nil)
+
+	// Execute
+	user, err := authService.SetRole(ctx, 7, domain.RoleRecruiter)
+	_, errUnknownRole := authService.SetRole(ctx, 7, "owner")
+	_, errMissing := authService.SetRole(ctx, 8, domain.RoleRecruiter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleRecruiter, user.Role)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, errUnknownRole, &validationErr)
+	assert.ErrorIs(t, errMissing, domain.ErrUserNotFound)
+	users.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestIssueTokens_ScopesFollowRole(t *testing.T) {
+	// Setup
+	authService, _, refreshTokens, _ := newTestAuthService(time.Now())
+	refreshTokens.On("Create", mock.Anything).Return(nil)
+	verifier, _ := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret(authTestSecret))
+
+	tests := []struct {
+		role     string
+		canWrite bool
+	}{
+		{domain.RoleUser, false},
+		{domain.RoleRecruiter, true},
+		{domain.RoleAdmin, true},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		// Execute
+		tokens, err := authService.issueTokens(&domain.User{ID: 7, Role: tt.role}, "family-1", time.Now())
+
+		// Assertions: self-registered users can read jobs but not change them
+		assert.NoError(t, err)
+		claims, err := verifier.Validate(tokens.AccessToken)
+		assert.NoError(t, err)
+		scopes := strings.Fields(claims.Scope)
+		assert.Contains(t, scopes, domain.ScopeJobsRead, tt.role)
+		assert.Equal(t, tt.canWrite, slices.Contains(scopes, domain.ScopeJobsWrite), tt.role)
+	}
+}
+
+func TestRefresh_Rotation(t *testing.T) {
+	// Setup
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, users, refreshTokens, _ := newTestAuthService(now)
+	loggedInAt := now.Add(-24 * time.Hour)
+	stored := &domain.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", FamilyIssuedAt: loggedInAt, ExpiresAt: now.Add(time.Hour)}
+
+	// Mock behavior
+	refreshTokens.On("FindByHash", hashRefreshToken("old-token")).Return(stored, nil)
+	refreshTokens.On("MarkUsed", 3, now).Return(nil)
+	users.On("FindByID", 7).Return(&domain.User{ID: 7, Role: "user"}, nil)
+	refreshTokens.On("Create", mock.MatchedBy(func(token *domain.RefreshToken) bool {
+		return token.FamilyID == "family-1" && token.FamilyIssuedAt.Equal(loggedInAt) && token.UserID == 7
+	})).Return(nil)
+
+	// Execute
+	tokens, err := authService.Refresh("old-token")
+
+	// Assertions: a new refresh token in the same family
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old-token", tokens.RefreshToken)
+	refreshTokens.AssertExpectations(t)
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	// Setup
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, _, refreshTokens, _ := newTestAuthService(now)
+	usedAt := now.Add(-time.Minute)
+
+	// Mock behavior: the token was already exchanged
+	refreshTokens.On("FindByHash", hashRefreshToken("stolen")).
+		Return(&domain.RefreshToken{ID: 3, UserID: 7, FamilyID: "family-1", ExpiresAt: now.Add(time.Hour), UsedAt: &usedAt}, nil)
+	refreshTokens.On("RevokeFamily", "family-1", now).Return(nil)
+
+	// Execute
+	_, err := authService.Refresh("stolen")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	refreshTokens.AssertExpectations(t)
+	refreshTokens.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRefresh_RevokedSubject(t *testing.T) {
+	// Setup: the tokens of user 7 issued up to a minute ago are revoked
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, users, refreshTokens, _ := newTestAuthService(now)
+	store := revocation.NewMemoryStore()
+	assert.NoError(t, store.RevokeSubject("7", now.Add(-time.Minute), now.Add(time.Hour)))
+	authService.revocations = store
+
+	// Mock behavior: a family started before the revocation and one started after it
+	refreshTokens.On("FindByHash", hashRefreshToken("before")).Return(&domain.RefreshToken{ID: 3, UserID: 7,
+		FamilyID: "family-1", FamilyIssuedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}, nil)
+	refreshTokens.On("FindByHash", hashRefreshToken("after")).Return(&domain.RefreshToken{ID: 4, UserID: 7,
+		FamilyID: "family-2", FamilyIssuedAt: now.Add(-time.Second), ExpiresAt: now.Add(time.Hour)}, nil)
+	refreshTokens.On("RevokeFamily", "family-1", now).Return(nil)
+	refreshTokens.On("MarkUsed", 4, now).Return(nil)
+	users.On("FindByID", 7).Return(&domain.User{ID: 7, Role: "user"}, nil)
+	refreshTokens.On("Create", mock.MatchedBy(func(token *domain.RefreshToken) bool {
+		return token.FamilyID == "family-2"
+	})).Return(nil)
+
+	// Execute
+	_, errBefore := authService.Refresh("before")
+	_, errAfter := authService.Refresh("after")
+
+	// Assertions: the family started before the revocation is revoked, the new login keeps working
+	assert.ErrorIs(t, errBefore, domain.ErrInvalidRefreshToken)
+	assert.NoError(t, errAfter)
+	refreshTokens.AssertExpectations(t)
+	refreshTokens.AssertNotCalled(t, "MarkUsed", 3, mock.Anything)
+}
+
+// failingRevocations cannot be read
+type failingRevocations struct{}
+
+func (failingRevocations) IsRevoked(string, string, time.Time, time.Time) (bool, error) {
+	return false, errors.New("db down")
+}
+
+func TestRefresh_RevocationsUnavailable(t *testing.T) {
+	// Setup
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, _, refreshTokens, _ := newTestAuthService(now)
+	authService.revocations = failingRevocations{}
+
+	// Mock behavior
+	refreshTokens.On("FindByHash", hashRefreshToken("token")).Return(&domain.RefreshToken{ID: 3, UserID: 7,
+		FamilyID: "family-1", FamilyIssuedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}, nil)
+
+	// Execute
+	_, err := authService.Refresh("token")
+
+	// Assertions: the refresh is refused rather than made unchecked
+	assert.Error(t, err)
+	refreshTokens.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+}
+
+func TestRefresh_Invalid(t *testing.T) {
+	// Setup
+	now := time.Now().UTC().Truncate(time.Second)
+	authService, _, refreshTokens, _ := newTestAuthService(now)
+	revokedAt := now.Add(-time.Minute)
+
+	// Mock behavior
+	refreshTokens.On("FindByHash", hashRefreshToken("unknown")).Return(nil, domain.ErrInvalidRefreshToken)
+	refreshTokens.On("FindByHash", hashRefreshToken("expired")).
+		Return(&domain.RefreshToken{ID: 4, FamilyID: "family-2", ExpiresAt: now.Add(-time.Second)}, nil)
+	refreshTokens.On("FindByHash", hashRefreshToken("revoked")).
+		Return(&domain.RefreshToken{ID: 5, FamilyID: "family-3", ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+	for _, token := range []string{"unknown", "expired", "revoked"} {
+		// Execute
+		_, err := authService.Refresh(token)
+
+		// Assertions
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken, token)
+	}
+	refreshTokens.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// statusEvents maps the job statuses announced to other services to their event type
+var statusEvents = map[string]string{
+	domain.JobStatusPublished: domain.EventJobPublished,
+	domain.JobStatusClosed:    domain.EventJobClosed,
+}
+
+// emitJobEvent writes a job event to the outbox of the current transaction
+// Nothing is written when repos has no outbox repository or the snapshot is missing.
+// @param repos repository.TxRepositories - The repositories of the current transaction
+// @param eventType string - The event type (e.g., domain.EventJobCreated)
+// @param job *domain.Job - The job snapshot sent as payload
+// @return error - An error if the event cannot be encoded or stored
+func emitJobEvent(repos repository.TxRepositories, eventType string, job *domain.Job) error {
+	if repos.Outbox == nil || job == nil {
+		return nil
+	}
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	id, err := newEventID()
+	if err != nil {
+		return err
+	}
+	return repos.Outbox.Enqueue(&domain.Event{
+		ID:            id,
+		Type:          eventType,
+		AggregateType: domain.AuditEntityJob,
+		AggregateID:   job.ID,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       payload,
+	})
+}
+
+// newEventID returns a random RFC 4122 version 4 UUID
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	s := hex.EncodeToString(b)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], nil
+}
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// EventPublisher delivers domain events to other services
+// Delivery is at-least-once: the relay retries until Publish returns nil, so an event
+// may be published more than once and consumers must discard duplicates by Event.ID.
+type EventPublisher interface {
+	// Publish delivers one event
+	// @param ctx context.Context - Cancelled when the relay stops
+	// @param event domain.Event - The event to deliver
+	// @return error - An error if the event was not delivered and must be retried
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// MemoryPublisher keeps the published events in memory
+// Useful in tests and local development.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+// NewMemoryPublisher creates an empty MemoryPublisher
+// @return *MemoryPublisher - The publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish records the event
+// @param ctx context.Context - Unused
+// @param event domain.Event - The event to record
+// @return error - Always nil
+func (p *MemoryPublisher) Publish(_ context.Context, event domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns a copy of the events published so far, in publication order
+// @return []domain.Event - The published events
+func (p *MemoryPublisher) Events() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.Event(nil), p.events...)
+}
+
+// WriterPublisher writes each event as one JSON line (NDJSON) to an io.Writer
+type WriterPublisher struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterPublisher creates a WriterPublisher writing to w
+// @param w io.Writer - The destination of the events
+// @return *WriterPublisher - The publisher
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{encoder: json.NewEncoder(w)}
+}
+
+// NewStdoutPublisher creates a WriterPublisher writing to standard output
+// @return *WriterPublisher - The publisher
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+// NewFilePublisher creates a WriterPublisher appending to the file at path
+// The file is created if it does not exist. The caller closes the returned file on shutdown.
+// @param path string - The path of the file
+// @return *WriterPublisher - The publisher
+// @return *os.File - The opened file
+// @return error - An error if the file cannot be opened
+func NewFilePublisher(path string) (*WriterPublisher, *os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewWriterPublisher(file), file, nil
+}
+
+// Publish writes the event as one JSON line
+// @param ctx context.Context - Unused
+// @param event domain.Event - The event to write
+// @return error - An error if the write fails
+func (p *WriterPublisher) Publish(_ context.Context, event domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.encoder.Encode(event)
+}
+
+// Broker is the minimal client interface of a message broker (Kafka, NATS, RabbitMQ, SNS...)
+// Implement it with the client library of the broker to plug it into BrokerPublisher.
+type Broker interface {
+	// Send publishes a message to a topic
+	// @param ctx context.Context - Cancelled when the relay stops
+	// @param topic string - The destination topic
+	// @param key string - The partitioning key; events of the same job share it to keep their order
+	// @param value []byte - The message body
+	// @param headers map[string]string - The message headers
+	// @return error - An error if the broker did not acknowledge the message
+	Send(ctx context.Context, topic, key string, value []byte, headers map[string]string) error
+}
+
+// BrokerPublisher adapts a Broker to EventPublisher
+// Events are JSON encoded and sent to the topic named after their type, e.g. "jobs.job.created".
+type BrokerPublisher struct {
+	broker Broker // The broker client
+	prefix string // Prepended to the event type to build the topic
+}
+
+// NewBrokerPublisher creates a BrokerPublisher
+// @param broker Broker - The broker client
+// @param topicPrefix string - Prepended to the event type to build the topic (e.g., "jobs.")
+// @return *BrokerPublisher - The publisher
+func NewBrokerPublisher(broker Broker, topicPrefix string) *BrokerPublisher {
+	return &BrokerPublisher{broker: broker, prefix: topicPrefix}
+}
+
+// Publish sends the event to the broker
+// @param ctx context.Context - Cancelled when the relay stops
+// @param event domain.Event - The event to send
+// @return error - An error if the event cannot be encoded or the broker rejects it
+func (p *BrokerPublisher) Publish(ctx context.Context, event domain.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"event_id":   event.ID,
+		"event_type": event.Type,
+	}
+	key := event.AggregateType + ":" + strconv.Itoa(event.AggregateID)
+	return p.broker.Send(ctx, p.prefix+event.Type, key, value, headers)
+}
+
+// MultiPublisher delivers every event to several publishers, in order
+// Publishing stops at the first failure; since the relay then retries the event, the
+// publishers before the failing one may receive it more than once.
+type MultiPublisher struct {
+	publishers []EventPublisher // Destinations of the events
+}
+
+// NewMultiPublisher creates a MultiPublisher
+// @param publishers ...EventPublisher - The destinations of the events
+// @return *MultiPublisher - The publisher
+func NewMultiPublisher(publishers ...EventPublisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+// Publish delivers the event to every publisher
+// @param ctx context.Context - Passed to the publishers
+// @param event domain.Event - The event to deliver
+// @return error - The first error returned by a publisher
+func (p *MultiPublisher) Publish(ctx context.Context, event domain.Event) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package events
+
+import (
+	"context"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEventPublisher is a mock implementation of EventPublisher for testing
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+// Publish mocks the Publish method
+func (m *MockEventPublisher) Publish(ctx context.Context, event domain.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingBroker captures the messages sent to it
+type recordingBroker struct {
+	topic, key string
+	headers    map[string]string
+}
+
+func (b *recordingBroker) Send(_ context.Context, topic, key string, _ []byte, headers map[string]string) error {
+	b.topic, b.key, b.headers = topic, key, headers
+	return nil
+}
+
+func TestWriterPublisher_WritesNDJSON(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	publisher := NewWriterPublisher(&buf)
+
+	// Execute
+	_ = publisher.Publish(context.Background(), domain.Event{ID: "a", Type: domain.EventJobCreated, Payload: json.RawMessage(`{"id":1}`)})
+	_ = publisher.Publish(context.Background(), domain.Event{ID: "b", Type: domain.EventJobClosed, Payload: json.RawMessage(`{"id":1}`)})
+
+	// Assertions
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	var event domain.Event
+	assert.NoError(t, json.Unmarshal(lines[1], &event))
+	assert.Equal(t, domain.EventJobClosed, event.Type)
+}
+
+func TestBrokerPublisher_TopicAndKey(t *testing.T) {
+	// Setup
+	broker := &recordingBroker{}
+	publisher := NewBrokerPublisher(broker, "jobs.")
+
+	// Execute
+	err := publisher.Publish(context.Background(), domain.Event{
+		ID: "a", Type: domain.EventJobPublished, AggregateType: domain.AuditEntityJob, AggregateID: 9, Payload: json.RawMessage(`{}`),
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "jobs.job.published", broker.topic)
+	assert.Equal(t, "job:9", broker.key)
+	assert.Equal(t, "a", broker.headers["event_id"])
+}
@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// Defaults of the relay settings
+const (
+	DefaultBatchSize    = 100
+	DefaultPollInterval = time.Second
+	DefaultLease        = 30 * time.Second
+	DefaultMinBackoff   = time.Second
+	DefaultMaxBackoff   = 10 * time.Minute
+)
+
+// maxErrorLength bounds the delivery error stored in the outbox
+const maxErrorLength = 1000
+
+// Relay delivers the events of the outbox to an EventPublisher
+// Events are delivered in outbox order and marked delivered only after Publish succeeds,
+// so a crash between both steps re-delivers the event (at-least-once). Failed events are
+// retried with exponential backoff, without limit.
+type Relay struct {
+	outbox    repository.OutboxRepository // Source of the events
+	publisher EventPublisher              // Destination of the events
+
+	batchSize  int              // Events claimed per poll
+	interval   time.Duration    // Pause between polls when the outbox is drained
+	lease      time.Duration    // How long claimed events are hidden from other relays
+	minBackoff time.Duration    // Delay before the first retry
+	maxBackoff time.Duration    // Upper bound of the retry delay
+	now        func() time.Time // Clock, replaced in tests
+}
+
+// RelayOption configures optional Relay settings
+type RelayOption func(*Relay)
+
+// WithPollInterval sets the pause between polls when the outbox is drained
+// @param interval time.Duration - The poll interval
+// @return RelayOption - The option to pass to NewRelay
+func WithPollInterval(interval time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.interval = interval
+	}
+}
+
+// WithBatchSize sets the number of events claimed per poll
+// @param size int - The batch size
+// @return RelayOption - The option to pass to NewRelay
+func WithBatchSize(size int) RelayOption {
+	return func(r *Relay) {
+		r.batchSize = size
+	}
+}
+
+// WithBackoff sets the retry delays; the delay doubles after every failed attempt
+// @param min time.Duration - Delay before the first retry
+// @param max time.Duration - Upper bound of the delay
+// @return RelayOption - The option to pass to NewRelay
+func WithBackoff(min, max time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.minBackoff = min
+		r.maxBackoff = max
+	}
+}
+
+// WithClock replaces the clock used to schedule retries
+// @param now func() time.Time - Returns the current time
+// @return RelayOption - The option to pass to NewRelay
+func WithClock(now func() time.Time) RelayOption {
+	return func(r *Relay) {
+		r.now = now
+	}
+}
+
+// NewRelay creates a new Relay
+// @param outbox repository.OutboxRepository - The outbox holding the events
+// @param publisher EventPublisher - The destination of the events
+// @param opts ...RelayOption - Optional settings
+// @return *Relay - The relay
+func NewRelay(outbox repository.OutboxRepository, publisher EventPublisher, opts ...RelayOption) *Relay {
+	r := &Relay{
+		outbox:     outbox,
+		publisher:  publisher,
+		batchSize:  DefaultBatchSize,
+		interval:   DefaultPollInterval,
+		lease:      DefaultLease,
+		minBackoff: DefaultMinBackoff,
+		maxBackoff: DefaultMaxBackoff,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run delivers the outbox events until ctx is cancelled
+// Full batches are followed immediately by the next poll; otherwise the relay waits one interval.
+// Running it on several replicas is safe: each event is claimed by one relay at a time.
+// @param ctx context.Context - Stops the relay when cancelled
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		claimed, err := r.RelayOnce(ctx)
+		if err != nil {
+			log.Printf("Failed to relay outbox events: %v", err)
+		}
+
+		if err == nil && claimed == r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// RelayOnce claims one batch of due events and publishes them in order
+// An event that fails is rescheduled and the following events of the batch are still attempted,
+// so a retried event may reach consumers after newer ones.
+// @param ctx context.Context - Passed to the publisher
+// @return int - The number of events claimed
+// @return error - An error if the outbox cannot be read or updated
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.outbox.Claim(r.now(), r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		if err := r.publisher.Publish(ctx, message.Event); err != nil {
+			reason := err.Error()
+			if len(reason) > maxErrorLength {
+				reason = reason[:maxErrorLength]
+			}
+			if err := r.outbox.MarkFailed(message.Seq, reason, r.now().Add(r.backoff(message.Attempts))); err != nil {
+				return len(messages), err
+			}
+			continue
+		}
+		if err := r.outbox.MarkDelivered(message.Seq, r.now()); err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+// backoff returns the delay before the next attempt of an event that already failed attempts times
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.minBackoff
+	for i := 0; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.maxBackoff {
+		delay = r.maxBackoff
+	}
+	return delay
+}
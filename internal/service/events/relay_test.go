@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelayOnce_DeliversInOrder(t *testing.T) {
+	// Setup
+	mockOutbox := new(repository.MockOutboxRepository)
+	publisher := NewMemoryPublisher()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	relay := NewRelay(mockOutbox, publisher, WithClock(func() time.Time { return now }))
+
+	// Mock data
+	messages := []*domain.OutboxMessage{
+		{Seq: 1, Event: domain.Event{ID: "a", Type: domain.EventJobCreated, AggregateID: 4}},
+		{Seq: 2, Event: domain.Event{ID: "b", Type: domain.EventJobPublished, AggregateID: 4}},
+	}
+
+	// Mock behavior
+	mockOutbox.On("Claim", now, DefaultBatchSize, DefaultLease).Return(messages, nil)
+	mockOutbox.On("MarkDelivered", int64(1), now).Return(nil)
+	mockOutbox.On("MarkDelivered", int64(2), now).Return(nil)
+
+	// Execute
+	claimed, err := relay.RelayOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+	published := publisher.Events()
+	assert.Len(t, published, 2)
+	assert.Equal(t, "a", published[0].ID)
+	assert.Equal(t, "b", published[1].ID)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestRelayOnce_SchedulesRetryWithBackoff(t *testing.T) {
+	// Setup
+	mockOutbox := new(repository.MockOutboxRepository)
+	mockPublisher := new(MockEventPublisher)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	relay := NewRelay(mockOutbox, mockPublisher,
+		WithClock(func() time.Time { return now }),
+		WithBackoff(time.Second, time.Minute),
+	)
+
+	// Mock data: the event already failed three times
+	failing := &domain.OutboxMessage{Seq: 7, Attempts: 3, Event: domain.Event{ID: "x"}}
+	next := &domain.OutboxMessage{Seq: 8, Event: domain.Event{ID: "y"}}
+
+	// Mock behavior
+	mockOutbox.On("Claim", now, DefaultBatchSize, DefaultLease).Return([]*domain.OutboxMessage{failing, next}, nil)
+	mockPublisher.On("Publish", mock.Anything, failing.Event).Return(errors.New("broker unavailable"))
+	mockPublisher.On("Publish", mock.Anything, next.Event).Return(nil)
+	mockOutbox.On("MarkFailed", int64(7), "broker unavailable", now.Add(8*time.Second)).Return(nil)
+	mockOutbox.On("MarkDelivered", int64(8), now).Return(nil)
+
+	// Execute
+	_, err := relay.RelayOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	mockOutbox.AssertExpectations(t)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestRelayBackoff_Capped(t *testing.T) {
+	relay := NewRelay(nil, nil, WithBackoff(time.Second, time.Minute))
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 4*time.Second, relay.backoff(2))
+	assert.Equal(t, time.Minute, relay.backoff(50))
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newEventedJobService builds a JobService whose mutations write to a mocked outbox
+func newEventedJobService() (JobService, *repository.MockJobRepository, *repository.MockOutboxRepository) {
+	mockRepo := new(repository.MockJobRepository)
+	mockOutbox := new(repository.MockOutboxRepository)
+	mockTx := &repository.MockTransactor{Repos: repository.TxRepositories{Jobs: mockRepo, Outbox: mockOutbox}}
+	mockTx.On("WithinTx")
+	return NewJobService(mockRepo, WithTransactor(mockTx)), mockRepo, mockOutbox
+}
+
+func TestAddJob_EmitsCreatedEvent(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockOutbox := newEventedJobService()
+
+	// Mock data
+	newJob := &domain.Job{Title: "Data Engineer", Description: "Pipelines"}
+	stored := &domain.Job{ID: 11, Title: "Data Engineer", Description: "Pipelines", Status: domain.JobStatusPublished}
+
+	// Mock behavior
+	mockRepo.On("Create", newJob).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.Job).ID = 11
+	}).Return(nil)
+	mockRepo.On("FindByID", 11).Return(stored, nil)
+	mockOutbox.On("Enqueue", mock.MatchedBy(func(event *domain.Event) bool {
+		var payload domain.Job
+		return event.Type == domain.EventJobCreated && event.AggregateID == 11 && len(event.ID) == 36 &&
+			json.Unmarshal(event.Payload, &payload) == nil && payload.Title == "Data Engineer"
+	})).Return(nil)
+
+	// Execute
+	err := jobService.AddJob(context.Background(), newJob)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestChangeJobStatus_EmitsClosedEvent(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockOutbox := newEventedJobService()
+
+	// Mock behavior
+	mockRepo.On("FindByID", 2).Return(&domain.Job{ID: 2, Status: domain.JobStatusPublished}, nil)
+	mockRepo.On("UpdateStatus", 2, domain.JobStatusClosed, 0).Return(nil)
+	mockOutbox.On("Enqueue", mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventJobClosed && event.AggregateID == 2
+	})).Return(nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 2, domain.JobStatusClosed, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestChangeJobStatus_DraftNotAnnounced(t *testing.T) {
+	// Setup
+	jobService, mockRepo, mockOutbox := newEventedJobService()
+
+	// Mock behavior
+	mockRepo.On("FindByID", 2).Return(&domain.Job{ID: 2, Status: domain.JobStatusPublished}, nil)
+	mockRepo.On("UpdateStatus", 2, domain.JobStatusDraft, 0).Return(nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 2, domain.JobStatusDraft, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockOutbox.AssertNotCalled(t, "Enqueue", mock.Anything)
+}
@@ -0,0 +1,80 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// ContentType is the media type of the generated feeds
+const ContentType = "application/xml; charset=utf-8"
+
+// Generator writes jobs in the XML format of a job board
+type Generator interface {
+	// Generate writes the feed of the given jobs
+	// @param w io.Writer - The destination of the feed
+	// @param jobs []*domain.Job - The published jobs to syndicate
+	// @param profile domain.JobPostingProfile - The employer and careers site details
+	// @return error - An error if the feed cannot be written
+	Generate(w io.Writer, jobs []*domain.Job, profile domain.JobPostingProfile) error
+}
+
+// generators holds the supported feed formats by name
+var generators = map[string]Generator{
+	"indeed":   Indeed{},
+	"linkedin": LinkedIn{},
+}
+
+// Lookup returns the generator of a feed format
+// @param format string - The format name, e.g. "indeed"
+// @return Generator - The generator of the format
+// @return bool - False if the format is not supported
+func Lookup(format string) (Generator, bool) {
+	generator, ok := generators[strings.ToLower(format)]
+	return generator, ok
+}
+
+// Formats returns the names of the supported feed formats, sorted
+func Formats() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cdata is text written as a CDATA section, as job boards expect for free text
+type cdata struct {
+	Text string `xml:",cdata"`
+}
+
+// lastBuildDate returns the latest update among the jobs, so an unchanged feed is byte-identical
+func lastBuildDate(jobs []*domain.Job) time.Time {
+	var latest time.Time
+	for _, job := range jobs {
+		if job.UpdatedAt.After(latest) {
+			latest = job.UpdatedAt
+		}
+	}
+	return latest.UTC()
+}
+
+// writeXML writes the XML declaration followed by the indented document
+func writeXML(w io.Writer, document interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,137 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProfile is the employer used in the generated feeds
+var testProfile = domain.JobPostingProfile{
+	OrganizationName: "Acme",
+	CareersURL:       "https://careers.acme.example/",
+	Locality:         "Austin",
+	Region:           "TX",
+	Country:          "US",
+}
+
+// testJobs returns the published jobs syndicated in the tests
+func testJobs() []*domain.Job {
+	return []*domain.Job{
+		{ID: 7, Title: "Go Developer", Description: "Build APIs <fast> & ship ]]> often", SalaryRange: "$80k - $100k",
+			EmploymentType: "full-time", Seniority: "senior", Department: "Engineering", Status: domain.JobStatusPublished,
+			Skills:    []domain.JobSkill{{Name: "Go"}},
+			CreatedAt: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{ID: 8, Title: "Recruiter", Description: "Hire people", EmploymentType: "seasonal", Status: domain.JobStatusPublished,
+			CreatedAt: time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC)},
+	}
+}
+
+// elementPaths returns the slash separated paths of the elements of an XML document
+func elementPaths(t *testing.T, document []byte) map[string]bool {
+	paths := make(map[string]bool)
+	var stack []string
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return paths
+		}
+		require.NoError(t, err)
+		switch element := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, element.Name.Local)
+			paths[strings.Join(stack, "/")] = true
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// assertMatchesSample checks that every element of the feed exists in the sample feed of the format
+// and that the feed has every element the sample job has, except the optional ones
+func assertMatchesSample(t *testing.T, feed []byte, sampleFile string, optional ...string) {
+	sample, err := os.ReadFile(sampleFile)
+	require.NoError(t, err)
+	samplePaths := elementPaths(t, sample)
+	feedPaths := elementPaths(t, feed)
+
+	for path := range feedPaths {
+		assert.True(t, samplePaths[path], "unexpected element %s", path)
+	}
+	skip := make(map[string]bool)
+	for _, path := range optional {
+		skip[path] = true
+	}
+	for path := range samplePaths {
+		if !skip[path] {
+			assert.True(t, feedPaths[path], "missing element %s", path)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	_, ok := Lookup("Indeed")
+	assert.True(t, ok)
+	_, ok = Lookup("monster")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"indeed", "linkedin"}, Formats())
+}
+
+func TestIndeed_MatchesSample(t *testing.T) {
+	// Execute
+	var buf bytes.Buffer
+	err := Indeed{}.Generate(&buf, testJobs(), testProfile)
+
+	// Assertions
+	require.NoError(t, err)
+	assertMatchesSample(t, buf.Bytes(), "testdata/indeed_sample.xml",
+		"source/job/sourcename", "source/job/postalcode", "source/job/email", "source/job/education")
+
+	var source indeedSource
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &source))
+	assert.Equal(t, "Sat, 03 Feb 2024 09:00:00 GMT", source.LastBuildDate)
+	require.Len(t, source.Jobs, 2)
+	assert.Equal(t, "https://careers.acme.example/jobs/7", source.Jobs[0].URL.Text)
+	assert.Equal(t, "Build APIs <fast> & ship ]]> often", source.Jobs[0].Description.Text)
+	assert.Equal(t, "Thu, 01 Feb 2024 09:00:00 GMT", source.Jobs[0].Date.Text)
+}
+
+func TestLinkedIn_MatchesSample(t *testing.T) {
+	// Execute
+	var buf bytes.Buffer
+	err := LinkedIn{}.Generate(&buf, testJobs(), testProfile)
+
+	// Assertions
+	require.NoError(t, err)
+	assertMatchesSample(t, buf.Bytes(), "testdata/linkedin_sample.xml",
+		"source/job/companyId", "source/job/postalCode", "source/job/workplaceTypes", "source/job/industryCodes",
+		"source/job/industryCodes/industryCode", "source/job/expirationDate")
+
+	var source linkedInSource
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &source))
+	assert.Equal(t, 2, source.ExpectedJobCount)
+	require.Len(t, source.Jobs, 2)
+	assert.Equal(t, "Austin, TX, US", source.Jobs[0].Location.Text)
+	assert.Equal(t, "FULL_TIME", source.Jobs[0].JobType.Text)
+	assert.Equal(t, "MID_SENIOR_LEVEL", source.Jobs[0].ExperienceLevel.Text)
+	assert.Equal(t, "OTHER", source.Jobs[1].JobType.Text)
+	assert.Equal(t, "NOT_APPLICABLE", source.Jobs[1].ExperienceLevel.Text)
+	assert.Nil(t, source.Jobs[1].Skills)
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	// The feed only depends on the jobs, so unchanged jobs keep the same ETag
+	var first, second bytes.Buffer
+	require.NoError(t, LinkedIn{}.Generate(&first, testJobs(), testProfile))
+	require.NoError(t, LinkedIn{}.Generate(&second, testJobs(), testProfile))
+	assert.Equal(t, first.String(), second.String())
+}
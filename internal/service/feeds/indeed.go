@@ -0,0 +1,74 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// Indeed generates the Indeed XML feed
+// Dates use the RFC 1123 format with a GMT zone, and free text is wrapped in CDATA sections.
+type Indeed struct{}
+
+// indeedSource is the root element of an Indeed feed
+type indeedSource struct {
+	XMLName       xml.Name    `xml:"source"`
+	Publisher     string      `xml:"publisher"`
+	PublisherURL  string      `xml:"publisherurl"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Jobs          []indeedJob `xml:"job"`
+}
+
+// indeedJob is a job of an Indeed feed
+type indeedJob struct {
+	Title           cdata `xml:"title"`
+	Date            cdata `xml:"date"`
+	ReferenceNumber cdata `xml:"referencenumber"`
+	URL             cdata `xml:"url"`
+	Company         cdata `xml:"company"`
+	City            cdata `xml:"city"`
+	State           cdata `xml:"state"`
+	Country         cdata `xml:"country"`
+	Description     cdata `xml:"description"`
+	Salary          cdata `xml:"salary"`
+	JobType         cdata `xml:"jobtype"`
+	Category        cdata `xml:"category"`
+	Experience      cdata `xml:"experience"`
+}
+
+// Generate writes the Indeed feed of the given jobs
+// @param w io.Writer - The destination of the feed
+// @param jobs []*domain.Job - The published jobs to syndicate
+// @param profile domain.JobPostingProfile - The employer and careers site details
+// @return error - An error if the feed cannot be written
+func (Indeed) Generate(w io.Writer, jobs []*domain.Job, profile domain.JobPostingProfile) error {
+	source := indeedSource{
+		Publisher:    profile.OrganizationName,
+		PublisherURL: profile.CareersURL,
+		Jobs:         make([]indeedJob, 0, len(jobs)),
+	}
+	if built := lastBuildDate(jobs); !built.IsZero() {
+		source.LastBuildDate = built.Format(http.TimeFormat)
+	}
+	for _, job := range jobs {
+		source.Jobs = append(source.Jobs, indeedJob{
+			Title:           cdata{job.Title},
+			Date:            cdata{job.PostedAt().UTC().Format(http.TimeFormat)},
+			ReferenceNumber: cdata{strconv.Itoa(job.ID)},
+			URL:             cdata{profile.JobURL(job.ID)},
+			Company:         cdata{profile.OrganizationName},
+			City:            cdata{profile.Locality},
+			State:           cdata{profile.Region},
+			Country:         cdata{profile.Country},
+			Description:     cdata{job.Description},
+			Salary:          cdata{job.SalaryRange},
+			JobType:         cdata{job.EmploymentType},
+			Category:        cdata{job.Department},
+			Experience:      cdata{job.Seniority},
+		})
+	}
+	return writeXML(w, source)
+}
@@ -0,0 +1,134 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// linkedInJobTypes maps the employment types to the LinkedIn job types
+var linkedInJobTypes = map[string]string{
+	"full-time":  "FULL_TIME",
+	"part-time":  "PART_TIME",
+	"contract":   "CONTRACT",
+	"temporary":  "TEMPORARY",
+	"internship": "INTERNSHIP",
+	"volunteer":  "VOLUNTEER",
+}
+
+// linkedInExperienceLevels maps the seniority levels to the LinkedIn experience levels
+var linkedInExperienceLevels = map[string]string{
+	"internship": "INTERNSHIP",
+	"junior":     "ENTRY_LEVEL",
+	"mid":        "ASSOCIATE",
+	"senior":     "MID_SENIOR_LEVEL",
+	"lead":       "MID_SENIOR_LEVEL",
+	"director":   "DIRECTOR",
+	"executive":  "EXECUTIVE",
+}
+
+// LinkedIn generates the LinkedIn job XML feed
+// Job types and experience levels are translated to the LinkedIn enumerations, OTHER and
+// NOT_APPLICABLE when unknown.
+type LinkedIn struct{}
+
+// linkedInSource is the root element of a LinkedIn feed
+type linkedInSource struct {
+	XMLName          xml.Name      `xml:"source"`
+	LastBuildDate    string        `xml:"lastBuildDate,omitempty"`
+	PublisherURL     string        `xml:"publisherUrl"`
+	Publisher        string        `xml:"publisher"`
+	ExpectedJobCount int           `xml:"expectedJobCount"`
+	Jobs             []linkedInJob `xml:"job"`
+}
+
+// linkedInJob is a job of a LinkedIn feed
+type linkedInJob struct {
+	PartnerJobID    cdata           `xml:"partnerJobId"`
+	Company         cdata           `xml:"company"`
+	Title           cdata           `xml:"title"`
+	Description     cdata           `xml:"description"`
+	ApplyURL        cdata           `xml:"applyUrl"`
+	Location        cdata           `xml:"location"`
+	City            cdata           `xml:"city"`
+	State           cdata           `xml:"state"`
+	Country         cdata           `xml:"country"`
+	JobType         cdata           `xml:"jobtype"`
+	ExperienceLevel cdata           `xml:"experienceLevel"`
+	ListDate        string          `xml:"listDate"`
+	ExpirationDate  string          `xml:"expirationDate,omitempty"`
+	Skills          *linkedInSkills `xml:"skills,omitempty"`
+}
+
+// linkedInSkills lists the skills of a LinkedIn job
+type linkedInSkills struct {
+	Skills []cdata `xml:"skill"`
+}
+
+// Generate writes the LinkedIn feed of the given jobs
+// @param w io.Writer - The destination of the feed
+// @param jobs []*domain.Job - The published jobs to syndicate
+// @param profile domain.JobPostingProfile - The employer and careers site details
+// @return error - An error if the feed cannot be written
+func (LinkedIn) Generate(w io.Writer, jobs []*domain.Job, profile domain.JobPostingProfile) error {
+	source := linkedInSource{
+		PublisherURL:     profile.CareersURL,
+		Publisher:        profile.OrganizationName,
+		ExpectedJobCount: len(jobs),
+		Jobs:             make([]linkedInJob, 0, len(jobs)),
+	}
+	if built := lastBuildDate(jobs); !built.IsZero() {
+		source.LastBuildDate = built.Format(time.RFC3339)
+	}
+	location := joinNonEmpty(", ", profile.Locality, profile.Region, profile.Country)
+	for _, job := range jobs {
+		entry := linkedInJob{
+			PartnerJobID:    cdata{strconv.Itoa(job.ID)},
+			Company:         cdata{profile.OrganizationName},
+			Title:           cdata{job.Title},
+			Description:     cdata{job.Description},
+			ApplyURL:        cdata{profile.JobURL(job.ID)},
+			Location:        cdata{location},
+			City:            cdata{profile.Locality},
+			State:           cdata{profile.Region},
+			Country:         cdata{profile.Country},
+			JobType:         cdata{lookupOr(linkedInJobTypes, job.EmploymentType, "OTHER")},
+			ExperienceLevel: cdata{lookupOr(linkedInExperienceLevels, job.Seniority, "NOT_APPLICABLE")},
+			ListDate:        job.PostedAt().UTC().Format(time.RFC3339),
+		}
+		if job.ExpiresAt != nil {
+			entry.ExpirationDate = job.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		if len(job.Skills) > 0 {
+			entry.Skills = &linkedInSkills{}
+			for _, skill := range job.Skills {
+				entry.Skills.Skills = append(entry.Skills.Skills, cdata{skill.Name})
+			}
+		}
+		source.Jobs = append(source.Jobs, entry)
+	}
+	return writeXML(w, source)
+}
+
+// lookupOr returns the value of the normalized key, or fallback if there is none
+func lookupOr(values map[string]string, key, fallback string) string {
+	if value, ok := values[strings.ToLower(strings.TrimSpace(key))]; ok {
+		return value
+	}
+	return fallback
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,205 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// Media types of the syndication feeds
+const (
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+)
+
+// Channel describes a syndication feed of jobs for feed readers
+type Channel struct {
+	Title   string              // Feed title
+	SelfURL string              // URL the feed is fetched from
+	SiteURL string              // Page the feed is about, e.g. the careers site
+	Author  string              // Publisher of the jobs
+	JobURL  func(id int) string // Returns the page of a job
+}
+
+// NewestFirst sorts jobs by publication time, newest first, and keeps at most limit of them
+// @param jobs []*domain.Job - The jobs to sort, modified in place
+// @param limit int - The maximum number of jobs kept
+// @return []*domain.Job - The newest jobs
+func NewestFirst(jobs []*domain.Job, limit int) []*domain.Job {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].PostedAt().Equal(jobs[j].PostedAt()) {
+			return jobs[i].ID > jobs[j].ID
+		}
+		return jobs[i].PostedAt().After(jobs[j].PostedAt())
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+// feedUpdated returns the last update among the jobs, or now when there are none
+func feedUpdated(jobs []*domain.Job) time.Time {
+	if updated := lastBuildDate(jobs); !updated.IsZero() {
+		return updated
+	}
+	return time.Now().UTC()
+}
+
+// atomFeed is the root element of an Atom feed
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomLink is a link of an Atom feed or entry
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// atomAuthor is the author of an Atom feed
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// atomEntry is an entry of an Atom feed
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Link       atomLink       `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+// atomCategory is a category of an Atom entry
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomContent is the content of an Atom entry
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// WriteAtom writes the jobs as an Atom feed
+// Entries are published when the job went live and updated when it was last modified;
+// the feed is updated at the latest modification among its entries.
+// @param w io.Writer - The destination of the feed
+// @param channel Channel - The feed details
+// @param jobs []*domain.Job - The jobs, in the order they are listed
+// @return error - An error if the feed cannot be written
+func WriteAtom(w io.Writer, channel Channel, jobs []*domain.Job) error {
+	feed := atomFeed{
+		Title:   channel.Title,
+		ID:      channel.SelfURL,
+		Updated: feedUpdated(jobs).Format(time.RFC3339),
+		Links:   []atomLink{{Rel: "self", Type: "application/atom+xml", Href: channel.SelfURL}},
+		Author:  atomAuthor{Name: channel.Author},
+		Entries: make([]atomEntry, 0, len(jobs)),
+	}
+	if channel.SiteURL != "" {
+		feed.Links = append(feed.Links, atomLink{Rel: "alternate", Type: "text/html", Href: channel.SiteURL})
+	}
+	for _, job := range jobs {
+		link := channel.JobURL(job.ID)
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:      job.Title,
+			ID:         link,
+			Published:  job.PostedAt().UTC().Format(time.RFC3339),
+			Updated:    job.UpdatedAt.UTC().Format(time.RFC3339),
+			Link:       atomLink{Rel: "alternate", Href: link},
+			Categories: jobCategories(job, func(term string) atomCategory { return atomCategory{Term: term} }),
+			Content:    atomContent{Type: "text", Text: job.Description},
+		})
+	}
+	return writeXML(w, feed)
+}
+
+// rssFeed is the root element of an RSS 2.0 feed
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is the channel of an RSS feed
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssItem is an item of an RSS feed
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+// WriteRSS writes the jobs as an RSS 2.0 feed
+// Items are dated when the job went live; the channel lastBuildDate is the latest modification.
+// @param w io.Writer - The destination of the feed
+// @param channel Channel - The feed details
+// @param jobs []*domain.Job - The jobs, in the order they are listed
+// @return error - An error if the feed cannot be written
+func WriteRSS(w io.Writer, channel Channel, jobs []*domain.Job) error {
+	site := channel.SiteURL
+	if site == "" {
+		site = channel.SelfURL
+	}
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          site,
+			Description:   channel.Title,
+			LastBuildDate: feedUpdated(jobs).Format(time.RFC1123Z),
+			AtomLink:      atomLink{Rel: "self", Type: "application/rss+xml", Href: channel.SelfURL},
+			Items:         make([]rssItem, 0, len(jobs)),
+		},
+	}
+	for _, job := range jobs {
+		link := channel.JobURL(job.ID)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       job.Title,
+			Link:        link,
+			GUID:        link,
+			PubDate:     job.PostedAt().UTC().Format(time.RFC1123Z),
+			Categories:  jobCategories(job, func(term string) string { return term }),
+			Description: job.Description,
+		})
+	}
+	return writeXML(w, feed)
+}
+
+// jobCategories returns the non-empty classification values of a job as feed categories
+func jobCategories[T any](job *domain.Job, category func(term string) T) []T {
+	var categories []T
+	for _, term := range []string{job.Department, job.EmploymentType, job.Seniority} {
+		if term != "" {
+			categories = append(categories, category(term))
+		}
+	}
+	return categories
+}
@@ -0,0 +1,81 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannel is the channel of the syndication feeds in the tests
+var testChannel = Channel{
+	Title:   "Acme jobs",
+	SelfURL: "https://api.acme.example/jobs/feed.atom?department=Engineering",
+	SiteURL: "https://careers.acme.example",
+	Author:  "Acme jobs",
+	JobURL:  func(id int) string { return "https://careers.acme.example/jobs/" + strconv.Itoa(id) },
+}
+
+func TestNewestFirst(t *testing.T) {
+	jobs := NewestFirst(testJobs(), 1)
+
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 8, jobs[0].ID)
+}
+
+func TestWriteAtom(t *testing.T) {
+	// Execute
+	var buf bytes.Buffer
+	err := WriteAtom(&buf, testChannel, testJobs())
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &feed))
+	assert.Equal(t, testChannel.SelfURL, feed.ID)
+	assert.Equal(t, "2024-02-03T09:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 2)
+	entry := feed.Entries[0]
+	assert.Equal(t, "https://careers.acme.example/jobs/7", entry.ID)
+	assert.Equal(t, "2024-02-01T09:00:00Z", entry.Published)
+	assert.Equal(t, "2024-02-03T09:00:00Z", entry.Updated)
+	assert.Equal(t, "Build APIs <fast> & ship ]]> often", entry.Content.Text)
+	assert.Equal(t, []atomCategory{{"Engineering"}, {"full-time"}, {"senior"}}, entry.Categories)
+}
+
+func TestWriteAtom_EmptyFeedIsUpdatedNow(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteAtom(&buf, testChannel, nil))
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &feed))
+	updated, err := time.Parse(time.RFC3339, feed.Updated)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), updated, time.Minute)
+	assert.Empty(t, feed.Entries)
+}
+
+func TestWriteRSS(t *testing.T) {
+	// Execute
+	var buf bytes.Buffer
+	err := WriteRSS(&buf, testChannel, testJobs())
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, buf.String(), `<link>https://careers.acme.example</link>`)
+	assert.Contains(t, buf.String(), `<atom:link rel="self" type="application/rss+xml" href="`)
+
+	var feed rssFeed
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &feed))
+	assert.Equal(t, "Sat, 03 Feb 2024 09:00:00 +0000", feed.Channel.LastBuildDate)
+	require.Len(t, feed.Channel.Items, 2)
+	assert.Equal(t, "Thu, 01 Feb 2024 09:00:00 +0000", feed.Channel.Items[0].PubDate)
+	assert.Equal(t, "https://careers.acme.example/jobs/7", feed.Channel.Items[0].GUID)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header to the size of its column
+const maxIdempotencyKeyLength = 255
+
+// idempotencyLock is how long a reservation blocks retries before it is considered abandoned
+// (e.g., the replica processing the request crashed before storing the response)
+const idempotencyLock = time.Minute
+
+// IdempotencyService defines methods for replaying the responses of requests retried with an Idempotency-Key
+type IdempotencyService interface {
+	Begin(scope, key, requestHash string) (*domain.IdempotentResponse, error) // Reserves a key or returns its stored response
+	Complete(scope, key string, response domain.IdempotentResponse) error     // Stores the response of a reserved key
+	Release(scope, key string) error                                          // Frees a reserved key so the request can be retried
+	PurgeExpired() (int64, error)                                             // Removes the keys past their TTL
+}
+
+type idempotencyServiceImpl struct {
+	repo repository.IdempotencyRepository // Dependency on the IdempotencyRepository
+	ttl  time.Duration                    // How long a key and its response are kept
+	now  func() time.Time                 // Clock, replaced in tests
+}
+
+// NewIdempotencyService creates a new IdempotencyService instance
+// @param repo repository.IdempotencyRepository - The repository storing the keys
+// @param ttl time.Duration - How long a key and its response are kept
+// @return IdempotencyService - The implementation of the service interface
+func NewIdempotencyService(repo repository.IdempotencyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyServiceImpl{repo: repo, ttl: ttl, now: time.Now}
+}
+
+// Begin reserves an idempotency key for a request, or returns the response stored for it
+// @param scope string - The caller the key belongs to
+// @param key string - The Idempotency-Key header value
+// @param requestHash string - The hash identifying the request made with the key
+// @return *domain.IdempotentResponse - The stored response to replay, nil if the key was reserved and the request must be processed
+// @return error - A *domain.ValidationError for an invalid key, domain.ErrIdempotencyKeyReused if the key was used for another request,
+// domain.ErrRequestInProgress if the first request has not finished, or an error if the query fails
+func (s *idempotencyServiceImpl) Begin(scope, key, requestHash string) (*domain.IdempotentResponse, error) {
+	if err := validateIdempotencyKey(key); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	existing, err := s.repo.Reserve(&domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}, now.Add(idempotencyLock))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case existing == nil:
+		return nil, nil
+	case existing.RequestHash != requestHash:
+		return nil, domain.ErrIdempotencyKeyReused
+	case existing.Response == nil:
+		return nil, domain.ErrRequestInProgress
+	}
+	return existing.Response, nil
+}
+
+// Complete stores the response of a reserved key
+// @param scope string - The caller the key belongs to
+// @param key string - The Idempotency-Key header value
+// @param response domain.IdempotentResponse - The response replayed on retries
+// @return error - An error if the query fails
+func (s *idempotencyServiceImpl) Complete(scope, key string, response domain.IdempotentResponse) error {
+	return s.repo.Complete(scope, key, response)
+}
+
+// Release frees a reserved key whose request failed, so the client can retry it
+// @param scope string - The caller the key belongs to
+// @param key string - The Idempotency-Key header value
+// @return error - An error if the query fails
+func (s *idempotencyServiceImpl) Release(scope, key string) error {
+	return s.repo.Release(scope, key)
+}
+
+// PurgeExpired removes the keys past their TTL
+// @return int64 - The number of removed keys
+// @return error - An error if the query fails
+func (s *idempotencyServiceImpl) PurgeExpired() (int64, error) {
+	return s.repo.DeleteExpired(s.now().UTC())
+}
+
+// validateIdempotencyKey checks the key is non-empty printable ASCII that fits its column
+// @param key string - The Idempotency-Key header value
+// @return error - A *domain.ValidationError if the key is invalid
+func validateIdempotencyKey(key string) error {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return &domain.ValidationError{Field: "Idempotency-Key", Message: "must be between 1 and 255 characters"}
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return &domain.ValidationError{Field: "Idempotency-Key", Message: "must only contain printable ASCII characters"}
+		}
+	}
+	return nil
+}
+
+// RunIdempotencyPurger periodically removes the idempotency keys past their TTL
+// It purges once at start-up and then on every interval tick, until ctx is cancelled.
+// Failures are logged and retried on the next tick.
+// @param ctx context.Context - Stops the purger when cancelled
+// @param keys IdempotencyService - The service used to purge the keys
+// @param interval time.Duration - How often the purge runs
+func RunIdempotencyPurger(ctx context.Context, keys IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := keys.PurgeExpired()
+		if err != nil {
+			log.Printf("Failed to purge expired idempotency keys: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d expired idempotency keys", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
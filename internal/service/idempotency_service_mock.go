@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyService is a mock implementation of IdempotencyService for testing
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+// Begin mocks the Begin method
+func (m *MockIdempotencyService) Begin(scope, key, requestHash string) (*domain.IdempotentResponse, error) {
+	args := m.Called(scope, key, requestHash)
+	if response, ok := args.Get(0).(*domain.IdempotentResponse); ok {
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Complete mocks the Complete method
+func (m *MockIdempotencyService) Complete(scope, key string, response domain.IdempotentResponse) error {
+	args := m.Called(scope, key, response)
+	return args.Error(0)
+}
+
+// Release mocks the Release method
+func (m *MockIdempotencyService) Release(scope, key string) error {
+	args := m.Called(scope, key)
+	return args.Error(0)
+}
+
+// PurgeExpired mocks the PurgeExpired method
+func (m *MockIdempotencyService) PurgeExpired() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
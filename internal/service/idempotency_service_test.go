@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestIdempotencyService returns a service with a 24h TTL and a clock fixed at now
+func newTestIdempotencyService(repo repository.IdempotencyRepository, now time.Time) IdempotencyService {
+	return &idempotencyServiceImpl{repo: repo, ttl: 24 * time.Hour, now: func() time.Time { return now }}
+}
+
+func TestBegin_ReservesKey(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockIdempotencyRepository)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	idempotencyService := newTestIdempotencyService(mockRepo, now)
+
+	// Mock behavior
+	mockRepo.On("Reserve", mock.MatchedBy(func(record *domain.IdempotencyRecord) bool {
+		return record.Scope == "acme" && record.Key == "key-1" && record.RequestHash == "hash" &&
+			record.ExpiresAt.Equal(now.Add(24*time.Hour))
+	}), now.Add(idempotencyLock)).Return(nil, nil)
+
+	// Execute
+	response, err := idempotencyService.Begin("acme", "key-1", "hash")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBegin_ReplaysStoredResponse(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockIdempotencyRepository)
+	idempotencyService := newTestIdempotencyService(mockRepo, time.Now())
+	stored := &domain.IdempotentResponse{StatusCode: 201, Body: []byte(`{"message":"job created successfully"}`)}
+
+	// Mock behavior
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(&domain.IdempotencyRecord{RequestHash: "hash", Response: stored}, nil)
+
+	// Execute
+	response, err := idempotencyService.Begin("acme", "key-1", "hash")
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, stored, response)
+}
+
+func TestBegin_KeyReusedForAnotherRequest(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockIdempotencyRepository)
+	idempotencyService := newTestIdempotencyService(mockRepo, time.Now())
+
+	// Mock behavior
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(&domain.IdempotencyRecord{RequestHash: "other", Response: &domain.IdempotentResponse{StatusCode: 201}}, nil)
+
+	// Execute
+	_, err := idempotencyService.Begin("acme", "key-1", "hash")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+}
+
+func TestBegin_RequestInProgress(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockIdempotencyRepository)
+	idempotencyService := newTestIdempotencyService(mockRepo, time.Now())
+
+	// Mock behavior
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).Return(&domain.IdempotencyRecord{RequestHash: "hash"}, nil)
+
+	// Execute
+	_, err := idempotencyService.Begin("acme", "key-1", "hash")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrRequestInProgress)
+}
+
+func TestBegin_InvalidKey(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockIdempotencyRepository)
+	idempotencyService := newTestIdempotencyService(mockRepo, time.Now())
+
+	for _, key := range []string{"has space", string(make([]byte, 256)), "clé"} {
+		// Execute
+		_, err := idempotencyService.Begin("acme", key, "hash")
+
+		// Assertions
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr, key)
+	}
+	mockRepo.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+}
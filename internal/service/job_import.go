@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// Limits of a job import
+const (
+	importBatchSize  = 500     // Jobs inserted per statement
+	maxImportRows    = 10000   // Data rows accepted per file
+	maxImportErrors  = 100     // Rejected rows listed in the report
+	maxImportLineLen = 1 << 20 // Longest NDJSON line
+)
+
+// importRow holds the fields of one imported job, as read from the file
+type importRow struct {
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	SalaryRange    string `json:"salary_range"`
+	EmploymentType string `json:"employment_type"`
+	Seniority      string `json:"seniority"`
+	Department     string `json:"department"`
+	Status         string `json:"status"`
+}
+
+// job converts the row to a job, trimming surrounding whitespace
+func (r *importRow) job() *domain.Job {
+	return &domain.Job{
+		Title:          strings.TrimSpace(r.Title),
+		Description:    strings.TrimSpace(r.Description),
+		SalaryRange:    strings.TrimSpace(r.SalaryRange),
+		EmploymentType: strings.TrimSpace(r.EmploymentType),
+		Seniority:      strings.TrimSpace(r.Seniority),
+		Department:     strings.TrimSpace(r.Department),
+		Status:         strings.TrimSpace(r.Status),
+	}
+}
+
+// importColumns maps the CSV header names to the row fields
+var importColumns = map[string]func(row *importRow, value string){
+	"title":           func(row *importRow, value string) { row.Title = value },
+	"description":     func(row *importRow, value string) { row.Description = value },
+	"salary_range":    func(row *importRow, value string) { row.SalaryRange = value },
+	"employment_type": func(row *importRow, value string) { row.EmploymentType = value },
+	"seniority":       func(row *importRow, value string) { row.Seniority = value },
+	"department":      func(row *importRow, value string) { row.Department = value },
+	"status":          func(row *importRow, value string) { row.Status = value },
+}
+
+// jobRowReader reads an import file one row at a time
+type jobRowReader interface {
+	// next returns the next row and its line; a malformed row is reported in rowErr and reading can go on.
+	// err is io.EOF at the end of the file, or a failure that aborts the import.
+	next() (row *importRow, line int, rowErr *domain.ImportRowError, err error)
+}
+
+// newJobRowReader returns the reader for the given format
+func newJobRowReader(format string, r io.Reader) (jobRowReader, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVRowReader(r)
+	case domain.ImportFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64<<10), maxImportLineLen)
+		return &ndjsonRowReader{scanner: scanner}, nil
+	default:
+		return nil, &domain.ValidationError{Field: "format", Message: "must be csv or ndjson"}
+	}
+}
+
+// csvRowReader reads rows from a CSV file whose first line names the columns
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns []func(row *importRow, value string) // Setter of each column, in file order
+}
+
+// newCSVRowReader reads and checks the header line
+func newCSVRowReader(r io.Reader) (*csvRowReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Field counts are checked per row
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &domain.ValidationError{Field: "file", Message: "is empty"}
+	}
+	if err != nil {
+		return nil, &domain.ValidationError{Field: "file", Message: "invalid CSV header: " + err.Error()}
+	}
+
+	columns := make([]func(row *importRow, value string), len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Spreadsheet exports often start with a byte order mark
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		setter, ok := importColumns[name]
+		if !ok {
+			return nil, &domain.ValidationError{Field: "file", Message: "unknown column " + name}
+		}
+		if seen[name] {
+			return nil, &domain.ValidationError{Field: "file", Message: "duplicate column " + name}
+		}
+		seen[name] = true
+		columns[i] = setter
+	}
+	if !seen["title"] || !seen["description"] {
+		return nil, &domain.ValidationError{Field: "file", Message: "title and description columns are required"}
+	}
+	return &csvRowReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvRowReader) next() (*importRow, int, *domain.ImportRowError, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, 0, nil, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, parseErr.StartLine, &domain.ImportRowError{Row: parseErr.StartLine, Message: parseErr.Err.Error()}, nil
+	}
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	if len(record) != len(r.columns) {
+		message := fmt.Sprintf("has %d fields, the header has %d", len(record), len(r.columns))
+		return nil, line, &domain.ImportRowError{Row: line, Message: message}, nil
+	}
+	row := &importRow{}
+	for i, value := range record {
+		r.columns[i](row, value)
+	}
+	return row, line, nil, nil
+}
+
+// ndjsonRowReader reads one JSON object per line; blank lines are skipped
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonRowReader) next() (*importRow, int, *domain.ImportRowError, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+		row := &importRow{}
+		if err := decoder.Decode(row); err != nil {
+			return nil, r.line, &domain.ImportRowError{Row: r.line, Message: "invalid JSON: " + err.Error()}, nil
+		}
+		return row, r.line, nil, nil
+	}
+	if errors.Is(r.scanner.Err(), bufio.ErrTooLong) {
+		return nil, 0, nil, &domain.ValidationError{Field: "file", Message: fmt.Sprintf("line %d is too long", r.line+1)}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, 0, nil, err
+	}
+	return nil, 0, nil, io.EOF
+}
+
+// ImportJobs creates jobs from a CSV or NDJSON file
+// The file is parsed and validated row by row. Jobs are only created when every row is valid,
+// in batches inside a single transaction, each with its audit entry and job.created event;
+// with dryRun nothing is written and the report tells what would happen.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param format string - domain.ImportFormatCSV or domain.ImportFormatNDJSON
+// @param r io.Reader - The file content
+// @param dryRun bool - Only validate the file
+// @return *domain.ImportReport - Row counts, created IDs and rejected rows
+// @return error - A *domain.ValidationError for an unreadable or oversized file, or an error if the creation fails
+func (s *jobServiceImpl) ImportJobs(ctx context.Context, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	reader, err := newJobRowReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun, Errors: []domain.ImportRowError{}}
+	known := make(map[string]bool) // Classification lookups shared by all rows
+	var jobs []*domain.Job
+	for {
+		row, line, rowErr, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Total++
+		if report.Total > maxImportRows {
+			return nil, &domain.ValidationError{Field: "file", Message: fmt.Sprintf("has more than %d rows", maxImportRows)}
+		}
+
+		var job *domain.Job
+		if rowErr == nil {
+			job = row.job()
+			if rowErr, err = s.validateImportedJob(job, line, known); err != nil {
+				return nil, err
+			}
+		}
+		if rowErr != nil {
+			report.Invalid++
+			if len(report.Errors) < maxImportErrors {
+				report.Errors = append(report.Errors, *rowErr)
+			}
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	report.Valid = len(jobs)
+
+	if dryRun || report.Invalid > 0 || len(jobs) == 0 {
+		return report, nil
+	}
+
+	err = s.withinTx(func(repos repository.TxRepositories) error {
+		for start := 0; start < len(jobs); start += importBatchSize {
+			if err := repos.Jobs.CreateBatch(jobs[start:min(start+importBatchSize, len(jobs))]); err != nil {
+				return err
+			}
+		}
+		for _, job := range jobs {
+			if err := recordJobChange(ctx, repos, domain.AuditActionCreate, job.ID, nil, job); err != nil {
+				return err
+			}
+			if err := emitJobEvent(repos, domain.EventJobCreated, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Imported = len(jobs)
+	report.JobIDs = make([]int, len(jobs))
+	for i, job := range jobs {
+		report.JobIDs[i] = job.ID
+	}
+	return report, nil
+}
+
+// validateImportedJob applies the AddJob rules to an imported row
+// It returns the reason the row is rejected, or an error if the vocabularies cannot be read.
+func (s *jobServiceImpl) validateImportedJob(job *domain.Job, line int, known map[string]bool) (*domain.ImportRowError, error) {
+	if job.Title == "" {
+		return &domain.ImportRowError{Row: line, Field: "title", Message: "is required"}, nil
+	}
+	if job.Description == "" {
+		return &domain.ImportRowError{Row: line, Field: "description", Message: "is required"}, nil
+	}
+	if job.Status == "" {
+		job.Status = domain.JobStatusPublished
+	}
+	if !domain.IsJobStatus(job.Status) {
+		return &domain.ImportRowError{Row: line, Field: "status", Message: "must be draft, published or closed"}, nil
+	}
+
+	s.normalizeClassification(job)
+	err := s.validateClassificationCached(job, known)
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return &domain.ImportRowError{Row: line, Field: validationErr.Field, Message: validationErr.Message}, nil
+	}
+	return nil, err
+}
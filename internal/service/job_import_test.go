@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportJobs_CSV(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Mock data: columns in any order, a byte order mark and a quoted comma
+	file := "\ufeffdescription,Title,salary_range\n" +
+		"\"Build APIs, mostly in Go\",Go Developer,50k-70k\n" +
+		"Run the pipelines,Data Engineer,\n"
+
+	// Mock behavior: the repository assigns consecutive IDs
+	mockRepo.On("CreateBatch", mock.MatchedBy(func(jobs []*domain.Job) bool {
+		return len(jobs) == 2 && jobs[0].Title == "Go Developer" && jobs[0].Description == "Build APIs, mostly in Go" &&
+			jobs[0].Status == domain.JobStatusPublished
+	})).Run(func(args mock.Arguments) {
+		for i, job := range args.Get(0).([]*domain.Job) {
+			job.ID = 100 + i
+		}
+	}).Return(nil)
+
+	// Execute
+	report, err := jobService.ImportJobs(context.Background(), domain.ImportFormatCSV, strings.NewReader(file), false)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []int{100, 101}, report.JobIDs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportJobs_InvalidRowsImportNothing(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Mock data
+	file := `{"title":"QA","description":"Test things"}
+{"title":"","description":"Nameless"}
+
+{"title":"SRE","description":"Pager","status":"archived"}
+not json
+{"title":"PM","description":"Plans","colour":"blue"}
+`
+
+	// Execute
+	report, err := jobService.ImportJobs(context.Background(), domain.ImportFormatNDJSON, strings.NewReader(file), false)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 1, report.Valid)
+	assert.Equal(t, 4, report.Invalid)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, domain.ImportRowError{Row: 2, Field: "title", Message: "is required"}, report.Errors[0])
+	assert.Equal(t, 4, report.Errors[1].Row)
+	assert.Equal(t, "status", report.Errors[1].Field)
+	assert.Equal(t, 5, report.Errors[2].Row)
+	assert.Equal(t, 6, report.Errors[3].Row)
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestImportJobs_DryRunCachesTaxonomyLookups(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data
+	file := "title,description,seniority\nA,a,senior\nB,b,senior\nC,c,wizard\nD,d\n"
+
+	// Mock behavior: each distinct term is looked up once
+	mockTaxonomy.On("Exists", domain.TaxonomySeniority, "senior").Return(true, nil).Once()
+	mockTaxonomy.On("Exists", domain.TaxonomySeniority, "wizard").Return(false, nil).Once()
+
+	// Execute
+	report, err := jobService.ImportJobs(context.Background(), domain.ImportFormatCSV, strings.NewReader(file), true)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, []domain.ImportRowError{
+		{Row: 4, Field: domain.TaxonomySeniority, Message: "unknown value wizard"},
+		{Row: 5, Message: "has 2 fields, the header has 3"},
+	}, report.Errors)
+	mockTaxonomy.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestImportJobs_UnknownColumn(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Execute
+	_, err := jobService.ImportJobs(context.Background(), domain.ImportFormatCSV, strings.NewReader("title,description,salary\n"), false)
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "file", validationErr.Field)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunJobPurger periodically removes the jobs soft-deleted longer ago than the retention period
+// It purges once at start-up and then on every interval tick, until ctx is cancelled.
+// Failures are logged and retried on the next tick. Running it on several replicas is safe:
+// the purge is a single idempotent DELETE.
+// @param ctx context.Context - Stops the purger when cancelled
+// @param jobs JobService - The service used to purge the jobs
+// @param retention time.Duration - How long deleted jobs are kept
+// @param interval time.Duration - How often the purge runs
+func RunJobPurger(ctx context.Context, jobs JobService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := jobs.PurgeDeletedJobs(retention)
+		if err != nil {
+			log.Printf("Failed to purge deleted jobs: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d jobs deleted more than %s ago", purged, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
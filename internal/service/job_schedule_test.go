@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// scheduleNow is the current time of the schedule tests
+var scheduleNow = time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+// at returns a pointer to scheduleNow moved by d
+func at(d time.Duration) *time.Time {
+	t := scheduleNow.Add(d)
+	return &t
+}
+
+func TestAddJob_ScheduledStartsAsDraft(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	// Mock behavior
+	mockRepo.On("Create", mock.MatchedBy(func(job *domain.Job) bool {
+		return job.Status == domain.JobStatusDraft
+	})).Return(nil)
+
+	// Execute
+	err := jobService.AddJob(context.Background(), &domain.Job{Title: "QA", Description: "Tests", PublishAt: at(time.Hour)})
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddJob_InvalidSchedule(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	tests := []struct {
+		job   *domain.Job
+		field string
+	}{
+		{&domain.Job{Title: "QA", Status: domain.JobStatusPublished, PublishAt: at(time.Hour)}, "publish_at"},
+		{&domain.Job{Title: "QA", PublishAt: at(2 * time.Hour), ExpiresAt: at(time.Hour)}, "expires_at"},
+	}
+	for _, tt := range tests {
+		// Execute
+		err := jobService.AddJob(context.Background(), tt.job)
+
+		// Assertions
+		var validationErr *domain.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, tt.field, validationErr.Field)
+		}
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateJob_PublishedJobCannotBeScheduled(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	// Mock behavior
+	mockRepo.On("FindByID", 4).Return(&domain.Job{ID: 4, Status: domain.JobStatusPublished}, nil)
+
+	// Execute
+	err := jobService.UpdateJob(context.Background(), &domain.Job{ID: 4, Title: "QA", Description: "Tests", PublishAt: at(time.Hour)})
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "publish_at", validationErr.Field)
+	}
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUpdateJob_ReschedulesDraft(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	// Mock behavior
+	mockRepo.On("FindByID", 4).Return(&domain.Job{ID: 4, Status: domain.JobStatusDraft}, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(job *domain.Job) bool { return job.ID == 4 && job.PublishAt != nil })).Return(nil)
+
+	// Execute
+	err := jobService.UpdateJob(context.Background(), &domain.Job{ID: 4, Title: "QA", Description: "Tests", PublishAt: at(time.Hour)})
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestChangeJobStatus_ExpiredJobCannotBePublished(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	// Mock behavior
+	mockRepo.On("FindByID", 3).Return(&domain.Job{ID: 3, Status: domain.JobStatusClosed, ExpiresAt: at(-time.Minute)}, nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 3, domain.JobStatusPublished, 0)
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeJobStatus_CancelsScheduledPublication(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return scheduleNow }))
+
+	// Mock behavior: publishing a scheduled draft by hand drops its publish_at
+	mockRepo.On("FindByID", 5).Return(&domain.Job{ID: 5, Status: domain.JobStatusDraft, PublishAt: at(time.Hour)}, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(job *domain.Job) bool { return job.ID == 5 && job.PublishAt == nil })).Return(nil)
+	mockRepo.On("UpdateStatus", 5, domain.JobStatusPublished, 0).Return(nil)
+
+	// Execute
+	err := jobService.ChangeJobStatus(context.Background(), 5, domain.JobStatusPublished, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplySchedule(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockOutbox := new(repository.MockOutboxRepository)
+	mockTx := &repository.MockTransactor{Repos: repository.TxRepositories{Jobs: mockRepo, Outbox: mockOutbox}}
+	mockTx.On("WithinTx")
+	jobService := NewJobService(mockRepo, WithTransactor(mockTx))
+
+	// Mock data
+	due := []*domain.Job{
+		{ID: 1, Status: domain.JobStatusDraft, PublishAt: at(-time.Minute)},
+		{ID: 2, Status: domain.JobStatusPublished, ExpiresAt: at(-time.Minute)},
+		{ID: 3, Status: domain.JobStatusDraft, PublishAt: at(-time.Hour)},
+		{ID: 4, Status: domain.JobStatusDraft, PublishAt: at(-2 * time.Hour), ExpiresAt: at(-time.Hour)},
+	}
+
+	// Mock behavior: job 3 was moved back to draft with a later publication in the meantime
+	mockRepo.On("FindScheduleDue", scheduleNow, scheduleBatchSize).Return(due, nil)
+	mockRepo.On("FindByID", 1).Return(due[0], nil)
+	mockRepo.On("FindByID", 2).Return(due[1], nil)
+	mockRepo.On("FindByID", 3).Return(&domain.Job{ID: 3, Status: domain.JobStatusDraft, PublishAt: at(time.Hour)}, nil)
+	mockRepo.On("FindByID", 4).Return(due[3], nil)
+	mockRepo.On("UpdateStatus", 1, domain.JobStatusPublished, 0).Return(nil)
+	mockRepo.On("UpdateStatus", 2, domain.JobStatusClosed, 0).Return(nil)
+	mockRepo.On("UpdateStatus", 4, domain.JobStatusClosed, 0).Return(nil)
+	mockOutbox.On("Enqueue", mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventJobPublished && event.AggregateID == 1
+	})).Return(nil)
+	mockOutbox.On("Enqueue", mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventJobClosed && (event.AggregateID == 2 || event.AggregateID == 4)
+	})).Return(nil)
+
+	// Execute
+	published, closed, err := jobService.ApplySchedule(context.Background(), scheduleNow)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 2, closed)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", 3, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
@@ -1,6 +1,12 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/poolcamacho/jobs-service/internal/repository"
 )
@@ -8,19 +14,85 @@ import (
 // JobService defines methods for job-related operations
 // This interface abstracts the business logic for managing jobs.
 type JobService interface {
-	GetAllJobs() ([]*domain.Job, error) // Retrieves all jobs
-	AddJob(job *domain.Job) error       // Adds a new job
+	GetAllJobs() ([]*domain.Job, error)                                                                    // Retrieves all jobs
+	SearchJobs(filter domain.JobFilter) ([]*domain.Job, error)                                             // Retrieves the jobs matching a filter
+	GetJobFacets(filter domain.JobFilter) (*domain.JobFacets, error)                                       // Counts matching jobs per classification value
+	ExportJobs(filter domain.JobFilter, fn func(job *domain.Job) error) error                              // Streams the jobs matching a filter
+	GetJobByID(id int) (*domain.Job, error)                                                                // Retrieves a single job
+	GetJobByIDIncludingDeleted(id int) (*domain.Job, error)                                                // Retrieves a single job, even if soft-deleted
+	AddJob(ctx context.Context, job *domain.Job) error                                                     // Adds a new job
+	UpdateJob(ctx context.Context, job *domain.Job) error                                                  // Updates an existing job
+	ChangeJobStatus(ctx context.Context, id int, status string, version int) error                         // Moves a job between draft, published and closed
+	ImportJobs(ctx context.Context, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error) // Creates jobs from a CSV or NDJSON file
+	DeleteJob(ctx context.Context, id int, version int) error                                              // Soft-deletes a job
+	RestoreJob(ctx context.Context, id int) error                                                          // Restores a soft-deleted job
+	PurgeDeletedJobs(retention time.Duration) (int64, error)                                               // Permanently removes jobs deleted longer ago than retention
+	ApplySchedule(ctx context.Context, now time.Time) (published, closed int, err error)                   // Publishes and closes the jobs whose schedule is due
+}
+
+// scheduleBatchSize is the number of due jobs loaded at a time by ApplySchedule
+const scheduleBatchSize = 100
+
+// Option configures optional collaborators of the job service
+type Option func(*jobServiceImpl)
+
+// WithTaxonomyRepository enables validation of the job classification fields
+// Without it, employment type, seniority and department are stored as given.
+// @param taxonomy repository.TaxonomyRepository - The repository holding the controlled vocabularies
+// @return Option - The option to pass to NewJobService
+func WithTaxonomyRepository(taxonomy repository.TaxonomyRepository) Option {
+	return func(s *jobServiceImpl) {
+		s.taxonomy = taxonomy
+	}
+}
+
+// WithSkillRepository enables resolution of skill aliases in job filters
+// Without it, skill filters must use the canonical skill names.
+// @param skills repository.SkillRepository - The repository holding the skills dictionary
+// @return Option - The option to pass to NewJobService
+func WithSkillRepository(skills repository.SkillRepository) Option {
+	return func(s *jobServiceImpl) {
+		s.skills = skills
+	}
+}
+
+// WithTransactor runs every job mutation in a transaction together with its audit entry and events
+// Without it, mutations go straight to the JobRepository and are neither audited nor announced.
+// @param transactor repository.Transactor - The transactor providing the transaction-bound repositories
+// @return Option - The option to pass to NewJobService
+func WithTransactor(transactor repository.Transactor) Option {
+	return func(s *jobServiceImpl) {
+		s.transactor = transactor
+	}
 }
 
 type jobServiceImpl struct {
-	repo repository.JobRepository // Dependency on the JobRepository
+	repo       repository.JobRepository      // Dependency on the JobRepository
+	taxonomy   repository.TaxonomyRepository // Optional vocabularies used to validate classifications
+	skills     repository.SkillRepository    // Optional dictionary used to resolve skill aliases
+	transactor repository.Transactor         // Optional unit of work for audited mutations
+	now        func() time.Time              // Clock, replaced in tests
+}
+
+// WithClock replaces the clock used to tell scheduled publications and expired jobs apart, and to compute the purge cutoff
+// @param now func() time.Time - Returns the current time
+// @return Option - The option to pass to NewJobService
+func WithClock(now func() time.Time) Option {
+	return func(s *jobServiceImpl) {
+		s.now = now
+	}
 }
 
 // NewJobService creates a new JobService instance
 // @param repo repository.JobRepository - The repository to interact with the database
+// @param opts ...Option - Optional collaborators (e.g., WithTaxonomyRepository)
 // @return JobService - The implementation of the service interface
-func NewJobService(repo repository.JobRepository) JobService {
-	return &jobServiceImpl{repo: repo}
+func NewJobService(repo repository.JobRepository, opts ...Option) JobService {
+	s := &jobServiceImpl{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetAllJobs retrieves all jobs from the repository
@@ -31,10 +103,444 @@ func (s *jobServiceImpl) GetAllJobs() ([]*domain.Job, error) {
 	return s.repo.FindAll() // Call repository method to fetch all jobs
 }
 
+// SearchJobs retrieves the jobs matching a filter
+// Normalises the filter and delegates the operation to the repository's FindByFilter method.
+// @param filter domain.JobFilter - The criteria the jobs must match
+// @return []*domain.Job - A slice of matching jobs
+// @return error - A *domain.ValidationError for an invalid filter, or an error if the retrieval fails
+func (s *jobServiceImpl) SearchJobs(filter domain.JobFilter) ([]*domain.Job, error) {
+	filter, err := s.normalizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByFilter(filter)
+}
+
+// ExportJobs streams the jobs matching a filter to fn, in ID order
+// Normalises the filter and delegates the operation to the repository's StreamByFilter method.
+// @param filter domain.JobFilter - The criteria the exported jobs must match
+// @param fn func(job *domain.Job) error - Called for each job; an error stops the export
+// @return error - A *domain.ValidationError for an invalid filter, the error returned by fn, or an error if the query fails
+func (s *jobServiceImpl) ExportJobs(filter domain.JobFilter, fn func(job *domain.Job) error) error {
+	filter, err := s.normalizeFilter(filter)
+	if err != nil {
+		return err
+	}
+	return s.repo.StreamByFilter(filter, fn)
+}
+
+// GetJobFacets counts the jobs matching a filter per classification value
+// Normalises the filter and delegates the operation to the repository's CountFacets method.
+// @param filter domain.JobFilter - The criteria the counted jobs must match
+// @return *domain.JobFacets - The facet counts
+// @return error - A *domain.ValidationError for an invalid filter, or an error if the count fails
+func (s *jobServiceImpl) GetJobFacets(filter domain.JobFilter) (*domain.JobFacets, error) {
+	filter, err := s.normalizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CountFacets(filter)
+}
+
+// normalizeFilter validates the skill match mode and resolves skill aliases to canonical names
+func (s *jobServiceImpl) normalizeFilter(filter domain.JobFilter) (domain.JobFilter, error) {
+	switch filter.SkillMatch {
+	case "", domain.SkillMatchAny, domain.SkillMatchAll:
+	default:
+		return filter, &domain.ValidationError{Field: "skill_match", Message: "must be any or all"}
+	}
+	if s.taxonomy != nil {
+		filter.EmploymentType = normalizeTerm(filter.EmploymentType)
+		filter.Seniority = normalizeTerm(filter.Seniority)
+		filter.Department = normalizeTerm(filter.Department)
+	}
+	if len(filter.Skills) == 0 || s.skills == nil {
+		return filter, nil
+	}
+
+	skills, err := canonicalSkillNames(s.skills, filter.Skills)
+	if err != nil {
+		return filter, err
+	}
+	filter.Skills = skills
+	return filter, nil
+}
+
+// GetJobByID retrieves a single job
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - domain.ErrJobNotFound if the job does not exist, or an error if the retrieval fails
+func (s *jobServiceImpl) GetJobByID(id int) (*domain.Job, error) {
+	return s.repo.FindByID(id)
+}
+
+// GetJobByIDIncludingDeleted retrieves a single job, even if it is soft-deleted
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - domain.ErrJobNotFound if the job does not exist, or an error if the retrieval fails
+func (s *jobServiceImpl) GetJobByIDIncludingDeleted(id int) (*domain.Job, error) {
+	return s.repo.FindByIDIncludingDeleted(id)
+}
+
 // AddJob adds a new job to the repository
-// Delegates the operation to the repository's Create method.
+// Validates the job and delegates the operation to the repository's Create method.
+// New jobs are published unless another status is given, or created as drafts when their
+// publish_at is in the future so the scheduler publishes them then.
+// @param ctx context.Context - Carries the actor recorded in the audit log
 // @param job *domain.Job - The job data to be added
-// @return error - An error if the creation fails
-func (s *jobServiceImpl) AddJob(job *domain.Job) error {
-	return s.repo.Create(job) // Call repository method to add a new job
+// @return error - A *domain.ValidationError for invalid fields, or an error if the creation fails
+func (s *jobServiceImpl) AddJob(ctx context.Context, job *domain.Job) error {
+	scheduled := job.PublishAt != nil && job.PublishAt.After(s.now())
+	if job.Status == "" {
+		job.Status = domain.JobStatusPublished
+		if scheduled {
+			job.Status = domain.JobStatusDraft
+		}
+	}
+	if !domain.IsJobStatus(job.Status) {
+		return &domain.ValidationError{Field: "status", Message: "must be draft, published or closed"}
+	}
+	if scheduled && job.Status == domain.JobStatusPublished {
+		return &domain.ValidationError{Field: "publish_at", Message: "is in the future; scheduled jobs start as drafts"}
+	}
+	if err := validateSchedule(job); err != nil {
+		return err
+	}
+	s.normalizeClassification(job)
+	if err := s.validateClassification(job); err != nil {
+		return err
+	}
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		if err := repos.Jobs.Create(job); err != nil { // Call repository method to add a new job
+			return err
+		}
+		after, err := loadSnapshot(repos, job.ID) // Reload to capture the values set by the database
+		if err != nil {
+			return err
+		}
+		if err := recordJobChange(ctx, repos, domain.AuditActionCreate, job.ID, nil, after); err != nil {
+			return err
+		}
+		return emitJobEvent(repos, domain.EventJobCreated, after)
+	})
+}
+
+// UpdateJob updates an existing job in the repository
+// Validates the classification fields the update changes and delegates the operation to the
+// repository's Update method; unchanged ones are kept even if their term was deleted since.
+// The status is left untouched; use ChangeJobStatus instead. The schedule is replaced like the
+// other fields, so omitting publish_at or expires_at clears them; a published job cannot get a
+// future publish_at, as it would stay live. The update only applies if the job is still at
+// job.Version, which is then set to the new version.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param job *domain.Job - The job data to be stored, identified by its ID and the version it was read at
+// @return error - A *domain.ValidationError for invalid fields, domain.ErrJobNotFound, domain.ErrVersionConflict, or an error if the update fails
+func (s *jobServiceImpl) UpdateJob(ctx context.Context, job *domain.Job) error {
+	if err := validateSchedule(job); err != nil {
+		return err
+	}
+	s.normalizeClassification(job)
+	classified := s.taxonomy != nil && (job.EmploymentType != "" || job.Seniority != "" || job.Department != "")
+	scheduled := job.PublishAt != nil && job.PublishAt.After(s.now())
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		before, err := loadSnapshot(repos, job.ID)
+		if err != nil {
+			return err
+		}
+		current := before
+		if current == nil && (classified || scheduled) {
+			if current, err = repos.Jobs.FindByID(job.ID); err != nil {
+				return err
+			}
+		}
+		if classified {
+			if err := s.validateClassification(changedClassification(job, current)); err != nil {
+				return err
+			}
+		}
+		if scheduled && current.Status == domain.JobStatusPublished {
+			return &domain.ValidationError{Field: "publish_at", Message: "is in the future; move the job back to draft to schedule it"}
+		}
+		if err := repos.Jobs.Update(job); err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+		after := *before
+		after.Title = job.Title
+		after.Description = job.Description
+		after.SalaryRange = job.SalaryRange
+		after.EmploymentType = job.EmploymentType
+		after.Seniority = job.Seniority
+		after.Department = job.Department
+		after.PublishAt = job.PublishAt
+		after.ExpiresAt = job.ExpiresAt
+		after.Version = job.Version
+		if err := recordJobChange(ctx, repos, domain.AuditActionUpdate, job.ID, before, &after); err != nil {
+			return err
+		}
+		return emitJobEvent(repos, domain.EventJobUpdated, &after)
+	})
+}
+
+// ChangeJobStatus moves a job between draft, published and closed
+// Setting the current status again is a no-op and is not audited. Publishing or moving back a
+// job by hand cancels its scheduled publication, and an expired job cannot be published again
+// until its expires_at is moved forward. The change only applies if the job is still at version.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param id int - The ID of the job
+// @param status string - The new status
+// @param version int - The version the job was read at; 0 changes it whatever its version
+// @return error - A *domain.ValidationError for unknown statuses or an expired job, domain.ErrJobNotFound, domain.ErrVersionConflict, or an error if the update fails
+func (s *jobServiceImpl) ChangeJobStatus(ctx context.Context, id int, status string, version int) error {
+	if !domain.IsJobStatus(status) {
+		return &domain.ValidationError{Field: "status", Message: "must be draft, published or closed"}
+	}
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		before, err := repos.Jobs.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if version > 0 && before.Version != version {
+			return domain.ErrVersionConflict
+		}
+		if before.Status == status {
+			return nil
+		}
+		if status == domain.JobStatusPublished && before.ExpiresAt != nil && !before.ExpiresAt.After(s.now()) {
+			return &domain.ValidationError{Field: "expires_at", Message: "has passed; move it forward before publishing"}
+		}
+		after := *before
+		after.Status = status
+		if before.PublishAt != nil && (status == domain.JobStatusDraft || before.Status == domain.JobStatusDraft) {
+			after.PublishAt = nil
+			if err := repos.Jobs.Update(&after); err != nil {
+				return err
+			}
+		}
+		return applyStatus(ctx, repos, before, &after)
+	})
+}
+
+// ApplySchedule publishes the drafts past their publish_at and closes the jobs past their expires_at
+// Each job changes in its own transaction, audited with the actor of ctx and announced like a
+// manual status change. Jobs changed in the meantime are re-checked and skipped if no longer due.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param now time.Time - The current time
+// @return published int - The number of jobs published
+// @return closed int - The number of jobs closed
+// @return err error - An error if the due jobs cannot be read or changed
+func (s *jobServiceImpl) ApplySchedule(ctx context.Context, now time.Time) (published, closed int, err error) {
+	for {
+		due, err := s.repo.FindScheduleDue(now, scheduleBatchSize)
+		if err != nil {
+			return published, closed, err
+		}
+		changed := 0
+		for _, job := range due {
+			var status string
+			err := s.withinTx(func(repos repository.TxRepositories) error {
+				before, err := repos.Jobs.FindByID(job.ID)
+				if errors.Is(err, domain.ErrJobNotFound) { // Deleted in the meantime
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				next, ok := before.ScheduledStatus(now)
+				if !ok {
+					return nil
+				}
+				after := *before
+				after.Status = next
+				err = applyStatus(ctx, repos, before, &after)
+				if errors.Is(err, domain.ErrVersionConflict) { // Changed since it was read; re-checked on the next run
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				status = next
+				return nil
+			})
+			if err != nil {
+				return published, closed, err
+			}
+			switch status {
+			case domain.JobStatusPublished:
+				published++
+				changed++
+			case domain.JobStatusClosed:
+				closed++
+				changed++
+			}
+		}
+		if len(due) < scheduleBatchSize || changed == 0 {
+			return published, closed, nil
+		}
+	}
+}
+
+// applyStatus stores the status of after if the job is still at after.Version, then audits and announces the change
+func applyStatus(ctx context.Context, repos repository.TxRepositories, before, after *domain.Job) error {
+	if err := repos.Jobs.UpdateStatus(after.ID, after.Status, after.Version); err != nil {
+		return err
+	}
+	after.Version++
+	if err := recordJobChange(ctx, repos, domain.AuditActionStatusChange, after.ID, before, after); err != nil {
+		return err
+	}
+	if eventType, ok := statusEvents[after.Status]; ok { // Moving back to draft is not announced
+		return emitJobEvent(repos, eventType, after)
+	}
+	return nil
+}
+
+// validateSchedule checks that a job does not expire before it is published
+func validateSchedule(job *domain.Job) error {
+	if job.PublishAt != nil && job.ExpiresAt != nil && !job.ExpiresAt.After(*job.PublishAt) {
+		return &domain.ValidationError{Field: "expires_at", Message: "must be after publish_at"}
+	}
+	return nil
+}
+
+// DeleteJob soft-deletes a job
+// The job disappears from listings and lookups but is kept for auditing until purged. The deletion
+// only applies if the job is still at version.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param id int - The ID of the job
+// @param version int - The version the job was read at; 0 deletes it whatever its version
+// @return error - domain.ErrJobNotFound if the job does not exist or is already deleted, domain.ErrVersionConflict, or an error if the deletion fails
+func (s *jobServiceImpl) DeleteJob(ctx context.Context, id int, version int) error {
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		before, err := loadSnapshot(repos, id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Jobs.Delete(id, version); err != nil {
+			return err
+		}
+		if err := recordJobChange(ctx, repos, domain.AuditActionDelete, id, before, nil); err != nil {
+			return err
+		}
+		return emitJobEvent(repos, domain.EventJobDeleted, before)
+	})
+}
+
+// RestoreJob restores a soft-deleted job
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param id int - The ID of the job
+// @return error - domain.ErrJobNotFound if the job does not exist or is not deleted, or an error if the restoration fails
+func (s *jobServiceImpl) RestoreJob(ctx context.Context, id int) error {
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		if err := repos.Jobs.Restore(id); err != nil {
+			return err
+		}
+		after, err := loadSnapshot(repos, id)
+		if err != nil {
+			return err
+		}
+		if err := recordJobChange(ctx, repos, domain.AuditActionRestore, id, nil, after); err != nil {
+			return err
+		}
+		return emitJobEvent(repos, domain.EventJobRestored, after)
+	})
+}
+
+// withinTx runs fn in a transaction when a transactor is configured
+// Otherwise fn runs directly against the JobRepository, without audit log nor events.
+func (s *jobServiceImpl) withinTx(fn func(repos repository.TxRepositories) error) error {
+	if s.transactor == nil {
+		return fn(repository.TxRepositories{Jobs: s.repo})
+	}
+	return s.transactor.WithinTx(fn)
+}
+
+// loadSnapshot loads the current state of a job for the audit log and the job events
+// Returns nil without querying when repos has neither an audit nor an outbox repository.
+func loadSnapshot(repos repository.TxRepositories, id int) (*domain.Job, error) {
+	if repos.Audit == nil && repos.Outbox == nil {
+		return nil, nil
+	}
+	return repos.Jobs.FindByID(id)
+}
+
+// PurgeDeletedJobs permanently removes the jobs soft-deleted longer ago than the retention period
+// @param retention time.Duration - How long deleted jobs are kept
+// @return int64 - The number of removed jobs
+// @return error - An error if the purge fails
+func (s *jobServiceImpl) PurgeDeletedJobs(retention time.Duration) (int64, error) {
+	return s.repo.PurgeDeleted(s.now().Add(-retention))
+}
+
+// normalizeClassification trims and lower-cases the job classification fields, the way AddTerm stores terms
+// Without controlled vocabularies the fields are free text and kept as given.
+func (s *jobServiceImpl) normalizeClassification(job *domain.Job) {
+	if s.taxonomy == nil {
+		return
+	}
+	job.EmploymentType = normalizeTerm(job.EmploymentType)
+	job.Seniority = normalizeTerm(job.Seniority)
+	job.Department = normalizeTerm(job.Department)
+}
+
+// normalizeTerm trims and lower-cases a taxonomy value
+func normalizeTerm(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// changedClassification returns a copy of job keeping only the classification fields that differ from current
+// Jobs stored before the values were normalised may differ from them in case only, which is no change.
+func changedClassification(job, current *domain.Job) *domain.Job {
+	changed := *job
+	if strings.EqualFold(changed.EmploymentType, current.EmploymentType) {
+		changed.EmploymentType = ""
+	}
+	if strings.EqualFold(changed.Seniority, current.Seniority) {
+		changed.Seniority = ""
+	}
+	if strings.EqualFold(changed.Department, current.Department) {
+		changed.Department = ""
+	}
+	return &changed
+}
+
+// validateClassification checks the job classification fields against the controlled vocabularies
+// Empty fields are allowed; a job does not have to be classified.
+func (s *jobServiceImpl) validateClassification(job *domain.Job) error {
+	return s.validateClassificationCached(job, nil)
+}
+
+// validateClassificationCached is validateClassification remembering the lookups in known
+// Bulk operations pass a shared map so every term is looked up once; known may be nil.
+func (s *jobServiceImpl) validateClassificationCached(job *domain.Job, known map[string]bool) error {
+	if s.taxonomy == nil {
+		return nil
+	}
+	fields := []struct{ kind, value string }{
+		{domain.TaxonomyEmploymentType, job.EmploymentType},
+		{domain.TaxonomySeniority, job.Seniority},
+		{domain.TaxonomyDepartment, job.Department},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		key := field.kind + "\x00" + field.value
+		exists, cached := known[key]
+		if !cached {
+			var err error
+			if exists, err = s.taxonomy.Exists(field.kind, field.value); err != nil {
+				return err
+			}
+			if known != nil {
+				known[key] = exists
+			}
+		}
+		if !exists {
+			return &domain.ValidationError{Field: field.kind, Message: "unknown value " + field.value}
+		}
+	}
+	return nil
 }
@@ -1,6 +1,10 @@
 package service
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,9 +26,149 @@ func (m *MockJobService) GetAllJobs() ([]*domain.Job, error) {
 }
 
 // AddJob mocks the AddJob method
+// @param ctx context.Context - The request context
 // @param job *domain.Job - The job data to be added
 // @return error - An error if the operation fails
-func (m *MockJobService) AddJob(job *domain.Job) error {
-	args := m.Called(job)
+func (m *MockJobService) AddJob(ctx context.Context, job *domain.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+// SearchJobs mocks the SearchJobs method
+// @param filter domain.JobFilter - The criteria the jobs must match
+// @return []*domain.Job - A slice of jobs
+// @return error - An error if the operation fails
+func (m *MockJobService) SearchJobs(filter domain.JobFilter) ([]*domain.Job, error) {
+	args := m.Called(filter)
+	if jobs, ok := args.Get(0).([]*domain.Job); ok {
+		return jobs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetJobFacets mocks the GetJobFacets method
+// @param filter domain.JobFilter - The criteria the counted jobs must match
+// @return *domain.JobFacets - The facet counts
+// @return error - An error if the operation fails
+func (m *MockJobService) GetJobFacets(filter domain.JobFilter) (*domain.JobFacets, error) {
+	args := m.Called(filter)
+	if facets, ok := args.Get(0).(*domain.JobFacets); ok {
+		return facets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ExportJobs mocks the ExportJobs method
+// Calls fn for each job passed as first return value.
+// @param filter domain.JobFilter - The criteria the exported jobs must match
+// @param fn func(job *domain.Job) error - Called for each job
+// @return error - An error if the operation fails
+func (m *MockJobService) ExportJobs(filter domain.JobFilter, fn func(job *domain.Job) error) error {
+	args := m.Called(filter, fn)
+	if jobs, ok := args.Get(0).([]*domain.Job); ok {
+		for _, job := range jobs {
+			if err := fn(job); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+// GetJobByID mocks the GetJobByID method
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - An error if the operation fails
+func (m *MockJobService) GetJobByID(id int) (*domain.Job, error) {
+	args := m.Called(id)
+	if job, ok := args.Get(0).(*domain.Job); ok {
+		return job, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateJob mocks the UpdateJob method
+// @param ctx context.Context - The request context
+// @param job *domain.Job - The job data to be stored
+// @return error - An error if the operation fails
+func (m *MockJobService) UpdateJob(ctx context.Context, job *domain.Job) error {
+	args := m.Called(ctx, job)
 	return args.Error(0)
 }
+
+// ChangeJobStatus mocks the ChangeJobStatus method
+// @param ctx context.Context - The request context
+// @param id int - The ID of the job
+// @param status string - The new status
+// @param version int - The version the job was read at
+// @return error - An error if the operation fails
+func (m *MockJobService) ChangeJobStatus(ctx context.Context, id int, status string, version int) error {
+	args := m.Called(ctx, id, status, version)
+	return args.Error(0)
+}
+
+// GetJobByIDIncludingDeleted mocks the GetJobByIDIncludingDeleted method
+// @param id int - The ID of the job
+// @return *domain.Job - The job
+// @return error - An error if the operation fails
+func (m *MockJobService) GetJobByIDIncludingDeleted(id int) (*domain.Job, error) {
+	args := m.Called(id)
+	if job, ok := args.Get(0).(*domain.Job); ok {
+		return job, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ImportJobs mocks the ImportJobs method
+// @param ctx context.Context - The request context
+// @param format string - The file format
+// @param r io.Reader - The file content
+// @param dryRun bool - Only validate the file
+// @return *domain.ImportReport - The import report
+// @return error - An error if the operation fails
+func (m *MockJobService) ImportJobs(ctx context.Context, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	args := m.Called(ctx, format, r, dryRun)
+	if report, ok := args.Get(0).(*domain.ImportReport); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// DeleteJob mocks the DeleteJob method
+// @param ctx context.Context - The request context
+// @param id int - The ID of the job
+// @param version int - The version the job was read at
+// @return error - An error if the operation fails
+func (m *MockJobService) DeleteJob(ctx context.Context, id int, version int) error {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+// RestoreJob mocks the RestoreJob method
+// @param ctx context.Context - The request context
+// @param id int - The ID of the job
+// @return error - An error if the operation fails
+func (m *MockJobService) RestoreJob(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// PurgeDeletedJobs mocks the PurgeDeletedJobs method
+// @param retention time.Duration - How long deleted jobs are kept
+// @return int64 - The number of removed jobs
+// @return error - An error if the operation fails
+func (m *MockJobService) PurgeDeletedJobs(retention time.Duration) (int64, error) {
+	args := m.Called(retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ApplySchedule mocks the ApplySchedule method
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param now time.Time - The current time
+// @return published int - The number of jobs published
+// @return closed int - The number of jobs closed
+// @return err error - An error if the operation fails
+func (m *MockJobService) ApplySchedule(ctx context.Context, now time.Time) (published, closed int, err error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/poolcamacho/jobs-service/internal/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetAllJobs(t *testing.T) {
@@ -58,7 +61,7 @@ func TestAddJob(t *testing.T) {
 	mockRepo.On("Create", newJob).Return(nil)
 
 	// Execute
-	err := jobService.AddJob(newJob)
+	err := jobService.AddJob(context.Background(), newJob)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -81,10 +84,234 @@ func TestAddJob_Error(t *testing.T) {
 	mockRepo.On("Create", newJob).Return(errors.New("database error"))
 
 	// Execute
-	err := jobService.AddJob(newJob)
+	err := jobService.AddJob(context.Background(), newJob)
 
 	// Assertions
 	assert.Error(t, err)
 	assert.EqualError(t, err, "database error")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestAddJob_ValidClassification(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data
+	newJob := &domain.Job{
+		Title:          "Backend Engineer",
+		Description:    "Build APIs.",
+		EmploymentType: "full-time",
+		Department:     "engineering",
+	}
+
+	// Mock behavior: only the fields that are set are validated
+	mockTaxonomy.On("Exists", domain.TaxonomyEmploymentType, "full-time").Return(true, nil)
+	mockTaxonomy.On("Exists", domain.TaxonomyDepartment, "engineering").Return(true, nil)
+	mockRepo.On("Create", newJob).Return(nil)
+
+	// Execute
+	err := jobService.AddJob(context.Background(), newJob)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockTaxonomy.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddJob_ClassificationIgnoresCase(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data
+	newJob := &domain.Job{Title: "Backend Engineer", Description: "Build APIs.", Department: " Engineering "}
+
+	// Mock behavior: terms are stored lower-cased
+	mockTaxonomy.On("Exists", domain.TaxonomyDepartment, "engineering").Return(true, nil)
+	mockRepo.On("Create", newJob).Return(nil)
+
+	// Execute
+	err := jobService.AddJob(context.Background(), newJob)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "engineering", newJob.Department)
+	mockTaxonomy.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddJob_UnknownClassification(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data
+	newJob := &domain.Job{Title: "Intern", Description: "Learn.", EmploymentType: "freelance"}
+
+	// Mock behavior
+	mockTaxonomy.On("Exists", domain.TaxonomyEmploymentType, "freelance").Return(false, nil)
+
+	// Execute
+	err := jobService.AddJob(context.Background(), newJob)
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, domain.TaxonomyEmploymentType, validationErr.Field)
+	mockRepo.AssertNotCalled(t, "Create", newJob)
+}
+
+func TestUpdateJob_UnknownSeniority(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data
+	job := &domain.Job{ID: 3, Title: "SRE", Description: "Keep things up.", Seniority: "wizard"}
+
+	// Mock behavior
+	mockRepo.On("FindByID", 3).Return(&domain.Job{ID: 3, Seniority: "senior"}, nil)
+	mockTaxonomy.On("Exists", domain.TaxonomySeniority, "wizard").Return(false, nil)
+
+	// Execute
+	err := jobService.UpdateJob(context.Background(), job)
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockRepo.AssertNotCalled(t, "Update", job)
+}
+
+func TestUpdateJob_KeepsUnchangedClassification(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockTaxonomy := new(repository.MockTaxonomyRepository)
+	jobService := NewJobService(mockRepo, WithTaxonomyRepository(mockTaxonomy))
+
+	// Mock data: the department term was deleted after the job was classified with it
+	job := &domain.Job{ID: 3, Title: "SRE", Description: "Keep things up.", Seniority: "Senior", Department: "Platform"}
+
+	// Mock behavior: only the changed seniority is validated
+	mockRepo.On("FindByID", 3).Return(&domain.Job{ID: 3, Seniority: "junior", Department: "Platform"}, nil)
+	mockTaxonomy.On("Exists", domain.TaxonomySeniority, "senior").Return(true, nil)
+	mockRepo.On("Update", job).Return(nil)
+
+	// Execute
+	err := jobService.UpdateJob(context.Background(), job)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "senior", job.Seniority)
+	assert.Equal(t, "platform", job.Department)
+	mockTaxonomy.AssertNotCalled(t, "Exists", domain.TaxonomyDepartment, mock.Anything)
+	mockTaxonomy.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetJobFacets(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Mock data
+	filter := domain.JobFilter{Department: "engineering"}
+	facets := &domain.JobFacets{
+		EmploymentType: map[string]int{"full-time": 4, "contract": 1},
+		Seniority:      map[string]int{"senior": 3, "junior": 2},
+		Department:     map[string]int{"engineering": 5, "sales": 2},
+	}
+
+	// Mock behavior
+	mockRepo.On("CountFacets", filter).Return(facets, nil)
+
+	// Execute
+	result, err := jobService.GetJobFacets(filter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, facets, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteJob_NotFound(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Mock behavior
+	mockRepo.On("Delete", 12, 0).Return(domain.ErrJobNotFound)
+
+	// Execute
+	err := jobService.DeleteJob(context.Background(), 12, 0)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPurgeDeletedJobs(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	jobService := NewJobService(mockRepo, WithClock(func() time.Time { return now }))
+	retention := 30 * 24 * time.Hour
+
+	// Mock behavior: the cutoff is the retention period before now
+	mockRepo.On("PurgeDeleted", time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)).Return(int64(3), nil)
+
+	// Execute
+	purged, err := jobService.PurgeDeletedJobs(retention)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRunJobPurger_StopsOnCancel(t *testing.T) {
+	// Setup
+	mockJobService := new(MockJobService)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// Mock behavior: cancel after the start-up purge
+	mockJobService.On("PurgeDeletedJobs", time.Hour).Return(int64(0), nil).Run(func(mock.Arguments) { cancel() })
+
+	// Execute
+	go func() {
+		RunJobPurger(ctx, mockJobService, time.Hour, time.Hour)
+		close(done)
+	}()
+
+	// Assertions
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after cancellation")
+	}
+	mockJobService.AssertNumberOfCalls(t, "PurgeDeletedJobs", 1)
+}
+
+func TestUpdateJob_VersionConflict(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	jobService := NewJobService(mockRepo)
+
+	// Mock data
+	job := &domain.Job{ID: 8, Title: "Designer", Description: "Design things", Version: 2}
+
+	// Mock behavior
+	mockRepo.On("Update", job).Return(domain.ErrVersionConflict)
+
+	// Execute
+	err := jobService.UpdateJob(context.Background(), job)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,309 @@
+package jobcache
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/poolcamacho/jobs-service/pkg/cache"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTTL is how long a cached listing is served when no WithTTL option is given
+const DefaultTTL = 30 * time.Second
+
+// generationKey holds the generation prefixed to every listing key; changing it invalidates them all
+const generationKey = "jobs:generation"
+
+// Option configures a JobCache
+type Option func(*JobCache)
+
+// WithTTL sets how long a cached listing is served
+// It bounds how stale a listing can get when another replica changes the jobs and the
+// replicas do not share the cache backend.
+// @param ttl time.Duration - The lifetime of a cached listing
+// @return Option - The option to pass to NewJobCache
+func WithTTL(ttl time.Duration) Option {
+	return func(c *JobCache) {
+		c.ttl = ttl
+	}
+}
+
+// Stats holds the counters of a JobCache
+type Stats struct {
+	Hits          int64   `json:"hits"`          // Listings served from the cache
+	Misses        int64   `json:"misses"`        // Listings loaded from the database
+	Shared        int64   `json:"shared"`        // Misses answered by a concurrent load of the same listing
+	Invalidations int64   `json:"invalidations"` // Job changes that discarded the cached listings
+	Errors        int64   `json:"errors"`        // Failed cache backend calls, served from the database instead
+	HitRatio      float64 `json:"hit_ratio"`     // Hits over lookups, 0 before the first lookup
+}
+
+// JobCache is a read-through cache of the job listings in front of a JobService
+// GetAllJobs, SearchJobs and GetJobFacets are served from the cache, keyed by filter. Every job
+// change made through the JobCache (or the SkillService returned by WrapSkillService) invalidates
+// all cached listings at once by moving to a new generation. Concurrent misses of the same listing
+// are collapsed into a single database query. Other methods go straight to the wrapped service.
+type JobCache struct {
+	service.JobService // The wrapped service
+
+	backend cache.Cache        // Stores the listings as JSON
+	ttl     time.Duration      // Lifetime of a cached listing
+	loads   singleflight.Group // Collapses concurrent misses of the same key
+
+	hits, misses, shared, invalidations, errors atomic.Int64
+	generations                                 atomic.Int64 // Disambiguates generations started within the same nanosecond
+}
+
+// NewJobCache wraps a JobService with a read-through cache of its listings
+// @param jobs service.JobService - The service loading the listings on a miss
+// @param backend cache.Cache - The store holding the cached listings (e.g., cache.NewLRU)
+// @param opts ...Option - Optional settings (e.g., WithTTL)
+// @return *JobCache - The caching service
+func NewJobCache(jobs service.JobService, backend cache.Cache, opts ...Option) *JobCache {
+	c := &JobCache{JobService: jobs, backend: backend, ttl: DefaultTTL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetAllJobs retrieves all jobs, from the cache when possible
+// @return []*domain.Job - A slice of all jobs
+// @return error - An error if the retrieval fails
+func (c *JobCache) GetAllJobs() ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	err := c.load("all", &jobs, func() (any, error) {
+		return c.JobService.GetAllJobs()
+	})
+	return jobs, err
+}
+
+// SearchJobs retrieves the jobs matching a filter, from the cache when possible
+// @param filter domain.JobFilter - The criteria the jobs must match
+// @return []*domain.Job - A slice of matching jobs
+// @return error - A *domain.ValidationError for an invalid filter, or an error if the retrieval fails
+func (c *JobCache) SearchJobs(filter domain.JobFilter) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	err := c.load("search:"+filterKey(filter), &jobs, func() (any, error) {
+		return c.JobService.SearchJobs(filter)
+	})
+	return jobs, err
+}
+
+// GetJobFacets counts the matching jobs per classification value, from the cache when possible
+// @param filter domain.JobFilter - The criteria the counted jobs must match
+// @return *domain.JobFacets - The facet counts
+// @return error - A *domain.ValidationError for an invalid filter, or an error if the retrieval fails
+func (c *JobCache) GetJobFacets(filter domain.JobFilter) (*domain.JobFacets, error) {
+	var facets *domain.JobFacets
+	err := c.load("facets:"+filterKey(filter), &facets, func() (any, error) {
+		return c.JobService.GetJobFacets(filter)
+	})
+	return facets, err
+}
+
+// AddJob adds a new job and invalidates the cached listings
+func (c *JobCache) AddJob(ctx context.Context, job *domain.Job) error {
+	return c.invalidateOnSuccess(c.JobService.AddJob(ctx, job))
+}
+
+// UpdateJob updates an existing job and invalidates the cached listings
+func (c *JobCache) UpdateJob(ctx context.Context, job *domain.Job) error {
+	return c.invalidateOnSuccess(c.JobService.UpdateJob(ctx, job))
+}
+
+// ChangeJobStatus changes the status of a job and invalidates the cached listings
+func (c *JobCache) ChangeJobStatus(ctx context.Context, id int, status string, version int) error {
+	return c.invalidateOnSuccess(c.JobService.ChangeJobStatus(ctx, id, status, version))
+}
+
+// DeleteJob soft-deletes a job and invalidates the cached listings
+func (c *JobCache) DeleteJob(ctx context.Context, id int, version int) error {
+	return c.invalidateOnSuccess(c.JobService.DeleteJob(ctx, id, version))
+}
+
+// RestoreJob restores a soft-deleted job and invalidates the cached listings
+func (c *JobCache) RestoreJob(ctx context.Context, id int) error {
+	return c.invalidateOnSuccess(c.JobService.RestoreJob(ctx, id))
+}
+
+// ImportJobs creates jobs from a file and invalidates the cached listings if any was created
+func (c *JobCache) ImportJobs(ctx context.Context, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	report, err := c.JobService.ImportJobs(ctx, format, r, dryRun)
+	if report != nil && report.Imported > 0 {
+		c.Invalidate()
+	}
+	return report, err
+}
+
+// PurgeDeletedJobs removes old soft-deleted jobs and invalidates the cached listings if any was removed
+func (c *JobCache) PurgeDeletedJobs(retention time.Duration) (int64, error) {
+	purged, err := c.JobService.PurgeDeletedJobs(retention)
+	if purged > 0 {
+		c.Invalidate()
+	}
+	return purged, err
+}
+
+// ApplySchedule publishes and closes the due jobs and invalidates the cached listings if any changed
+func (c *JobCache) ApplySchedule(ctx context.Context, now time.Time) (published, closed int, err error) {
+	published, closed, err = c.JobService.ApplySchedule(ctx, now)
+	if published+closed > 0 {
+		c.Invalidate()
+	}
+	return published, closed, err
+}
+
+// Invalidate discards every cached listing
+// Listings cached under the previous generation are no longer looked up and age out of the backend.
+func (c *JobCache) Invalidate() {
+	c.invalidations.Add(1)
+	if err := c.backend.Set(generationKey, []byte(c.newGeneration()), 0); err != nil {
+		c.errors.Add(1)
+	}
+}
+
+// Stats returns the counters of the cache
+// @return Stats - A snapshot of the counters
+func (c *JobCache) Stats() Stats {
+	stats := Stats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Shared:        c.shared.Load(),
+		Invalidations: c.invalidations.Load(),
+		Errors:        c.errors.Load(),
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
+
+// WrapSkillService returns a SkillService invalidating the cached listings when job tags or aliases change
+// Skill filters depend on both, so their cached results would otherwise go stale.
+// @param skills service.SkillService - The service to wrap
+// @return service.SkillService - The invalidating service
+func (c *JobCache) WrapSkillService(skills service.SkillService) service.SkillService {
+	return &skillService{SkillService: skills, cache: c}
+}
+
+// load decodes the listing cached under key into dst, calling fetch and caching its result on a miss
+// Errors returned by fetch are not cached.
+func (c *JobCache) load(key string, dst any, fetch func() (any, error)) error {
+	key = "jobs:" + c.generation() + ":" + key
+	data, ok, err := c.backend.Get(key)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	if ok {
+		c.hits.Add(1)
+		return json.Unmarshal(data, dst)
+	}
+
+	c.misses.Add(1)
+	loaded := false
+	value, err, shared := c.loads.Do(key, func() (any, error) {
+		loaded = true
+		result, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.backend.Set(key, data, c.ttl); err != nil {
+			c.errors.Add(1)
+		}
+		return data, nil
+	})
+	if shared && !loaded {
+		c.shared.Add(1)
+	}
+	if err != nil {
+		return err
+	}
+	// Every caller decodes its own copy, so callers sharing a load cannot see each other's changes
+	return json.Unmarshal(value.([]byte), dst)
+}
+
+// generation returns the current generation, starting one if the backend holds none
+func (c *JobCache) generation() string {
+	data, ok, err := c.backend.Get(generationKey)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	if ok {
+		return string(data)
+	}
+	generation := c.newGeneration()
+	if err := c.backend.Set(generationKey, []byte(generation), 0); err != nil {
+		c.errors.Add(1)
+	}
+	return generation
+}
+
+// newGeneration returns a generation unlikely to have been used before, even by another replica
+func (c *JobCache) newGeneration() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "." + strconv.FormatInt(c.generations.Add(1), 36)
+}
+
+// invalidateOnSuccess invalidates the cached listings unless the change failed
+func (c *JobCache) invalidateOnSuccess(err error) error {
+	if err == nil {
+		c.Invalidate()
+	}
+	return err
+}
+
+// filterKey encodes a filter canonically, so filters differing only in skill order share a cache entry
+func filterKey(filter domain.JobFilter) string {
+	skills := append([]string(nil), filter.Skills...)
+	sort.Strings(skills) // Any and all matches do not depend on the order of the skills
+
+	values := url.Values{}
+	values.Set("employment_type", filter.EmploymentType)
+	values.Set("seniority", filter.Seniority)
+	values.Set("department", filter.Department)
+	values.Set("status", filter.Status)
+	values.Set("skills", strings.Join(skills, ","))
+	values.Set("skill_match", filter.SkillMatch)
+	values.Set("include_deleted", strconv.FormatBool(filter.IncludeDeleted))
+	return values.Encode()
+}
+
+// skillService invalidates the cached listings when the skills tagged on jobs or their aliases change
+type skillService struct {
+	service.SkillService
+	cache *JobCache
+}
+
+// AddAlias adds an alias to a skill and invalidates the cached listings
+func (s *skillService) AddAlias(skillID int, alias string) error {
+	return s.cache.invalidateOnSuccess(s.SkillService.AddAlias(skillID, alias))
+}
+
+// RemoveSkill removes a skill from the dictionary and invalidates the cached listings
+func (s *skillService) RemoveSkill(id int) error {
+	return s.cache.invalidateOnSuccess(s.SkillService.RemoveSkill(id))
+}
+
+// SetJobSkills replaces the skills tagged on a job and invalidates the cached listings
+func (s *skillService) SetJobSkills(ctx context.Context, jobID int, skills []domain.JobSkill) ([]domain.JobSkill, error) {
+	tagged, err := s.SkillService.SetJobSkills(ctx, jobID, skills)
+	return tagged, s.cache.invalidateOnSuccess(err)
+}
+
+// RemoveJobSkill removes a skill from a job and invalidates the cached listings
+func (s *skillService) RemoveJobSkill(ctx context.Context, jobID int, name string) error {
+	return s.cache.invalidateOnSuccess(s.SkillService.RemoveJobSkill(ctx, jobID, name))
+}
@@ -0,0 +1,186 @@
+package jobcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/poolcamacho/jobs-service/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchJobs_ServedFromCache(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+	filter := domain.JobFilter{Department: "Engineering"}
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", filter).Return([]*domain.Job{{ID: 1, Title: "Developer"}}, nil).Once()
+
+	// Execute
+	first, err := jobCache.SearchJobs(filter)
+	assert.NoError(t, err)
+	second, err := jobCache.SearchJobs(filter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, "Developer", second[0].Title)
+	mockJobService.AssertNumberOfCalls(t, "SearchJobs", 1)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, HitRatio: 0.5}, jobCache.Stats())
+}
+
+func TestSearchJobs_KeyedByFilter(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", mock.Anything).Return([]*domain.Job{}, nil)
+
+	// Execute
+	jobCache.SearchJobs(domain.JobFilter{Skills: []string{"Go", "SQL"}})
+	jobCache.SearchJobs(domain.JobFilter{Skills: []string{"SQL", "Go"}})
+	jobCache.SearchJobs(domain.JobFilter{Skills: []string{"Go", "SQL"}, SkillMatch: domain.SkillMatchAll})
+
+	// Assertions
+	mockJobService.AssertNumberOfCalls(t, "SearchJobs", 2)
+}
+
+func TestSearchJobs_ErrorsNotCached(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", mock.Anything).Return(nil, errors.New("database error")).Once()
+	mockJobService.On("SearchJobs", mock.Anything).Return([]*domain.Job{{ID: 1}}, nil).Once()
+
+	// Execute
+	_, err := jobCache.SearchJobs(domain.JobFilter{})
+	assert.Error(t, err)
+	jobs, err := jobCache.SearchJobs(domain.JobFilter{})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+func TestAddJob_InvalidatesListings(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+	ctx := context.Background()
+
+	// Mock behavior
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1}}, nil).Once()
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1}, {ID: 2}}, nil).Once()
+	mockJobService.On("AddJob", ctx, mock.Anything).Return(nil)
+
+	// Execute
+	before, _ := jobCache.GetAllJobs()
+	assert.NoError(t, jobCache.AddJob(ctx, &domain.Job{Title: "Designer"}))
+	after, _ := jobCache.GetAllJobs()
+
+	// Assertions
+	assert.Len(t, before, 1)
+	assert.Len(t, after, 2)
+	assert.Equal(t, int64(1), jobCache.Stats().Invalidations)
+}
+
+func TestUpdateJob_FailureKeepsListings(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+	ctx := context.Background()
+
+	// Mock behavior
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1}}, nil).Once()
+	mockJobService.On("UpdateJob", ctx, mock.Anything).Return(domain.ErrJobNotFound)
+
+	// Execute
+	jobCache.GetAllJobs()
+	err := jobCache.UpdateJob(ctx, &domain.Job{ID: 9})
+	jobCache.GetAllJobs()
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	mockJobService.AssertNumberOfCalls(t, "GetAllJobs", 1)
+}
+
+func TestSetJobSkills_InvalidatesListings(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	mockSkillService := new(service.MockSkillService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+	skillService := jobCache.WrapSkillService(mockSkillService)
+	filter := domain.JobFilter{Skills: []string{"Go"}}
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", filter).Return([]*domain.Job{}, nil).Twice()
+	mockSkillService.On("SetJobSkills", mock.Anything, 1, mock.Anything).Return([]domain.JobSkill{{Name: "Go"}}, nil)
+
+	// Execute
+	jobCache.SearchJobs(filter)
+	_, err := skillService.SetJobSkills(context.Background(), 1, []domain.JobSkill{{Name: "Go"}})
+	jobCache.SearchJobs(filter)
+
+	// Assertions
+	assert.NoError(t, err)
+	mockJobService.AssertNumberOfCalls(t, "SearchJobs", 2)
+}
+
+func TestSearchJobs_CollapsesConcurrentMisses(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10))
+	release := make(chan struct{})
+
+	// Mock behavior: the first load blocks until every caller has missed
+	mockJobService.On("SearchJobs", mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return([]*domain.Job{{ID: 1}}, nil)
+
+	// Execute
+	const callers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jobs, err := jobCache.SearchJobs(domain.JobFilter{Status: domain.JobStatusPublished})
+			assert.NoError(t, err)
+			assert.Len(t, jobs, 1)
+		}()
+	}
+	assert.Eventually(t, func() bool { return jobCache.Stats().Misses == callers }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assertions
+	mockJobService.AssertNumberOfCalls(t, "SearchJobs", 1)
+	assert.Equal(t, int64(callers-1), jobCache.Stats().Shared)
+}
+
+func TestSearchJobs_ExpiresAfterTTL(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobCache := NewJobCache(mockJobService, cache.NewLRU(10), WithTTL(time.Nanosecond))
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", mock.Anything).Return([]*domain.Job{}, nil)
+
+	// Execute
+	jobCache.SearchJobs(domain.JobFilter{})
+	time.Sleep(time.Millisecond)
+	jobCache.SearchJobs(domain.JobFilter{})
+
+	// Assertions
+	mockJobService.AssertNumberOfCalls(t, "SearchJobs", 2)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// schemaEmploymentTypes maps employment type spellings to the schema.org employmentType values
+var schemaEmploymentTypes = map[string]string{
+	"FULL_TIME":  "FULL_TIME",
+	"FULLTIME":   "FULL_TIME",
+	"PART_TIME":  "PART_TIME",
+	"PARTTIME":   "PART_TIME",
+	"CONTRACT":   "CONTRACTOR",
+	"CONTRACTOR": "CONTRACTOR",
+	"FREELANCE":  "CONTRACTOR",
+	"TEMPORARY":  "TEMPORARY",
+	"TEMP":       "TEMPORARY",
+	"INTERN":     "INTERN",
+	"INTERNSHIP": "INTERN",
+	"VOLUNTEER":  "VOLUNTEER",
+	"PER_DIEM":   "PER_DIEM",
+}
+
+// salaryAmountPattern matches an amount such as 50000, 50,000, 1.5k or 2M
+var salaryAmountPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*([kKmM])?\b`)
+
+// salaryCurrencyPattern matches an ISO 4217 currency code
+var salaryCurrencyPattern = regexp.MustCompile(`\b[A-Z]{3}\b`)
+
+// salaryCurrencySymbols maps currency symbols to their ISO 4217 code
+var salaryCurrencySymbols = []struct{ symbol, code string }{{"€", "EUR"}, {"£", "GBP"}, {"$", "USD"}}
+
+// thousandsPattern matches numbers grouped in thousands with commas or dots
+var thousandsPattern = regexp.MustCompile(`^\d{1,3}([.,])\d{3}(?:[.,]\d{3})*$`)
+
+// NewJobPosting renders a job as a schema.org JobPosting
+// Fields the job cannot fill, such as an unparseable salary, are left out.
+// @param job *domain.Job - The job to render
+// @param profile domain.JobPostingProfile - The employer details added to the posting
+// @return *domain.JobPosting - The JSON-LD document
+func NewJobPosting(job *domain.Job, profile domain.JobPostingProfile) *domain.JobPosting {
+	posting := &domain.JobPosting{
+		Context:        "https://schema.org/",
+		Type:           "JobPosting",
+		Title:          job.Title,
+		Description:    job.Description,
+		DatePosted:     job.PostedAt().UTC().Format(time.RFC3339),
+		EmploymentType: schemaEmploymentType(job.EmploymentType),
+		BaseSalary:     parseSalaryRange(job.SalaryRange, profile.Currency),
+		Department:     job.Department,
+	}
+	// A closed job without an expiry stopped accepting applications when it was last updated
+	if job.ExpiresAt != nil {
+		posting.ValidThrough = job.ExpiresAt.UTC().Format(time.RFC3339)
+	} else if job.Status == domain.JobStatusClosed {
+		posting.ValidThrough = job.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	if profile.OrganizationName != "" {
+		posting.HiringOrganization = &domain.Organization{
+			Type:   "Organization",
+			Name:   profile.OrganizationName,
+			SameAs: profile.OrganizationURL,
+			Logo:   profile.OrganizationLogo,
+		}
+		posting.Identifier = &domain.PropertyValue{
+			Type:  "PropertyValue",
+			Name:  profile.OrganizationName,
+			Value: strconv.Itoa(job.ID),
+		}
+	}
+	if profile.Locality != "" || profile.Region != "" || profile.Country != "" {
+		posting.JobLocation = &domain.Place{
+			Type: "Place",
+			Address: &domain.PostalAddress{
+				Type:            "PostalAddress",
+				AddressLocality: profile.Locality,
+				AddressRegion:   profile.Region,
+				AddressCountry:  profile.Country,
+			},
+		}
+	}
+	if len(job.Skills) > 0 {
+		names := make([]string, len(job.Skills))
+		for i, skill := range job.Skills {
+			names[i] = skill.Name
+		}
+		posting.Skills = strings.Join(names, ", ")
+	}
+	return posting
+}
+
+// schemaEmploymentType returns the schema.org employmentType of an employment type, OTHER if unknown
+func schemaEmploymentType(employmentType string) string {
+	if employmentType == "" {
+		return ""
+	}
+	key := strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(strings.TrimSpace(employmentType)))
+	if value, ok := schemaEmploymentTypes[key]; ok {
+		return value
+	}
+	return "OTHER"
+}
+
+// parseSalaryRange reads a free text salary range such as "$80k - $100k per year" or "3000 EUR/month"
+// Returns nil when no amount or currency can be found.
+// @param salaryRange string - The salary range of the job
+// @param defaultCurrency string - The currency used when the text names none
+// @return *domain.MonetaryAmount - The parsed salary
+func parseSalaryRange(salaryRange, defaultCurrency string) *domain.MonetaryAmount {
+	var amounts []float64
+	for _, match := range salaryAmountPattern.FindAllStringSubmatch(salaryRange, -1) {
+		amount, ok := parseSalaryAmount(match[1], match[2])
+		if ok {
+			amounts = append(amounts, amount)
+		}
+	}
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	currency := salaryCurrencyPattern.FindString(salaryRange)
+	if currency == "" {
+		for _, known := range salaryCurrencySymbols {
+			if strings.Contains(salaryRange, known.symbol) {
+				currency = known.code
+				break
+			}
+		}
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	if currency == "" {
+		return nil
+	}
+
+	value := &domain.QuantitativeValue{Type: "QuantitativeValue", UnitText: salaryUnit(salaryRange)}
+	if len(amounts) == 1 {
+		value.Value = &amounts[0]
+	} else {
+		bounds := amounts[:2]
+		sort.Float64s(bounds)
+		value.MinValue, value.MaxValue = &bounds[0], &bounds[1]
+	}
+	return &domain.MonetaryAmount{Type: "MonetaryAmount", Currency: currency, Value: value}
+}
+
+// parseSalaryAmount converts a matched amount and its k or M suffix to a number
+func parseSalaryAmount(number, suffix string) (float64, bool) {
+	if sep := thousandsPattern.FindStringSubmatch(number); sep != nil {
+		number = strings.ReplaceAll(number, sep[1], "")
+	} else {
+		number = strings.ReplaceAll(number, ",", ".")
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch strings.ToLower(suffix) {
+	case "k":
+		amount *= 1_000
+	case "m":
+		amount *= 1_000_000
+	}
+	return amount, true
+}
+
+// salaryUnit returns the schema.org unitText of a salary range, YEAR unless the text says otherwise
+func salaryUnit(salaryRange string) string {
+	text := strings.ToLower(salaryRange)
+	switch {
+	case strings.Contains(text, "hour") || strings.Contains(text, "/h"):
+		return "HOUR"
+	case strings.Contains(text, "day") || strings.Contains(text, "/d"):
+		return "DAY"
+	case strings.Contains(text, "week") || strings.Contains(text, "/w"):
+		return "WEEK"
+	case strings.Contains(text, "month") || strings.Contains(text, "/mo"):
+		return "MONTH"
+	default:
+		return "YEAR"
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobPosting(t *testing.T) {
+	// Test data
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	closed := time.Date(2024, 4, 15, 17, 0, 0, 0, time.UTC)
+	job := &domain.Job{ID: 42, Title: "Go Developer", Description: "APIs", SalaryRange: "$80k - $100k",
+		EmploymentType: "full-time", Department: "Engineering", Status: domain.JobStatusClosed,
+		Skills:    []domain.JobSkill{{Name: "Go"}, {Name: "MySQL"}},
+		CreatedAt: created, UpdatedAt: closed}
+	profile := domain.JobPostingProfile{OrganizationName: "Acme", OrganizationURL: "https://acme.example",
+		Locality: "Madrid", Country: "ES", Currency: "EUR"}
+
+	// Execute
+	posting := NewJobPosting(job, profile)
+
+	// Assertions
+	assert.Equal(t, "https://schema.org/", posting.Context)
+	assert.Equal(t, "JobPosting", posting.Type)
+	assert.Equal(t, "2024-03-01T08:00:00Z", posting.DatePosted)
+	assert.Equal(t, "2024-04-15T17:00:00Z", posting.ValidThrough)
+	assert.Equal(t, "FULL_TIME", posting.EmploymentType)
+	assert.Equal(t, "Acme", posting.HiringOrganization.Name)
+	assert.Equal(t, "42", posting.Identifier.Value)
+	assert.Equal(t, "Madrid", posting.JobLocation.Address.AddressLocality)
+	assert.Equal(t, "Go, MySQL", posting.Skills)
+	assert.Equal(t, "USD", posting.BaseSalary.Currency)
+	assert.Equal(t, 80000.0, *posting.BaseSalary.Value.MinValue)
+	assert.Equal(t, 100000.0, *posting.BaseSalary.Value.MaxValue)
+}
+
+func TestNewJobPosting_WithoutProfile(t *testing.T) {
+	// Execute
+	posting := NewJobPosting(&domain.Job{ID: 1, Title: "QA", SalaryRange: "competitive",
+		Status: domain.JobStatusPublished}, domain.JobPostingProfile{})
+
+	// Assertions
+	assert.Nil(t, posting.HiringOrganization)
+	assert.Nil(t, posting.Identifier)
+	assert.Nil(t, posting.JobLocation)
+	assert.Nil(t, posting.BaseSalary)
+	assert.Empty(t, posting.ValidThrough)
+	assert.Empty(t, posting.EmploymentType)
+}
+
+func TestParseSalaryRange(t *testing.T) {
+	tests := []struct {
+		salaryRange string
+		currency    string
+		min, max    float64
+		value       float64
+		unit        string
+	}{
+		{salaryRange: "50000-70000", currency: "EUR", min: 50000, max: 70000, unit: "YEAR"},
+		{salaryRange: "70,000 - 50,000 MXN", currency: "MXN", min: 50000, max: 70000, unit: "YEAR"},
+		{salaryRange: "€3.000 per month", currency: "EUR", value: 3000, unit: "MONTH"},
+		{salaryRange: "£25/hour", currency: "GBP", value: 25, unit: "HOUR"},
+		{salaryRange: "1.5M USD", currency: "USD", value: 1500000, unit: "YEAR"},
+	}
+	for _, tt := range tests {
+		salary := parseSalaryRange(tt.salaryRange, "EUR")
+		if !assert.NotNil(t, salary, tt.salaryRange) {
+			continue
+		}
+		assert.Equal(t, tt.currency, salary.Currency, tt.salaryRange)
+		assert.Equal(t, tt.unit, salary.Value.UnitText, tt.salaryRange)
+		if tt.value != 0 {
+			assert.Equal(t, tt.value, *salary.Value.Value, tt.salaryRange)
+		} else {
+			assert.Equal(t, tt.min, *salary.Value.MinValue, tt.salaryRange)
+			assert.Equal(t, tt.max, *salary.Value.MaxValue, tt.salaryRange)
+		}
+	}
+
+	// Without a currency in the text or a default there is no salary
+	assert.Nil(t, parseSalaryRange("50000", ""))
+}
+
+func TestSchemaEmploymentType(t *testing.T) {
+	assert.Equal(t, "PART_TIME", schemaEmploymentType("Part time"))
+	assert.Equal(t, "CONTRACTOR", schemaEmploymentType("contract"))
+	assert.Equal(t, "INTERN", schemaEmploymentType("internship"))
+	assert.Equal(t, "OTHER", schemaEmploymentType("seasonal"))
+}
@@ -0,0 +1,242 @@
+package matching
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// SkillsFactor scores the share of the job's skills the candidate has
+// Required skills count twice as much as nice-to-have ones.
+type SkillsFactor struct{}
+
+// Name identifies the factor in the score breakdown
+func (SkillsFactor) Name() string { return "skills" }
+
+// Score returns the weighted share of the job's skills covered by the candidate
+func (SkillsFactor) Score(profile domain.CandidateProfile, job *domain.Job) (float64, string, bool) {
+	if len(job.Skills) == 0 || len(profile.Skills) == 0 {
+		return 0, "", false
+	}
+	has := make(map[string]bool, len(profile.Skills))
+	for _, skill := range profile.Skills {
+		has[domain.NormalizeSkillKey(skill)] = true
+	}
+
+	var covered, total float64
+	var missing []string
+	for _, skill := range job.Skills {
+		weight := 1.0
+		if skill.Requirement == domain.SkillRequired {
+			weight = 2.0
+		}
+		total += weight
+		if has[domain.NormalizeSkillKey(skill.Name)] {
+			covered += weight
+		} else if skill.Requirement == domain.SkillRequired {
+			missing = append(missing, skill.Name)
+		}
+	}
+	detail := fmt.Sprintf("has %.0f%% of the weighted job skills", 100*covered/total)
+	if len(missing) > 0 {
+		detail += "; missing required: " + strings.Join(missing, ", ")
+	}
+	return covered / total, detail, true
+}
+
+// TermOverlapFactor scores how many of the candidate's skills and keywords appear in the job text
+type TermOverlapFactor struct{}
+
+// Name identifies the factor in the score breakdown
+func (TermOverlapFactor) Name() string { return "term_overlap" }
+
+// Score returns the share of candidate terms found in the job title or description
+// A term found in the title counts fully; one only found in the description counts half.
+func (TermOverlapFactor) Score(profile domain.CandidateProfile, job *domain.Job) (float64, string, bool) {
+	terms := uniqueTerms(append(append([]string{}, profile.Skills...), profile.Keywords...))
+	if len(terms) == 0 {
+		return 0, "", false
+	}
+	title := tokenSet(job.Title)
+	description := tokenSet(job.Description)
+
+	var score float64
+	var found []string
+	for _, term := range terms {
+		switch {
+		case containsAll(title, term):
+			score += 1
+			found = append(found, term)
+		case containsAll(description, term):
+			score += 0.5
+			found = append(found, term)
+		}
+	}
+	detail := fmt.Sprintf("%d of %d terms found", len(found), len(terms))
+	if len(found) > 0 {
+		detail += ": " + strings.Join(found, ", ")
+	}
+	return score / float64(len(terms)), detail, true
+}
+
+// SalaryFactor scores whether the job's salary range reaches the candidate's expectation
+type SalaryFactor struct{}
+
+// Name identifies the factor in the score breakdown
+func (SalaryFactor) Name() string { return "salary" }
+
+// Score returns 1 when the range reaches the desired salary, decreasing proportionally below it
+func (SalaryFactor) Score(profile domain.CandidateProfile, job *domain.Job) (float64, string, bool) {
+	if profile.DesiredSalary <= 0 {
+		return 0, "", false
+	}
+	low, high, ok := ParseSalaryRange(job.SalaryRange)
+	if !ok {
+		return 0, "", false
+	}
+	desired := float64(profile.DesiredSalary)
+	switch {
+	case desired <= low:
+		return 1, fmt.Sprintf("range %s starts above the desired %d", job.SalaryRange, profile.DesiredSalary), true
+	case desired <= high:
+		return 1, fmt.Sprintf("desired %d is within range %s", profile.DesiredSalary, job.SalaryRange), true
+	default:
+		return high / desired, fmt.Sprintf("range %s tops out below the desired %d", job.SalaryRange, profile.DesiredSalary), true
+	}
+}
+
+// seniorityYears maps the default seniority levels to their expected years of experience
+var seniorityYears = map[string][2]int{
+	"junior": {0, 2},
+	"mid":    {2, 5},
+	"senior": {5, 10},
+	"lead":   {8, 40},
+}
+
+// SeniorityFactor scores the candidate's experience against the job's seniority level
+type SeniorityFactor struct{}
+
+// Name identifies the factor in the score breakdown
+func (SeniorityFactor) Name() string { return "seniority" }
+
+// Score returns 1 inside the level's experience band, losing 0.25 per year outside it
+func (SeniorityFactor) Score(profile domain.CandidateProfile, job *domain.Job) (float64, string, bool) {
+	band, known := seniorityYears[job.Seniority]
+	if profile.ExperienceYears == nil || !known {
+		return 0, "", false
+	}
+	years := *profile.ExperienceYears
+	var gap int
+	switch {
+	case years < band[0]:
+		gap = band[0] - years
+	case years > band[1]:
+		gap = years - band[1]
+	}
+	detail := fmt.Sprintf("%d years of experience for a %s role (%d-%d expected)", years, job.Seniority, band[0], band[1])
+	return 1 - 0.25*float64(gap), detail, true
+}
+
+// LocationFactor scores whether the job mentions the candidate's preferred location
+// Jobs have no structured location yet, so the title and description are searched.
+type LocationFactor struct{}
+
+// Name identifies the factor in the score breakdown
+func (LocationFactor) Name() string { return "location" }
+
+// Score returns 1 when the preferred location is mentioned by the job, 0 otherwise
+func (LocationFactor) Score(profile domain.CandidateProfile, job *domain.Job) (float64, string, bool) {
+	location := strings.TrimSpace(profile.Location)
+	if location == "" {
+		return 0, "", false
+	}
+	text := tokenSet(job.Title + " " + job.Description)
+	if containsAll(text, location) {
+		return 1, "job mentions " + location, true
+	}
+	return 0, "job does not mention " + location, true
+}
+
+// salaryNumber matches amounts such as "4000", "4,500", "60K" or "1.2M"
+var salaryNumber = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*([km]?)`)
+
+// ParseSalaryRange extracts the bounds of a free-text salary range such as "4000-6000" or "60K-80K"
+// A single amount is treated as both bounds.
+// @param salaryRange string - The salary range as stored on the job
+// @return low, high float64 - The bounds of the range
+// @return ok bool - False when no amount could be found
+func ParseSalaryRange(salaryRange string) (low, high float64, ok bool) {
+	matches := salaryNumber.FindAllStringSubmatch(salaryRange, 2)
+	if len(matches) == 0 {
+		return 0, 0, false
+	}
+	var amounts []float64
+	for _, m := range matches {
+		raw := m[1]
+		// "4,500" is a thousands separator, "1.2" or "1,2" a decimal one
+		if strings.Contains(raw, ",") && len(raw)-strings.Index(raw, ",") == 4 {
+			raw = strings.Replace(raw, ",", "", 1)
+		}
+		value, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		switch strings.ToLower(m[2]) {
+		case "k":
+			value *= 1000
+		case "m":
+			value *= 1000000
+		}
+		amounts = append(amounts, value)
+	}
+	low, high = amounts[0], amounts[len(amounts)-1]
+	if low > high {
+		low, high = high, low
+	}
+	return low, high, true
+}
+
+// tokenPattern splits text into lower-cased words, keeping symbols common in tech names (c++, c#, node.js)
+var tokenPattern = regexp.MustCompile(`[a-z0-9+#.]+`)
+
+// tokenSet returns the set of words in a text
+func tokenSet(text string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, token := range tokenPattern.FindAllString(strings.ToLower(text), -1) {
+		tokens[strings.TrimRight(token, ".")] = true
+	}
+	return tokens
+}
+
+// containsAll reports whether every word of term is in tokens, so multi-word terms match as a whole
+func containsAll(tokens map[string]bool, term string) bool {
+	words := tokenSet(term)
+	if len(words) == 0 {
+		return false
+	}
+	for word := range words {
+		if !tokens[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueTerms trims terms and drops empty and repeated ones, ignoring case
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var unique []string
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		key := strings.ToLower(term)
+		if term == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, term)
+	}
+	return unique
+}
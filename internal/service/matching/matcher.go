@@ -0,0 +1,98 @@
+package matching
+
+import (
+	"math"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// Matcher scores how well a job fits a candidate
+// Implementations must be safe for concurrent use.
+type Matcher interface {
+	// Match scores a job against a candidate profile
+	// @param profile domain.CandidateProfile - The candidate, with skills already in canonical form
+	// @param job *domain.Job - The job to score
+	// @return domain.JobMatch - The overall score and its per-factor breakdown
+	Match(profile domain.CandidateProfile, job *domain.Job) domain.JobMatch
+}
+
+// Factor scores a single aspect of the fit between a candidate and a job
+type Factor interface {
+	// Name identifies the factor in the score breakdown
+	Name() string
+
+	// Score returns a value between 0 and 1 with an explanation
+	// ok is false when the factor cannot be evaluated (e.g., the job has no salary range),
+	// in which case the factor is left out instead of counting as a zero.
+	Score(profile domain.CandidateProfile, job *domain.Job) (score float64, detail string, ok bool)
+}
+
+// WeightedFactor pairs a factor with its relative weight
+type WeightedFactor struct {
+	Factor Factor  // The factor to evaluate
+	Weight float64 // Relative weight; weights do not need to add up to 1
+}
+
+// WeightedMatcher combines factors into a weighted average
+// Factors that cannot be evaluated for a job are excluded from both the sum and the total weight.
+type WeightedMatcher struct {
+	factors []WeightedFactor
+}
+
+// NewWeightedMatcher creates a Matcher from weighted factors
+// @param factors ...WeightedFactor - The factors to evaluate
+// @return *WeightedMatcher - The matcher
+func NewWeightedMatcher(factors ...WeightedFactor) *WeightedMatcher {
+	return &WeightedMatcher{factors: factors}
+}
+
+// NewDefaultMatcher creates the Matcher used by the recommendation endpoint
+// Skills weigh the most, followed by free-text term overlap, salary, seniority and location.
+// @return Matcher - The matcher
+func NewDefaultMatcher() Matcher {
+	return NewWeightedMatcher(
+		WeightedFactor{Factor: SkillsFactor{}, Weight: 0.35},
+		WeightedFactor{Factor: TermOverlapFactor{}, Weight: 0.25},
+		WeightedFactor{Factor: SalaryFactor{}, Weight: 0.15},
+		WeightedFactor{Factor: SeniorityFactor{}, Weight: 0.15},
+		WeightedFactor{Factor: LocationFactor{}, Weight: 0.10},
+	)
+}
+
+// Match scores a job against a candidate profile
+// @param profile domain.CandidateProfile - The candidate, with skills already in canonical form
+// @param job *domain.Job - The job to score
+// @return domain.JobMatch - The overall score and its per-factor breakdown
+func (m *WeightedMatcher) Match(profile domain.CandidateProfile, job *domain.Job) domain.JobMatch {
+	match := domain.JobMatch{Job: job, Factors: []domain.MatchFactor{}}
+	var total, weights float64
+	for _, wf := range m.factors {
+		score, detail, ok := wf.Factor.Score(profile, job)
+		if !ok || wf.Weight <= 0 {
+			continue
+		}
+		score = clamp(score)
+		total += score * wf.Weight
+		weights += wf.Weight
+		match.Factors = append(match.Factors, domain.MatchFactor{
+			Name:   wf.Factor.Name(),
+			Weight: wf.Weight,
+			Score:  round(score),
+			Detail: detail,
+		})
+	}
+	if weights > 0 {
+		match.Score = round(total / weights)
+	}
+	return match
+}
+
+// clamp bounds a score to [0, 1]
+func clamp(score float64) float64 {
+	return math.Max(0, math.Min(1, score))
+}
+
+// round keeps three decimals so scores read well in JSON
+func round(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
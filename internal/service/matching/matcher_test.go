@@ -0,0 +1,89 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMatcher_Breakdown(t *testing.T) {
+	// Setup
+	matcher := NewDefaultMatcher()
+	years := 6
+	profile := domain.CandidateProfile{
+		Skills:          []string{"Go", "Docker"},
+		ExperienceYears: &years,
+		Location:        "remote",
+		DesiredSalary:   5000,
+	}
+	job := &domain.Job{
+		Title:       "Senior Go Engineer",
+		Description: "Fully remote team building APIs with Docker.",
+		SalaryRange: "4000-6000",
+		Seniority:   "senior",
+		Skills: []domain.JobSkill{
+			{Name: "Go", Requirement: domain.SkillRequired},
+			{Name: "Kubernetes", Requirement: domain.SkillNiceToHave},
+		},
+	}
+
+	// Execute
+	match := matcher.Match(profile, job)
+
+	// Assertions
+	scores := make(map[string]float64)
+	for _, factor := range match.Factors {
+		scores[factor.Name] = factor.Score
+	}
+	assert.Equal(t, map[string]float64{
+		"skills":       0.667, // Go (required, 2) of Go + Kubernetes (nice to have, 1)
+		"term_overlap": 0.75,  // Go in the title, Docker only in the description
+		"salary":       1,
+		"seniority":    1,
+		"location":     1,
+	}, scores)
+	assert.InDelta(t, (0.35*0.667+0.25*0.75+0.15+0.15+0.10)/1.0, match.Score, 0.001)
+}
+
+func TestDefaultMatcher_SkipsFactorsThatCannotBeEvaluated(t *testing.T) {
+	// Setup
+	matcher := NewDefaultMatcher()
+	profile := domain.CandidateProfile{Keywords: []string{"product"}}
+	job := &domain.Job{Title: "Product Manager", Description: "Own the roadmap."}
+
+	// Execute
+	match := matcher.Match(profile, job)
+
+	// Assertions: only the term overlap factor applies, so it alone decides the score
+	assert.Len(t, match.Factors, 1)
+	assert.Equal(t, "term_overlap", match.Factors[0].Name)
+	assert.Equal(t, 1.0, match.Score)
+}
+
+func TestSalaryFactor_BelowExpectation(t *testing.T) {
+	// Execute
+	score, _, ok := SalaryFactor{}.Score(domain.CandidateProfile{DesiredSalary: 100000}, &domain.Job{SalaryRange: "60K-80K"})
+
+	// Assertions
+	assert.True(t, ok)
+	assert.InDelta(t, 0.8, score, 0.0001)
+}
+
+func TestParseSalaryRange(t *testing.T) {
+	cases := map[string][2]float64{
+		"4000-6000":          {4000, 6000},
+		"60K-80K":            {60000, 80000},
+		"$4,500 - $6,000":    {4500, 6000},
+		"1.2M":               {1200000, 1200000},
+		"up to 90k per year": {90000, 90000},
+	}
+	for input, expected := range cases {
+		low, high, ok := ParseSalaryRange(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, expected, [2]float64{low, high}, input)
+	}
+
+	_, _, ok := ParseSalaryRange("competitive")
+	assert.False(t, ok)
+}
@@ -0,0 +1,100 @@
+package matching
+
+import (
+	"sort"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// DefaultLimit and MaxLimit bound the number of recommended jobs
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Recommender defines methods for recommending jobs to candidates
+// This interface abstracts ranking the job listing with a Matcher.
+type Recommender interface {
+	// Recommend ranks the jobs by how well they fit a candidate
+	// @param profile domain.CandidateProfile - The candidate
+	// @param limit int - The maximum number of jobs to return (DefaultLimit when 0, capped at MaxLimit)
+	// @return []domain.JobMatch - The best matching jobs, highest score first
+	// @return error - An error if the jobs cannot be retrieved
+	Recommend(profile domain.CandidateProfile, limit int) ([]domain.JobMatch, error)
+}
+
+type recommenderImpl struct {
+	jobs    repository.JobRepository   // Source of the jobs to rank
+	skills  repository.SkillRepository // Resolves candidate skill aliases
+	matcher Matcher                    // Scores each job
+	now     func() time.Time           // Clock telling live jobs apart, replaced in tests
+}
+
+// NewRecommender creates a new Recommender instance
+// @param jobs repository.JobRepository - The repository holding the jobs to rank
+// @param skills repository.SkillRepository - The skills dictionary used to resolve aliases
+// @param matcher Matcher - The matcher scoring each job
+// @return Recommender - The implementation of the service interface
+func NewRecommender(jobs repository.JobRepository, skills repository.SkillRepository, matcher Matcher) Recommender {
+	return &recommenderImpl{jobs: jobs, skills: skills, matcher: matcher, now: time.Now}
+}
+
+// Recommend ranks the jobs by how well they fit a candidate
+// Candidate skills are resolved to canonical names first so "golang" matches a job tagged "Go".
+// Only live jobs are ranked: drafts, closed jobs and jobs scheduled for later are never shown to candidates.
+// Jobs scoring zero are left out; ties keep the newest job first.
+// @param profile domain.CandidateProfile - The candidate
+// @param limit int - The maximum number of jobs to return (DefaultLimit when 0, capped at MaxLimit)
+// @return []domain.JobMatch - The best matching jobs, highest score first
+// @return error - An error if the jobs cannot be retrieved
+func (r *recommenderImpl) Recommend(profile domain.CandidateProfile, limit int) ([]domain.JobMatch, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	if len(profile.Skills) > 0 {
+		resolved, err := r.skills.Resolve(profile.Skills)
+		if err != nil {
+			return nil, err
+		}
+		canonical := make([]string, 0, len(profile.Skills))
+		for _, name := range profile.Skills {
+			if skill, ok := resolved[domain.NormalizeSkillKey(name)]; ok {
+				name = skill.Name
+			}
+			canonical = append(canonical, name)
+		}
+		profile.Skills = canonical
+	}
+
+	jobs, err := r.jobs.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now()
+	matches := make([]domain.JobMatch, 0, len(jobs))
+	for _, job := range jobs {
+		if !job.IsLive(now) {
+			continue
+		}
+		if match := r.matcher.Match(profile, job); match.Score > 0 {
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Job.CreatedAt.After(matches[j].Job.CreatedAt)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
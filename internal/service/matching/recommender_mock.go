@@ -0,0 +1,20 @@
+package matching
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRecommender is a mock implementation of Recommender for testing
+type MockRecommender struct {
+	mock.Mock
+}
+
+// Recommend mocks the Recommend method
+func (m *MockRecommender) Recommend(profile domain.CandidateProfile, limit int) ([]domain.JobMatch, error) {
+	args := m.Called(profile, limit)
+	if matches, ok := args.Get(0).([]domain.JobMatch); ok {
+		return matches, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,78 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommend_RanksAndResolvesAliases(t *testing.T) {
+	// Setup
+	mockJobs := new(repository.MockJobRepository)
+	mockSkills := new(repository.MockSkillRepository)
+	recommender := NewRecommender(mockJobs, mockSkills, NewDefaultMatcher())
+
+	// Mock data
+	now := time.Now()
+	goJob := &domain.Job{ID: 1, Title: "Go Developer", Status: domain.JobStatusPublished, CreatedAt: now,
+		Skills: []domain.JobSkill{{Name: "Go", Requirement: domain.SkillRequired}}}
+	javaJob := &domain.Job{ID: 2, Title: "Java Developer", Status: domain.JobStatusPublished, CreatedAt: now,
+		Skills: []domain.JobSkill{{Name: "Java", Requirement: domain.SkillRequired}}}
+	olderGoJob := &domain.Job{ID: 3, Title: "Go Developer", Status: domain.JobStatusPublished, CreatedAt: now.Add(-time.Hour),
+		Skills: []domain.JobSkill{{Name: "Go", Requirement: domain.SkillRequired}}}
+
+	// Mock behavior
+	mockSkills.On("Resolve", []string{"golang"}).Return(map[string]*domain.Skill{"golang": {ID: 1, Name: "Go"}}, nil)
+	mockJobs.On("FindAll").Return([]*domain.Job{javaJob, olderGoJob, goJob}, nil)
+
+	// Execute
+	matches, err := recommender.Recommend(domain.CandidateProfile{Skills: []string{"golang"}}, 0)
+
+	// Assertions: the Java job scores zero and is left out; ties keep the newest first
+	assert.NoError(t, err)
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, 1, matches[0].Job.ID)
+		assert.Equal(t, 3, matches[1].Job.ID)
+	}
+	mockSkills.AssertExpectations(t)
+	mockJobs.AssertExpectations(t)
+}
+
+func TestRecommend_OnlyLiveJobs(t *testing.T) {
+	// Setup
+	mockJobs := new(repository.MockJobRepository)
+	mockSkills := new(repository.MockSkillRepository)
+	recommender := NewRecommender(mockJobs, mockSkills, NewDefaultMatcher()).(*recommenderImpl)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recommender.now = func() time.Time { return now }
+
+	// Mock data: every job needs Go, but only the first is open to candidates
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+	goJob := func(id int, status string, publishAt, expiresAt *time.Time) *domain.Job {
+		return &domain.Job{ID: id, Title: "Go Developer", Status: status, PublishAt: publishAt, ExpiresAt: expiresAt,
+			Skills: []domain.JobSkill{{Name: "Go", Requirement: domain.SkillRequired}}}
+	}
+	jobs := []*domain.Job{
+		goJob(1, domain.JobStatusPublished, &earlier, &later),
+		goJob(2, domain.JobStatusDraft, nil, nil),
+		goJob(3, domain.JobStatusClosed, nil, nil),
+		goJob(4, domain.JobStatusPublished, &later, nil),   // Scheduled for later
+		goJob(5, domain.JobStatusPublished, nil, &earlier), // Expired, not closed by the scheduler yet
+	}
+
+	// Mock behavior
+	mockSkills.On("Resolve", []string{"Go"}).Return(map[string]*domain.Skill{}, nil)
+	mockJobs.On("FindAll").Return(jobs, nil)
+
+	// Execute
+	matches, err := recommender.Recommend(domain.CandidateProfile{Skills: []string{"Go"}}, 0)
+
+	// Assertions
+	assert.NoError(t, err)
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, 1, matches[0].Job.ID)
+	}
+}
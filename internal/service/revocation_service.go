@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+)
+
+// maxRevocationIDLength bounds the jti and subject of a revocation to the size of their columns
+const maxRevocationIDLength = 255
+
+// RevocationService defines methods for revoking tokens before their expiry
+type RevocationService interface {
+	RevokeToken(ctx context.Context, req domain.TokenRevocationRequest) (*domain.TokenRevocation, error)       // Revokes a token by jti
+	RevokeSubject(ctx context.Context, req domain.SubjectRevocationRequest) (*domain.SubjectRevocation, error) // Revokes the tokens of a subject
+}
+
+// RevocationOption configures the revocation service
+type RevocationOption func(*revocationServiceImpl)
+
+// WithRefreshTokens also revokes the refresh tokens of the local users whose tokens are revoked
+// Local users have their numeric ID as subject; other subjects have no refresh tokens here.
+// @param refreshTokens repository.RefreshTokenRepository - The refresh tokens issued by /auth/login (default: none)
+// @return RevocationOption - The option to pass to NewRevocationService
+func WithRefreshTokens(refreshTokens repository.RefreshTokenRepository) RevocationOption {
+	return func(s *revocationServiceImpl) {
+		s.refreshTokens = refreshTokens
+	}
+}
+
+type revocationServiceImpl struct {
+	store         revocation.Store                  // Dependency on the revocation store checked by the auth middleware
+	refreshTokens repository.RefreshTokenRepository // Refresh tokens of the local users, nil if none
+	tokenLifetime time.Duration                     // Longest lifetime of the accepted tokens, bounding how long revocations are kept
+	now           func() time.Time                  // Clock, replaced in tests
+}
+
+// NewRevocationService creates a new RevocationService instance
+// @param store revocation.Store - The store checked by the auth middleware
+// @param tokenLifetime time.Duration - Longest lifetime of the accepted tokens; revocations are kept that long
+// @param opts ...RevocationOption - Optional refresh token repository
+// @return RevocationService - The implementation of the service interface
+func NewRevocationService(store revocation.Store, tokenLifetime time.Duration, opts ...RevocationOption) RevocationService {
+	s := &revocationServiceImpl{store: store, tokenLifetime: tokenLifetime, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// RevokeToken revokes a single token until it expires
+// @param ctx context.Context - Carries the admin revoking the token
+// @param req domain.TokenRevocationRequest - The jti of the token and, if known, its expiry
+// @return *domain.TokenRevocation - The stored revocation
+// @return error - A *domain.ValidationError for an invalid jti or expiry, or an error if the store fails
+func (s *revocationServiceImpl) RevokeToken(ctx context.Context, req domain.TokenRevocationRequest) (*domain.TokenRevocation, error) {
+	jti := strings.TrimSpace(req.JTI)
+	if jti == "" || len(jti) > maxRevocationIDLength {
+		return nil, &domain.ValidationError{Field: "jti", Message: "must be between 1 and 255 characters"}
+	}
+	now := s.now().UTC()
+	until := now.Add(s.tokenLifetime)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, &domain.ValidationError{Field: "expires_at", Message: "token has already expired"}
+		}
+		until = req.ExpiresAt.UTC()
+	}
+
+	if err := s.store.RevokeToken(jti, until); err != nil {
+		return nil, err
+	}
+	log.Printf("Token %s revoked by %s until %s", jti, domain.ActorFromContext(ctx).Subject, until.Format(time.RFC3339))
+	return &domain.TokenRevocation{JTI: jti, ExpiresAt: until}, nil
+}
+
+// RevokeSubject revokes the tokens of a subject issued at or before a cutoff time
+// The revocation is kept for the longest token lifetime after the cutoff, when the tokens it covers have expired.
+// For a local user, the refresh tokens of the logins made up to the cutoff are revoked too.
+// @param ctx context.Context - Carries the admin revoking the tokens
+// @param req domain.SubjectRevocationRequest - The subject and the cutoff time, now if not set
+// @return *domain.SubjectRevocation - The stored revocation
+// @return error - A *domain.ValidationError for an invalid subject or a cutoff in the future, or an error if the store fails
+func (s *revocationServiceImpl) RevokeSubject(ctx context.Context, req domain.SubjectRevocationRequest) (*domain.SubjectRevocation, error) {
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" || len(subject) > maxRevocationIDLength {
+		return nil, &domain.ValidationError{Field: "subject", Message: "must be between 1 and 255 characters"}
+	}
+	now := s.now().UTC()
+	before := now
+	if req.Before != nil {
+		// A cutoff in the future would also reject the tokens issued after the revocation, locking the subject out
+		if req.Before.After(now) {
+			return nil, &domain.ValidationError{Field: "before", Message: "must not be in the future"}
+		}
+		before = req.Before.UTC()
+	}
+	until := before.Add(s.tokenLifetime)
+	if !until.After(now) {
+		return nil, &domain.ValidationError{Field: "before", Message: "tokens issued before this time have already expired"}
+	}
+
+	if err := s.store.RevokeSubject(subject, before, until); err != nil {
+		return nil, err
+	}
+	if userID, err := strconv.Atoi(subject); err == nil && userID > 0 && s.refreshTokens != nil {
+		if err := s.refreshTokens.RevokeUser(userID, before, now); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("Tokens of %s issued before %s revoked by %s", subject, before.Format(time.RFC3339), domain.ActorFromContext(ctx).Subject)
+	return &domain.SubjectRevocation{Subject: subject, RevokedBefore: before, ExpiresAt: until}, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRevocationService is a mock implementation of RevocationService for testing
+type MockRevocationService struct {
+	mock.Mock
+}
+
+// RevokeToken mocks the RevokeToken method
+func (m *MockRevocationService) RevokeToken(ctx context.Context, req domain.TokenRevocationRequest) (*domain.TokenRevocation, error) {
+	args := m.Called(ctx, req)
+	if revoked, ok := args.Get(0).(*domain.TokenRevocation); ok {
+		return revoked, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RevokeSubject mocks the RevokeSubject method
+func (m *MockRevocationService) RevokeSubject(ctx context.Context, req domain.SubjectRevocationRequest) (*domain.SubjectRevocation, error) {
+	args := m.Called(ctx, req)
+	if revoked, ok := args.Get(0).(*domain.SubjectRevocation); ok {
+		return revoked, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
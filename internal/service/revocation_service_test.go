@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRevocationService returns a service backed by an in-memory store, with a clock fixed at now
+func newTestRevocationService(store revocation.Store, now time.Time) RevocationService {
+	return &revocationServiceImpl{store: store, tokenLifetime: 24 * time.Hour, now: func() time.Time { return now }}
+}
+
+func TestRevokeToken(t *testing.T) {
+	// Setup
+	store := revocation.NewMemoryStore()
+	now := time.Now().UTC()
+	revocationService := newTestRevocationService(store, now)
+	exp := now.Add(time.Hour)
+
+	// Execute
+	revoked, err := revocationService.RevokeToken(context.Background(), domain.TokenRevocationRequest{JTI: "tok-1", ExpiresAt: &exp})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, exp, revoked.ExpiresAt)
+	isRevoked, _ := store.IsRevoked("tok-1", "user-1", now.Add(-time.Minute), now)
+	assert.True(t, isRevoked)
+	isRevoked, _ = store.IsRevoked("tok-1", "user-1", now.Add(-time.Minute), exp.Add(time.Second))
+	assert.False(t, isRevoked, "forgotten once the token has expired")
+	isRevoked, _ = store.IsRevoked("tok-2", "user-1", now.Add(-time.Minute), now)
+	assert.False(t, isRevoked)
+}
+
+func TestRevokeToken_DefaultExpiry(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	revocationService := newTestRevocationService(revocation.NewMemoryStore(), now)
+
+	// Execute
+	revoked, err := revocationService.RevokeToken(context.Background(), domain.TokenRevocationRequest{JTI: "tok-1"})
+
+	// Assertions: kept for the longest token lifetime
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour), revoked.ExpiresAt)
+}
+
+func TestRevokeSubject(t *testing.T) {
+	// Setup
+	store := revocation.NewMemoryStore()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	revocationService := newTestRevocationService(store, now)
+
+	// Execute
+	revoked, err := revocationService.RevokeSubject(context.Background(), domain.SubjectRevocationRequest{Subject: "user-1"})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, now, revoked.RevokedBefore)
+	assert.Equal(t, now.Add(24*time.Hour), revoked.ExpiresAt)
+
+	tests := []struct {
+		name     string
+		subject  string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"issued before", "user-1", now.Add(-time.Hour), true},
+		{"issued in the same second", "user-1", now, true},
+		{"issued after", "user-1", now.Add(time.Second), false},
+		{"no issue time", "user-1", time.Time{}, true},
+		{"other subject", "user-2", now.Add(-time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isRevoked, err := store.IsRevoked("", tt.subject, tt.issuedAt, now.Add(time.Minute))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, isRevoked)
+		})
+	}
+}
+
+func TestRevokeSubject_RevokesRefreshTokens(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	refreshTokens := new(repository.MockRefreshTokenRepository)
+	revocationService := NewRevocationService(revocation.NewMemoryStore(), 24*time.Hour, WithRefreshTokens(refreshTokens)).(*revocationServiceImpl)
+	revocationService.now = func() time.Time { return now }
+	before := now.Add(-time.Hour)
+
+	// Mock behavior: only local users, identified by their numeric ID, have refresh tokens
+	refreshTokens.On("RevokeUser", 7, before, now).Return(nil)
+
+	// Execute
+	_, errLocal := revocationService.RevokeSubject(context.Background(), domain.SubjectRevocationRequest{Subject: "7", Before: &before})
+	_, errExternal := revocationService.RevokeSubject(context.Background(), domain.SubjectRevocationRequest{Subject: "user-1"})
+
+	// Assertions
+	assert.NoError(t, errLocal)
+	assert.NoError(t, errExternal)
+	refreshTokens.AssertExpectations(t)
+	refreshTokens.AssertNumberOfCalls(t, "RevokeUser", 1)
+}
+
+func TestRevocation_Invalid(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	revocationService := newTestRevocationService(revocation.NewMemoryStore(), now)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+	longAgo := now.Add(-48 * time.Hour)
+
+	// Execute
+	_, errEmptyJTI := revocationService.RevokeToken(context.Background(), domain.TokenRevocationRequest{JTI: " "})
+	_, errExpired := revocationService.RevokeToken(context.Background(), domain.TokenRevocationRequest{JTI: "tok-1", ExpiresAt: &past})
+	_, errFuture := revocationService.RevokeSubject(context.Background(), domain.SubjectRevocationRequest{Subject: "user-1", Before: &future})
+	_, errLongAgo := revocationService.RevokeSubject(context.Background(), domain.SubjectRevocationRequest{Subject: "user-1", Before: &longAgo})
+
+	// Assertions
+	for _, err := range []error{errEmptyJTI, errExpired, errFuture, errLongAgo} {
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	}
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// LeaseName is the lease electing the replica that runs the scheduler
+const LeaseName = "job-scheduler"
+
+// Actor is the subject recorded in the audit log for the scheduled status changes
+const Actor = "scheduler"
+
+// Defaults of the scheduler settings
+const (
+	DefaultInterval = 15 * time.Second
+	DefaultLeaseTTL = time.Minute
+)
+
+// Scheduler publishes and closes jobs when their publish_at and expires_at pass
+// Every replica runs one, but only the holder of the lease applies the schedule; the others
+// keep trying and take over once the holder stops renewing it.
+type Scheduler struct {
+	jobs   service.JobService         // Applies the status changes
+	leases repository.LeaseRepository // Elects the active replica
+
+	holder   string           // Identity of this replica in the lease
+	interval time.Duration    // Pause between runs
+	leaseTTL time.Duration    // How long the lease survives without renewal
+	now      func() time.Time // Clock, replaced in tests
+}
+
+// Option configures optional Scheduler settings
+type Option func(*Scheduler)
+
+// WithInterval sets the pause between runs
+// @param interval time.Duration - The interval; keep it well below the lease TTL
+// @return Option - The option to pass to NewScheduler
+func WithInterval(interval time.Duration) Option {
+	return func(s *Scheduler) {
+		s.interval = interval
+	}
+}
+
+// WithLeaseTTL sets how long the lease is held without renewal
+// @param ttl time.Duration - The lease TTL
+// @return Option - The option to pass to NewScheduler
+func WithLeaseTTL(ttl time.Duration) Option {
+	return func(s *Scheduler) {
+		s.leaseTTL = ttl
+	}
+}
+
+// WithHolder sets the identity of this replica in the lease
+// @param holder string - A name unique among the replicas
+// @return Option - The option to pass to NewScheduler
+func WithHolder(holder string) Option {
+	return func(s *Scheduler) {
+		s.holder = holder
+	}
+}
+
+// WithClock replaces the clock deciding which jobs are due
+// @param now func() time.Time - Returns the current time
+// @return Option - The option to pass to NewScheduler
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// NewScheduler creates a new Scheduler
+// The default holder is the host name followed by a random suffix.
+// @param jobs service.JobService - The service applying the status changes
+// @param leases repository.LeaseRepository - The repository holding the lease
+// @param opts ...Option - Optional settings
+// @return *Scheduler - The scheduler
+func NewScheduler(jobs service.JobService, leases repository.LeaseRepository, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		jobs:     jobs,
+		leases:   leases,
+		holder:   defaultHolder(),
+		interval: DefaultInterval,
+		leaseTTL: DefaultLeaseTTL,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run applies the schedule on every interval until ctx is cancelled, then releases the lease
+// @param ctx context.Context - Stops the scheduler when cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, _, err := s.RunOnce(ctx); err != nil {
+			log.Printf("Failed to apply the job schedule: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := s.leases.Release(LeaseName, s.holder); err != nil {
+				log.Printf("Failed to release the scheduler lease: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce takes or renews the lease and, if held, publishes and closes the due jobs
+// @param ctx context.Context - Passed to the job service
+// @return published int - The number of jobs published
+// @return closed int - The number of jobs closed
+// @return err error - An error if the lease or the jobs cannot be read or changed
+func (s *Scheduler) RunOnce(ctx context.Context) (published, closed int, err error) {
+	now := s.now()
+	held, err := s.leases.TryAcquire(LeaseName, s.holder, now, s.leaseTTL)
+	if err != nil || !held {
+		return 0, 0, err
+	}
+	ctx = domain.ContextWithActor(ctx, domain.Actor{Subject: Actor})
+	published, closed, err = s.jobs.ApplySchedule(ctx, now)
+	if published > 0 || closed > 0 {
+		log.Printf("Scheduler published %d and closed %d jobs", published, closed)
+	}
+	return published, closed, err
+}
+
+// defaultHolder returns the host name followed by a random suffix, unique per process
+func defaultHolder() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeClock is a clock the tests move forward by hand
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// memoryLeases is an in-memory LeaseRepository shared by the schedulers of a test,
+// standing in for the leases table several replicas compete for
+type memoryLeases struct {
+	mu     sync.Mutex
+	holder map[string]string
+	until  map[string]time.Time
+}
+
+func newMemoryLeases() *memoryLeases {
+	return &memoryLeases{holder: map[string]string{}, until: map[string]time.Time{}}
+}
+
+func (l *memoryLeases) TryAcquire(name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if current, ok := l.holder[name]; ok && current != holder && now.Before(l.until[name]) {
+		return false, nil
+	}
+	l.holder[name] = holder
+	l.until[name] = now.Add(ttl)
+	return true, nil
+}
+
+func (l *memoryLeases) Release(name, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder[name] == holder {
+		delete(l.holder, name)
+	}
+	return nil
+}
+
+// isScheduler matches the context the scheduler passes to the job service
+func isScheduler(ctx context.Context) bool {
+	return domain.ActorFromContext(ctx).Subject == Actor
+}
+
+func TestRunOnce_OnlyLeaseHolderApplies(t *testing.T) {
+	// Setup
+	clock := &fakeClock{now: time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)}
+	leases := newMemoryLeases()
+	mockJobService := new(service.MockJobService)
+	first := NewScheduler(mockJobService, leases, WithHolder("replica-1"), WithClock(clock.Now), WithLeaseTTL(time.Minute))
+	second := NewScheduler(mockJobService, leases, WithHolder("replica-2"), WithClock(clock.Now), WithLeaseTTL(time.Minute))
+
+	// Mock behavior
+	mockJobService.On("ApplySchedule", mock.MatchedBy(isScheduler), mock.Anything).Return(1, 2, nil)
+
+	// Execute: the first replica takes the lease, the second one is kept out
+	published, closed, err := first.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 2, closed)
+
+	published, closed, err = second.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, published+closed)
+	mockJobService.AssertNumberOfCalls(t, "ApplySchedule", 1)
+
+	// The holder renews the lease on every run
+	clock.Advance(45 * time.Second)
+	_, _, err = first.RunOnce(context.Background())
+	assert.NoError(t, err)
+	clock.Advance(45 * time.Second)
+	_, _, err = second.RunOnce(context.Background())
+	assert.NoError(t, err)
+	mockJobService.AssertNumberOfCalls(t, "ApplySchedule", 2)
+
+	// Once the holder stops renewing, the other replica takes over after the TTL
+	clock.Advance(time.Minute)
+	_, _, err = second.RunOnce(context.Background())
+	assert.NoError(t, err)
+	mockJobService.AssertNumberOfCalls(t, "ApplySchedule", 3)
+	mockJobService.AssertCalled(t, "ApplySchedule", mock.Anything, clock.Now())
+}
+
+func TestRun_ReleasesLeaseOnShutdown(t *testing.T) {
+	// Setup
+	mockLeases := new(repository.MockLeaseRepository)
+	mockJobService := new(service.MockJobService)
+	now := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	scheduler := NewScheduler(mockJobService, mockLeases, WithHolder("replica-1"),
+		WithClock(func() time.Time { return now }), WithInterval(time.Hour))
+
+	// Mock behavior
+	mockLeases.On("TryAcquire", LeaseName, "replica-1", now, DefaultLeaseTTL).Return(true, nil)
+	applied := make(chan struct{}, 1)
+	mockJobService.On("ApplySchedule", mock.Anything, now).Run(func(mock.Arguments) { applied <- struct{}{} }).Return(0, 0, nil)
+	mockLeases.On("Release", LeaseName, "replica-1").Return(nil)
+
+	// Execute: stop the scheduler after its first run
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+	<-applied
+	cancel()
+	<-done
+
+	// Assertions
+	mockLeases.AssertExpectations(t)
+	mockJobService.AssertExpectations(t)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// SkillService defines methods for the skills dictionary and the skills tagged on jobs
+// This interface abstracts the business logic for skill normalisation and job tagging.
+type SkillService interface {
+	ListSkills() ([]*domain.Skill, error)                                                             // Retrieves the skills dictionary
+	AddSkill(skill *domain.Skill) error                                                               // Adds a skill to the dictionary
+	AddAlias(skillID int, alias string) error                                                         // Adds an alias to a skill
+	RemoveSkill(id int) error                                                                         // Removes a skill from the dictionary
+	GetJobSkills(jobID int) ([]domain.JobSkill, error)                                                // Retrieves the skills tagged on a job
+	SetJobSkills(ctx context.Context, jobID int, skills []domain.JobSkill) ([]domain.JobSkill, error) // Replaces the skills tagged on a job
+	RemoveJobSkill(ctx context.Context, jobID int, name string) error                                 // Removes a skill from a job
+}
+
+// SkillOption configures optional collaborators of the skill service
+type SkillOption func(*skillServiceImpl)
+
+// WithSkillTransactor runs every change of a job's skills in a transaction together with its audit entry and events
+// Without it, job tags go straight to the SkillRepository and are neither audited nor announced.
+// @param transactor repository.Transactor - The transactor providing the transaction-bound repositories
+// @return SkillOption - The option to pass to NewSkillService
+func WithSkillTransactor(transactor repository.Transactor) SkillOption {
+	return func(s *skillServiceImpl) {
+		s.transactor = transactor
+	}
+}
+
+type skillServiceImpl struct {
+	repo       repository.SkillRepository // Dependency on the SkillRepository
+	jobs       repository.JobRepository   // Used to check that tagged jobs exist
+	transactor repository.Transactor      // Optional unit of work for audited tag changes
+}
+
+// NewSkillService creates a new SkillService instance
+// @param repo repository.SkillRepository - The repository holding skills and job tags
+// @param jobs repository.JobRepository - The repository used to look up tagged jobs
+// @param opts ...SkillOption - Optional collaborators (e.g., WithSkillTransactor)
+// @return SkillService - The implementation of the service interface
+func NewSkillService(repo repository.SkillRepository, jobs repository.JobRepository, opts ...SkillOption) SkillService {
+	s := &skillServiceImpl{repo: repo, jobs: jobs}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListSkills retrieves the skills dictionary
+// @return []*domain.Skill - A slice of skills with their aliases
+// @return error - An error if the retrieval fails
+func (s *skillServiceImpl) ListSkills() ([]*domain.Skill, error) {
+	return s.repo.FindAll()
+}
+
+// AddSkill adds a skill to the dictionary
+// The canonical name keeps its casing (e.g., "Go"); aliases are matched case-insensitively.
+// @param skill *domain.Skill - The skill to add
+// @return error - A *domain.ValidationError, domain.ErrSkillExists, or an error if the creation fails
+func (s *skillServiceImpl) AddSkill(skill *domain.Skill) error {
+	skill.Name = strings.TrimSpace(skill.Name)
+	if skill.Name == "" {
+		return &domain.ValidationError{Field: "name", Message: "is required"}
+	}
+
+	// Drop empty aliases, duplicates and aliases equal to the name itself
+	seen := map[string]bool{domain.NormalizeSkillKey(skill.Name): true}
+	aliases := make([]string, 0, len(skill.Aliases))
+	for _, alias := range skill.Aliases {
+		key := domain.NormalizeSkillKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		aliases = append(aliases, key)
+	}
+	skill.Aliases = aliases
+	return s.repo.Create(skill)
+}
+
+// AddAlias adds an alias to a skill
+// @param skillID int - The ID of the skill
+// @param alias string - The alias to add
+// @return error - A *domain.ValidationError, domain.ErrSkillNotFound, domain.ErrSkillExists, or an error if the update fails
+func (s *skillServiceImpl) AddAlias(skillID int, alias string) error {
+	if domain.NormalizeSkillKey(alias) == "" {
+		return &domain.ValidationError{Field: "alias", Message: "is required"}
+	}
+	return s.repo.AddAlias(skillID, alias)
+}
+
+// RemoveSkill removes a skill from the dictionary, untagging it from every job
+// @param id int - The ID of the skill
+// @return error - domain.ErrSkillNotFound, or an error if the deletion fails
+func (s *skillServiceImpl) RemoveSkill(id int) error {
+	return s.repo.Delete(id)
+}
+
+// GetJobSkills retrieves the skills tagged on a job
+// @param jobID int - The ID of the job
+// @return []domain.JobSkill - The job's skills
+// @return error - domain.ErrJobNotFound, or an error if the retrieval fails
+func (s *skillServiceImpl) GetJobSkills(jobID int) ([]domain.JobSkill, error) {
+	if _, err := s.jobs.FindByID(jobID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByJob(jobID)
+}
+
+// SetJobSkills replaces the skills tagged on a job
+// Names and aliases are resolved against the dictionary; unknown skills are rejected.
+// A skill listed twice keeps the strongest requirement. The change is audited and announced
+// as a job update, like UpdateJob.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param jobID int - The ID of the job
+// @param skills []domain.JobSkill - The new set of skills
+// @return []domain.JobSkill - The stored skills with their canonical names
+// @return error - A *domain.ValidationError, domain.ErrJobNotFound, or an error if the update fails
+func (s *skillServiceImpl) SetJobSkills(ctx context.Context, jobID int, skills []domain.JobSkill) ([]domain.JobSkill, error) {
+	var tagged []domain.JobSkill
+	err := s.withinTx(func(repos repository.TxRepositories) error {
+		before, err := repos.Jobs.FindByID(jobID)
+		if err != nil {
+			return err
+		}
+		if tagged, err = resolveJobSkills(repos.Skills, skills); err != nil {
+			return err
+		}
+		if err := repos.Skills.ReplaceJobSkills(jobID, tagged); err != nil {
+			return err
+		}
+		return recordSkillChange(ctx, repos, before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tagged, nil
+}
+
+// resolveJobSkills maps the requested skills to dictionary skills, merging duplicates
+func resolveJobSkills(repo repository.SkillRepository, skills []domain.JobSkill) ([]domain.JobSkill, error) {
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		switch skill.Requirement {
+		case "", domain.SkillRequired, domain.SkillNiceToHave:
+		default:
+			return nil, &domain.ValidationError{Field: "requirement", Message: "must be required or nice_to_have"}
+		}
+		names = append(names, skill.Name)
+	}
+	resolved, err := repo.Resolve(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	tagged := make([]domain.JobSkill, 0, len(skills))
+	index := make(map[int]int, len(skills)) // Skill ID -> position in tagged
+	for _, skill := range skills {
+		match, ok := resolved[domain.NormalizeSkillKey(skill.Name)]
+		if !ok {
+			unknown = append(unknown, skill.Name)
+			continue
+		}
+		requirement := skill.Requirement
+		if requirement == "" {
+			requirement = domain.SkillRequired
+		}
+		if i, ok := index[match.ID]; ok {
+			if requirement == domain.SkillRequired {
+				tagged[i].Requirement = domain.SkillRequired
+			}
+			continue
+		}
+		index[match.ID] = len(tagged)
+		tagged = append(tagged, domain.JobSkill{SkillID: match.ID, Name: match.Name, Requirement: requirement})
+	}
+	if len(unknown) > 0 {
+		return nil, &domain.ValidationError{Field: "skills", Message: "unknown skills " + strings.Join(unknown, ", ")}
+	}
+	return tagged, nil
+}
+
+// RemoveJobSkill removes a skill from a job
+// The change is audited and announced as a job update, like UpdateJob.
+// @param ctx context.Context - Carries the actor recorded in the audit log
+// @param jobID int - The ID of the job
+// @param name string - The skill name or alias
+// @return error - domain.ErrJobNotFound, domain.ErrSkillNotFound if the job is not tagged with the skill, or an error if the deletion fails
+func (s *skillServiceImpl) RemoveJobSkill(ctx context.Context, jobID int, name string) error {
+	return s.withinTx(func(repos repository.TxRepositories) error {
+		before, err := repos.Jobs.FindByID(jobID)
+		if err != nil {
+			return err
+		}
+		resolved, err := repos.Skills.Resolve([]string{name})
+		if err != nil {
+			return err
+		}
+		skill, ok := resolved[domain.NormalizeSkillKey(name)]
+		if !ok {
+			return domain.ErrSkillNotFound
+		}
+		if err := repos.Skills.RemoveJobSkill(jobID, skill.ID); err != nil {
+			return err
+		}
+		return recordSkillChange(ctx, repos, before)
+	})
+}
+
+// recordSkillChange audits and announces a change of the skills of a job as a job update
+func recordSkillChange(ctx context.Context, repos repository.TxRepositories, before *domain.Job) error {
+	after, err := loadSnapshot(repos, before.ID) // Reload to capture the new skills and version
+	if err != nil {
+		return err
+	}
+	if after == nil {
+		return nil
+	}
+	if err := recordJobChange(ctx, repos, domain.AuditActionUpdate, before.ID, before, after); err != nil {
+		return err
+	}
+	return emitJobEvent(repos, domain.EventJobUpdated, after)
+}
+
+// withinTx runs fn in a transaction when a transactor is configured
+// Otherwise fn runs directly against the repositories, without audit log nor events.
+func (s *skillServiceImpl) withinTx(fn func(repos repository.TxRepositories) error) error {
+	if s.transactor == nil {
+		return fn(repository.TxRepositories{Jobs: s.jobs, Skills: s.repo})
+	}
+	return s.transactor.WithinTx(fn)
+}
+
+// canonicalSkillNames maps skill names and aliases to their canonical dictionary names
+// Comma separated entries are split; unknown names are kept as given so they simply match nothing.
+func canonicalSkillNames(repo repository.SkillRepository, names []string) ([]string, error) {
+	var split []string
+	for _, name := range names {
+		for _, part := range strings.Split(name, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	if len(split) == 0 {
+		return nil, nil
+	}
+
+	resolved, err := repo.Resolve(split)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(split))
+	canonical := make([]string, 0, len(split))
+	for _, name := range split {
+		if skill, ok := resolved[domain.NormalizeSkillKey(name)]; ok {
+			name = skill.Name
+		}
+		if !seen[name] {
+			seen[name] = true
+			canonical = append(canonical, name)
+		}
+	}
+	return canonical, nil
+}
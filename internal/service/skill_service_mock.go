@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSkillService is a mock implementation of SkillService for testing
+type MockSkillService struct {
+	mock.Mock
+}
+
+// ListSkills mocks the ListSkills method
+func (m *MockSkillService) ListSkills() ([]*domain.Skill, error) {
+	args := m.Called()
+	if skills, ok := args.Get(0).([]*domain.Skill); ok {
+		return skills, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// AddSkill mocks the AddSkill method
+func (m *MockSkillService) AddSkill(skill *domain.Skill) error {
+	args := m.Called(skill)
+	return args.Error(0)
+}
+
+// AddAlias mocks the AddAlias method
+func (m *MockSkillService) AddAlias(skillID int, alias string) error {
+	args := m.Called(skillID, alias)
+	return args.Error(0)
+}
+
+// RemoveSkill mocks the RemoveSkill method
+func (m *MockSkillService) RemoveSkill(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetJobSkills mocks the GetJobSkills method
+func (m *MockSkillService) GetJobSkills(jobID int) ([]domain.JobSkill, error) {
+	args := m.Called(jobID)
+	if skills, ok := args.Get(0).([]domain.JobSkill); ok {
+		return skills, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// SetJobSkills mocks the SetJobSkills method
+func (m *MockSkillService) SetJobSkills(ctx context.Context, jobID int, skills []domain.JobSkill) ([]domain.JobSkill, error) {
+	args := m.Called(ctx, jobID, skills)
+	if tagged, ok := args.Get(0).([]domain.JobSkill); ok {
+		return tagged, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RemoveJobSkill mocks the RemoveJobSkill method
+func (m *MockSkillService) RemoveJobSkill(ctx context.Context, jobID int, name string) error {
+	args := m.Called(ctx, jobID, name)
+	return args.Error(0)
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetJobSkills_ResolvesAliases(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockSkillRepository)
+	mockJobs := new(repository.MockJobRepository)
+	skillService := NewSkillService(mockRepo, mockJobs)
+
+	// Mock data: "golang" and "Go" resolve to the same skill
+	input := []domain.JobSkill{
+		{Name: "golang", Requirement: domain.SkillNiceToHave},
+		{Name: "Go"},
+		{Name: "postgres", Requirement: domain.SkillNiceToHave},
+	}
+	goSkill := &domain.Skill{ID: 1, Name: "Go"}
+	pgSkill := &domain.Skill{ID: 2, Name: "PostgreSQL"}
+	expected := []domain.JobSkill{
+		{SkillID: 1, Name: "Go", Requirement: domain.SkillRequired},
+		{SkillID: 2, Name: "PostgreSQL", Requirement: domain.SkillNiceToHave},
+	}
+
+	// Mock behavior
+	mockJobs.On("FindByID", 7).Return(&domain.Job{ID: 7}, nil)
+	mockRepo.On("Resolve", []string{"golang", "Go", "postgres"}).
+		Return(map[string]*domain.Skill{"golang": goSkill, "go": goSkill, "postgres": pgSkill}, nil)
+	mockRepo.On("ReplaceJobSkills", 7, expected).Return(nil)
+
+	// Execute
+	result, err := skillService.SetJobSkills(context.Background(), 7, input)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetJobSkills_UnknownSkill(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockSkillRepository)
+	mockJobs := new(repository.MockJobRepository)
+	skillService := NewSkillService(mockRepo, mockJobs)
+
+	// Mock behavior
+	mockJobs.On("FindByID", 7).Return(&domain.Job{ID: 7}, nil)
+	mockRepo.On("Resolve", []string{"cobol"}).Return(map[string]*domain.Skill{}, nil)
+
+	// Execute
+	_, err := skillService.SetJobSkills(context.Background(), 7, []domain.JobSkill{{Name: "cobol"}})
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "cobol")
+	mockRepo.AssertNotCalled(t, "ReplaceJobSkills", mock.Anything, mock.Anything)
+}
+
+func TestSetJobSkills_JobNotFound(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockSkillRepository)
+	mockJobs := new(repository.MockJobRepository)
+	skillService := NewSkillService(mockRepo, mockJobs)
+
+	// Mock behavior
+	mockJobs.On("FindByID", 99).Return(nil, domain.ErrJobNotFound)
+
+	// Execute
+	_, err := skillService.SetJobSkills(context.Background(), 99, []domain.JobSkill{{Name: "Go"}})
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	mockRepo.AssertNotCalled(t, "Resolve", mock.Anything)
+}
+
+func TestRemoveJobSkill_JobNotFound(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockSkillRepository)
+	mockJobs := new(repository.MockJobRepository)
+	skillService := NewSkillService(mockRepo, mockJobs)
+
+	// Mock behavior: missing and soft-deleted jobs are both not found
+	mockJobs.On("FindByID", 99).Return(nil, domain.ErrJobNotFound)
+
+	// Execute
+	err := skillService.RemoveJobSkill(context.Background(), 99, "go")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	mockRepo.AssertNotCalled(t, "RemoveJobSkill", mock.Anything, mock.Anything)
+}
+
+func TestRemoveJobSkill_RecordsAuditEntryAndEvent(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockSkillRepository)
+	mockJobs := new(repository.MockJobRepository)
+	mockAudit := new(repository.MockAuditRepository)
+	mockOutbox := new(repository.MockOutboxRepository)
+	mockTx := &repository.MockTransactor{Repos: repository.TxRepositories{Jobs: mockJobs, Skills: mockRepo, Audit: mockAudit, Outbox: mockOutbox}}
+	mockTx.On("WithinTx")
+	skillService := NewSkillService(mockRepo, mockJobs, WithSkillTransactor(mockTx))
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "user-7"})
+
+	// Mock data
+	goSkill := domain.JobSkill{SkillID: 1, Name: "Go", Requirement: domain.SkillRequired}
+	before := &domain.Job{ID: 5, Title: "Go Developer", Version: 3, Skills: []domain.JobSkill{goSkill}}
+	after := &domain.Job{ID: 5, Title: "Go Developer", Version: 4}
+
+	// Mock behavior
+	mockJobs.On("FindByID", 5).Return(before, nil).Once()
+	mockJobs.On("FindByID", 5).Return(after, nil).Once()
+	mockRepo.On("Resolve", []string{"golang"}).Return(map[string]*domain.Skill{"golang": {ID: 1, Name: "Go"}}, nil)
+	mockRepo.On("RemoveJobSkill", 5, 1).Return(nil)
+	mockAudit.On("Append", mock.MatchedBy(func(entry *domain.AuditEntry) bool {
+		_, skillsChanged := entry.Changes["skills"]
+		return entry.EntityID == 5 && entry.Action == domain.AuditActionUpdate && entry.Actor == "user-7" &&
+			skillsChanged && len(entry.Changes) == 1
+	})).Return(nil)
+	mockOutbox.On("Enqueue", mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventJobUpdated && event.AggregateID == 5
+	})).Return(nil)
+
+	// Execute
+	err := skillService.RemoveJobSkill(ctx, 5, "golang")
+
+	// Assertions
+	assert.NoError(t, err)
+	mockTx.AssertExpectations(t)
+	mockJobs.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
+}
+
+func TestSearchJobs_ResolvesSkillAliases(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockJobRepository)
+	mockSkills := new(repository.MockSkillRepository)
+	jobService := NewJobService(mockRepo, WithSkillRepository(mockSkills))
+
+	// Mock data
+	filter := domain.JobFilter{Skills: []string{"golang,k8s", "Go"}, SkillMatch: domain.SkillMatchAll}
+	expectedFilter := domain.JobFilter{Skills: []string{"Go", "Kubernetes"}, SkillMatch: domain.SkillMatchAll}
+	jobs := []*domain.Job{{ID: 1, Title: "Platform Engineer"}}
+
+	// Mock behavior
+	mockSkills.On("Resolve", []string{"golang", "k8s", "Go"}).Return(map[string]*domain.Skill{
+		"golang": {ID: 1, Name: "Go"},
+		"go":     {ID: 1, Name: "Go"},
+		"k8s":    {ID: 3, Name: "Kubernetes"},
+	}, nil)
+	mockRepo.On("FindByFilter", expectedFilter).Return(jobs, nil)
+
+	// Execute
+	result, err := jobService.SearchJobs(filter)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, jobs, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// TaxonomyService defines methods for managing the job classification vocabularies
+// This interface abstracts the business logic for employment types, seniority levels and departments.
+type TaxonomyService interface {
+	ListTerms(kind string) ([]*domain.TaxonomyTerm, error) // Retrieves the terms of a taxonomy kind
+	AddTerm(term *domain.TaxonomyTerm) error               // Adds a term to a taxonomy kind
+	RemoveTerm(kind, value string) error                   // Removes a term from a taxonomy kind
+}
+
+type taxonomyServiceImpl struct {
+	repo repository.TaxonomyRepository // Dependency on the TaxonomyRepository
+}
+
+// NewTaxonomyService creates a new TaxonomyService instance
+// @param repo repository.TaxonomyRepository - The repository to interact with the database
+// @return TaxonomyService - The implementation of the service interface
+func NewTaxonomyService(repo repository.TaxonomyRepository) TaxonomyService {
+	return &taxonomyServiceImpl{repo: repo}
+}
+
+// ListTerms retrieves the terms of a taxonomy kind
+// @param kind string - The taxonomy kind
+// @return []*domain.TaxonomyTerm - A slice of terms
+// @return error - A *domain.ValidationError for unknown kinds, or an error if the retrieval fails
+func (s *taxonomyServiceImpl) ListTerms(kind string) ([]*domain.TaxonomyTerm, error) {
+	if err := validateKind(kind); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByKind(kind)
+}
+
+// AddTerm adds a term to a taxonomy kind
+// The value is trimmed and lower-cased; the label defaults to the value.
+// @param term *domain.TaxonomyTerm - The term to add
+// @return error - A *domain.ValidationError, domain.ErrTaxonomyTermExists, or an error if the creation fails
+func (s *taxonomyServiceImpl) AddTerm(term *domain.TaxonomyTerm) error {
+	if err := validateKind(term.Kind); err != nil {
+		return err
+	}
+	term.Value = strings.ToLower(strings.TrimSpace(term.Value))
+	if term.Value == "" {
+		return &domain.ValidationError{Field: "value", Message: "is required"}
+	}
+	if term.Label == "" {
+		term.Label = term.Value
+	}
+	return s.repo.Create(term)
+}
+
+// RemoveTerm removes a term from a taxonomy kind
+// @param kind string - The taxonomy kind
+// @param value string - The value to remove
+// @return error - A *domain.ValidationError, domain.ErrTaxonomyTermNotFound, or an error if the deletion fails
+func (s *taxonomyServiceImpl) RemoveTerm(kind, value string) error {
+	if err := validateKind(kind); err != nil {
+		return err
+	}
+	return s.repo.Delete(kind, value)
+}
+
+// validateKind rejects taxonomy kinds the service does not know about
+func validateKind(kind string) error {
+	if !domain.IsTaxonomyKind(kind) {
+		return &domain.ValidationError{Field: "kind", Message: "must be one of " + strings.Join(domain.TaxonomyKinds, ", ")}
+	}
+	return nil
+}
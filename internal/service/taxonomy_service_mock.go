@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaxonomyService is a mock implementation of TaxonomyService for testing
+type MockTaxonomyService struct {
+	mock.Mock
+}
+
+// ListTerms mocks the ListTerms method
+func (m *MockTaxonomyService) ListTerms(kind string) ([]*domain.TaxonomyTerm, error) {
+	args := m.Called(kind)
+	if terms, ok := args.Get(0).([]*domain.TaxonomyTerm); ok {
+		return terms, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// AddTerm mocks the AddTerm method
+func (m *MockTaxonomyService) AddTerm(term *domain.TaxonomyTerm) error {
+	args := m.Called(term)
+	return args.Error(0)
+}
+
+// RemoveTerm mocks the RemoveTerm method
+func (m *MockTaxonomyService) RemoveTerm(kind, value string) error {
+	args := m.Called(kind, value)
+	return args.Error(0)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddTerm_NormalisesValue(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockTaxonomyRepository)
+	taxonomyService := NewTaxonomyService(mockRepo)
+
+	// Mock data
+	term := &domain.TaxonomyTerm{Kind: domain.TaxonomyDepartment, Value: "  Engineering "}
+
+	// Mock behavior
+	mockRepo.On("Create", mock.AnythingOfType("*domain.TaxonomyTerm")).Return(nil)
+
+	// Execute
+	err := taxonomyService.AddTerm(term)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, "engineering", term.Value)
+	assert.Equal(t, "engineering", term.Label)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddTerm_UnknownKind(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockTaxonomyRepository)
+	taxonomyService := NewTaxonomyService(mockRepo)
+
+	// Execute
+	err := taxonomyService.AddTerm(&domain.TaxonomyTerm{Kind: "location", Value: "remote"})
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRemoveTerm_NotFound(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockTaxonomyRepository)
+	taxonomyService := NewTaxonomyService(mockRepo)
+
+	// Mock behavior
+	mockRepo.On("Delete", domain.TaxonomySeniority, "wizard").Return(domain.ErrTaxonomyTermNotFound)
+
+	// Execute
+	err := taxonomyService.RemoveTerm(domain.TaxonomySeniority, "wizard")
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrTaxonomyTermNotFound)
+	mockRepo.AssertExpectations(t)
+}
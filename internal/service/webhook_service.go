@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/poolcamacho/jobs-service/internal/service/webhooks"
+)
+
+// Bounds of the number of deliveries returned by ListDeliveries
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// WebhookService defines methods for managing webhook subscriptions
+// Subscriptions belong to the actor that created them; other actors cannot see or change them.
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error)         // Registers an endpoint
+	ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)                                               // Retrieves the subscriptions of the actor
+	GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error)                                           // Retrieves one subscription
+	UpdateSubscription(ctx context.Context, id int, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) // Changes the endpoint or events
+	DeleteSubscription(ctx context.Context, id int) error                                                                       // Removes a subscription
+	ListDeliveries(ctx context.Context, id, limit, offset int) ([]*domain.WebhookDelivery, error)                               // Retrieves the delivery log
+	Redeliver(ctx context.Context, id, deliveryID int) error                                                                    // Queues a delivery again
+}
+
+type webhookServiceImpl struct {
+	repo repository.WebhookRepository // Dependency on the WebhookRepository
+}
+
+// NewWebhookService creates a new WebhookService instance
+// @param repo repository.WebhookRepository - The repository to interact with the database
+// @return WebhookService - The implementation of the service interface
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	return &webhookServiceImpl{repo: repo}
+}
+
+// CreateSubscription registers an endpoint for the actor in ctx
+// A random signing secret is generated; it is only returned by this call.
+// @param ctx context.Context - Carries the actor owning the subscription
+// @param req domain.WebhookSubscriptionRequest - The endpoint and event types
+// @return *domain.WebhookSubscription - The created subscription, including its secret
+// @return error - A *domain.ValidationError for an invalid URL or event type or a token without subject, or an error if the creation fails
+func (s *webhookServiceImpl) CreateSubscription(ctx context.Context, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if err := validateSubscription(req); err != nil {
+		return nil, err
+	}
+	owner := domain.ActorFromContext(ctx).Subject
+	if owner == "" {
+		return nil, &domain.ValidationError{Field: "token", Message: "carries no subject to own the subscription"}
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	sub := &domain.WebhookSubscription{
+		Owner:     owner,
+		URL:       req.URL,
+		Events:    req.Events,
+		Secret:    secret,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.CreateSubscription(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of the actor in ctx
+// @param ctx context.Context - Carries the actor
+// @return []*domain.WebhookSubscription - The subscriptions, without their secrets
+// @return error - An error if the retrieval fails
+func (s *webhookServiceImpl) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subs, err := s.repo.FindSubscriptions(domain.ActorFromContext(ctx).Subject)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subs {
+		sub.Secret = ""
+	}
+	return subs, nil
+}
+
+// GetSubscription retrieves one subscription of the actor in ctx
+// @param ctx context.Context - Carries the actor
+// @param id int - The ID of the subscription
+// @return *domain.WebhookSubscription - The subscription, without its secret
+// @return error - domain.ErrWebhookNotFound if the actor has no such subscription, or an error if the retrieval fails
+func (s *webhookServiceImpl) GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	sub, err := s.repo.FindSubscription(id, domain.ActorFromContext(ctx).Subject)
+	if err != nil {
+		return nil, err
+	}
+	sub.Secret = ""
+	return sub, nil
+}
+
+// UpdateSubscription changes the endpoint, event types or active flag of a subscription
+// The secret is kept. Deliveries already queued still go to the new URL.
+// @param ctx context.Context - Carries the actor
+// @param id int - The ID of the subscription
+// @param req domain.WebhookSubscriptionRequest - The new endpoint and event types
+// @return *domain.WebhookSubscription - The updated subscription, without its secret
+// @return error - A *domain.ValidationError, domain.ErrWebhookNotFound, or an error if the update fails
+func (s *webhookServiceImpl) UpdateSubscription(ctx context.Context, id int, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	if err := validateSubscription(req); err != nil {
+		return nil, err
+	}
+	sub, err := s.repo.FindSubscription(id, domain.ActorFromContext(ctx).Subject)
+	if err != nil {
+		return nil, err
+	}
+	sub.URL = req.URL
+	sub.Events = req.Events
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+	if err := s.repo.UpdateSubscription(sub); err != nil {
+		return nil, err
+	}
+	sub.Secret = ""
+	sub.UpdatedAt = time.Now().UTC()
+	return sub, nil
+}
+
+// DeleteSubscription removes a subscription of the actor in ctx, with its delivery log
+// @param ctx context.Context - Carries the actor
+// @param id int - The ID of the subscription
+// @return error - domain.ErrWebhookNotFound if the actor has no such subscription, or an error if the deletion fails
+func (s *webhookServiceImpl) DeleteSubscription(ctx context.Context, id int) error {
+	return s.repo.DeleteSubscription(id, domain.ActorFromContext(ctx).Subject)
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, newest first
+// @param ctx context.Context - Carries the actor
+// @param id int - The ID of the subscription
+// @param limit int - Maximum number of deliveries (default 50, max 200)
+// @param offset int - Number of deliveries to skip
+// @return []*domain.WebhookDelivery - The deliveries
+// @return error - domain.ErrWebhookNotFound if the actor has no such subscription, or an error if the retrieval fails
+func (s *webhookServiceImpl) ListDeliveries(ctx context.Context, id, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.repo.FindSubscription(id, domain.ActorFromContext(ctx).Subject); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.FindDeliveries(id, limit, offset)
+}
+
+// Redeliver queues a delivery again, whatever its status, with a fresh retry schedule
+// @param ctx context.Context - Carries the actor
+// @param id int - The ID of the subscription
+// @param deliveryID int - The ID of the delivery
+// @return error - domain.ErrWebhookNotFound, domain.ErrDeliveryNotFound, or an error if the update fails
+func (s *webhookServiceImpl) Redeliver(ctx context.Context, id, deliveryID int) error {
+	if _, err := s.repo.FindSubscription(id, domain.ActorFromContext(ctx).Subject); err != nil {
+		return err
+	}
+	return s.repo.ResetDelivery(id, deliveryID, time.Now())
+}
+
+// validateSubscription checks the endpoint URL and the event types of a subscription request
+func validateSubscription(req domain.WebhookSubscriptionRequest) error {
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return &domain.ValidationError{Field: "url", Message: "must be an absolute http or https URL"}
+	}
+	if !webhooks.IsAllowedHost(endpoint.Hostname()) {
+		return &domain.ValidationError{Field: "url", Message: "must not point to a loopback, link-local or private address"}
+	}
+	for _, eventType := range req.Events {
+		if !domain.IsJobEvent(eventType) {
+			return &domain.ValidationError{Field: "events", Message: "unknown event type " + eventType}
+		}
+	}
+	return nil
+}
+
+// newWebhookSecret returns a random 256-bit signing secret encoded as hex
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService is a mock implementation of WebhookService for testing
+type MockWebhookService struct {
+	mock.Mock
+}
+
+// CreateSubscription mocks the CreateSubscription method
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, req)
+	if sub, ok := args.Get(0).(*domain.WebhookSubscription); ok {
+		return sub, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListSubscriptions mocks the ListSubscriptions method
+func (m *MockWebhookService) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if subs, ok := args.Get(0).([]*domain.WebhookSubscription); ok {
+		return subs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetSubscription mocks the GetSubscription method
+func (m *MockWebhookService) GetSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if sub, ok := args.Get(0).(*domain.WebhookSubscription); ok {
+		return sub, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// UpdateSubscription mocks the UpdateSubscription method
+func (m *MockWebhookService) UpdateSubscription(ctx context.Context, id int, req domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id, req)
+	if sub, ok := args.Get(0).(*domain.WebhookSubscription); ok {
+		return sub, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// DeleteSubscription mocks the DeleteSubscription method
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// ListDeliveries mocks the ListDeliveries method
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, id, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id, limit, offset)
+	if deliveries, ok := args.Get(0).([]*domain.WebhookDelivery); ok {
+		return deliveries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// Redeliver mocks the Redeliver method
+func (m *MockWebhookService) Redeliver(ctx context.Context, id, deliveryID int) error {
+	args := m.Called(ctx, id, deliveryID)
+	return args.Error(0)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockWebhookRepository)
+	webhookService := NewWebhookService(mockRepo)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "acme"})
+
+	// Mock behavior
+	mockRepo.On("CreateSubscription", mock.MatchedBy(func(sub *domain.WebhookSubscription) bool {
+		return sub.Owner == "acme" && sub.Active && strings.HasPrefix(sub.Secret, "whsec_")
+	})).Return(nil)
+
+	// Execute
+	sub, err := webhookService.CreateSubscription(ctx, domain.WebhookSubscriptionRequest{
+		URL:    "https://hooks.acme.test/jobs",
+		Events: []string{domain.EventJobPublished},
+	})
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.NotEmpty(t, sub.Secret)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateSubscription_InvalidRequest(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockWebhookRepository)
+	webhookService := NewWebhookService(mockRepo)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "acme"})
+
+	// Execute
+	_, badURL := webhookService.CreateSubscription(ctx, domain.WebhookSubscriptionRequest{URL: "ftp://acme.test"})
+	_, badEvent := webhookService.CreateSubscription(ctx, domain.WebhookSubscriptionRequest{
+		URL: "https://acme.test", Events: []string{"job.exploded"},
+	})
+	_, metadataURL := webhookService.CreateSubscription(ctx, domain.WebhookSubscriptionRequest{URL: "http://169.254.169.254/latest/meta-data"})
+
+	// Assertions
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, badURL, &validationErr)
+	assert.Equal(t, "url", validationErr.Field)
+	assert.ErrorAs(t, badEvent, &validationErr)
+	assert.Equal(t, "events", validationErr.Field)
+	assert.ErrorAs(t, metadataURL, &validationErr)
+	assert.Equal(t, "url", validationErr.Field)
+	mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything)
+}
+
+func TestRedeliver_OtherOwner(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockWebhookRepository)
+	webhookService := NewWebhookService(mockRepo)
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{Subject: "intruder"})
+
+	// Mock behavior
+	mockRepo.On("FindSubscription", 4, "intruder").Return(nil, domain.ErrWebhookNotFound)
+
+	// Execute
+	err := webhookService.Redeliver(ctx, 4, 10)
+
+	// Assertions
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	mockRepo.AssertNotCalled(t, "ResetDelivery", mock.Anything, mock.Anything, mock.Anything)
+}
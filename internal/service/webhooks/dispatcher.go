@@ -0,0 +1,241 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// Defaults of the dispatcher settings
+const (
+	DefaultBatchSize    = 50
+	DefaultWorkers      = 4
+	DefaultPollInterval = time.Second
+	DefaultTimeout      = 10 * time.Second
+	DefaultMaxAttempts  = 8
+	DefaultMinBackoff   = 30 * time.Second
+	DefaultMaxBackoff   = 6 * time.Hour
+)
+
+// maxErrorLength bounds the delivery error stored in the delivery log
+const maxErrorLength = 1000
+
+// Dispatcher sends the queued webhook deliveries to the subscribed endpoints
+// A delivery succeeds when the endpoint answers 2xx. Otherwise it is retried with exponential
+// backoff, and dead-lettered once it has failed maxAttempts times; dead deliveries stay in the
+// delivery log and can be redelivered manually.
+type Dispatcher struct {
+	repo   repository.WebhookRepository // Delivery queue
+	client *http.Client                 // Client used to call the endpoints
+
+	batchSize   int              // Deliveries claimed per poll
+	workers     int              // Deliveries sent concurrently
+	interval    time.Duration    // Pause between polls when the queue is drained
+	maxAttempts int              // Attempts before a delivery is dead-lettered
+	minBackoff  time.Duration    // Delay before the first retry
+	maxBackoff  time.Duration    // Upper bound of the retry delay
+	now         func() time.Time // Clock, replaced in tests
+}
+
+// DispatcherOption configures optional Dispatcher settings
+type DispatcherOption func(*Dispatcher)
+
+// WithHTTPClient replaces the HTTP client used to call the endpoints
+// The replacement is trusted as is, without the address checks of NewHTTPClient.
+// @param client *http.Client - The client
+// @return DispatcherOption - The option to pass to NewDispatcher
+func WithHTTPClient(client *http.Client) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithPollInterval sets the pause between polls when the queue is drained
+// @param interval time.Duration - The poll interval
+// @return DispatcherOption - The option to pass to NewDispatcher
+func WithPollInterval(interval time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.interval = interval
+	}
+}
+
+// WithMaxAttempts sets the number of attempts before a delivery is dead-lettered
+// @param attempts int - The maximum number of attempts
+// @return DispatcherOption - The option to pass to NewDispatcher
+func WithMaxAttempts(attempts int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the retry delays; the delay doubles after every failed attempt
+// @param min time.Duration - Delay before the first retry
+// @param max time.Duration - Upper bound of the delay
+// @return DispatcherOption - The option to pass to NewDispatcher
+func WithBackoff(min, max time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.minBackoff = min
+		d.maxBackoff = max
+	}
+}
+
+// WithClock replaces the clock used to sign requests and schedule retries
+// @param now func() time.Time - Returns the current time
+// @return DispatcherOption - The option to pass to NewDispatcher
+func WithClock(now func() time.Time) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.now = now
+	}
+}
+
+// NewDispatcher creates a new Dispatcher
+// @param repo repository.WebhookRepository - The repository holding the delivery queue
+// @param opts ...DispatcherOption - Optional settings
+// @return *Dispatcher - The dispatcher
+func NewDispatcher(repo repository.WebhookRepository, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		repo:        repo,
+		client:      NewHTTPClient(DefaultTimeout),
+		batchSize:   DefaultBatchSize,
+		workers:     DefaultWorkers,
+		interval:    DefaultPollInterval,
+		maxAttempts: DefaultMaxAttempts,
+		minBackoff:  DefaultMinBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run sends the queued deliveries until ctx is cancelled
+// Full batches are followed immediately by the next poll; otherwise the dispatcher waits one interval.
+// @param ctx context.Context - Stops the dispatcher when cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		claimed, err := d.DispatchOnce(ctx)
+		if err != nil {
+			log.Printf("Failed to dispatch webhooks: %v", err)
+		}
+
+		if err == nil && claimed == d.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.interval):
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due deliveries and sends them concurrently
+// @param ctx context.Context - Cancels the requests in flight
+// @return int - The number of deliveries claimed
+// @return error - An error if the queue cannot be read, or the first error recording an outcome
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	// The lease outlasts the slowest possible batch, so deliveries are not claimed twice while in flight
+	lease := time.Duration(d.batchSize/d.workers+1)*d.client.Timeout + time.Minute
+	dispatches, err := d.repo.ClaimDeliveries(d.now(), d.batchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	slots := make(chan struct{}, d.workers)
+	for _, dispatch := range dispatches {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(dispatch *domain.WebhookDispatch) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := d.dispatch(ctx, dispatch); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(dispatch)
+	}
+	wg.Wait()
+	return len(dispatches), firstErr
+}
+
+// dispatch makes one attempt of a delivery and records its outcome
+func (d *Dispatcher) dispatch(ctx context.Context, dispatch *domain.WebhookDispatch) error {
+	delivery := dispatch.Delivery
+	statusCode, err := d.send(ctx, dispatch)
+	if err == nil {
+		return d.repo.MarkDelivered(delivery.ID, statusCode, d.now())
+	}
+
+	reason := err.Error()
+	if len(reason) > maxErrorLength {
+		reason = reason[:maxErrorLength]
+	}
+	attempts := delivery.Attempts + 1
+	if attempts >= d.maxAttempts {
+		return d.repo.MarkFailed(delivery.ID, statusCode, reason, nil) // Dead-letter
+	}
+	next := d.now().Add(d.backoff(delivery.Attempts))
+	return d.repo.MarkFailed(delivery.ID, statusCode, reason, &next)
+}
+
+// send POSTs the signed delivery payload to the endpoint
+// It returns the response status, 0 when there was no response, and an error unless the status is 2xx.
+func (d *Dispatcher) send(ctx context.Context, dispatch *domain.WebhookDispatch) (int, error) {
+	delivery := dispatch.Delivery
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	now := d.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jobs-service-webhooks/1.0")
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Drain so the connection is reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt of a delivery that already failed attempts times
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.minBackoff
+	for i := 0; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.maxBackoff {
+		delay = d.maxBackoff
+	}
+	return delay
+}
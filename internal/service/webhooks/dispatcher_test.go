@@ -0,0 +1,227 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatchOnce_SignedDelivery(t *testing.T) {
+	// Setup: a receiver verifying the signature like a client would
+	now := time.Now().Truncate(time.Second)
+	var verified bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified = Verify("whsec_test", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, 5*time.Minute, time.Now()) &&
+			r.Header.Get(HeaderEvent) == domain.EventJobCreated && r.Header.Get(HeaderDelivery) == "3"
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	mockRepo := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(mockRepo, WithHTTPClient(receiver.Client()), WithClock(func() time.Time { return now }))
+
+	// Mock data
+	payload, _ := json.Marshal(domain.Event{ID: "e1", Type: domain.EventJobCreated})
+	dispatch := &domain.WebhookDispatch{
+		Delivery: domain.WebhookDelivery{ID: 3, EventID: "e1", EventType: domain.EventJobCreated, Payload: payload},
+		URL:      receiver.URL,
+		Secret:   "whsec_test",
+	}
+
+	// Mock behavior
+	mockRepo.On("ClaimDeliveries", now, DefaultBatchSize, mock.AnythingOfType("time.Duration")).Return([]*domain.WebhookDispatch{dispatch}, nil)
+	mockRepo.On("MarkDelivered", 3, http.StatusNoContent, now).Return(nil)
+
+	// Execute
+	claimed, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.True(t, verified)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchOnce_RetriesWithBackoff(t *testing.T) {
+	// Setup
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(mockRepo, WithHTTPClient(receiver.Client()), WithClock(func() time.Time { return now }), WithBackoff(time.Minute, time.Hour))
+
+	// Mock data: the delivery already failed twice
+	dispatch := &domain.WebhookDispatch{Delivery: domain.WebhookDelivery{ID: 5, Attempts: 2, Payload: []byte(`{}`)}, URL: receiver.URL}
+
+	// Mock behavior
+	mockRepo.On("ClaimDeliveries", now, DefaultBatchSize, mock.AnythingOfType("time.Duration")).Return([]*domain.WebhookDispatch{dispatch}, nil)
+	mockRepo.On("MarkFailed", 5, http.StatusServiceUnavailable, "endpoint answered 503 Service Unavailable",
+		mock.MatchedBy(func(next *time.Time) bool { return next != nil && next.Equal(now.Add(4*time.Minute)) })).Return(nil)
+
+	// Execute
+	_, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchOnce_DeadLettersAfterMaxAttempts(t *testing.T) {
+	// Setup
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	now := time.Now()
+	mockRepo := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(mockRepo, WithHTTPClient(receiver.Client()), WithClock(func() time.Time { return now }), WithMaxAttempts(3))
+
+	// Mock data: this is the third attempt
+	dispatch := &domain.WebhookDispatch{Delivery: domain.WebhookDelivery{ID: 6, Attempts: 2, Payload: []byte(`{}`)}, URL: receiver.URL}
+
+	// Mock behavior
+	mockRepo.On("ClaimDeliveries", now, DefaultBatchSize, mock.AnythingOfType("time.Duration")).Return([]*domain.WebhookDispatch{dispatch}, nil)
+	mockRepo.On("MarkFailed", 6, http.StatusInternalServerError, mock.AnythingOfType("string"), (*time.Time)(nil)).Return(nil)
+
+	// Execute
+	_, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchOnce_RefusesPrivateTarget(t *testing.T) {
+	// Setup: the default client must not reach a receiver on the loopback interface
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	now := time.Now()
+	mockRepo := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(mockRepo, WithClock(func() time.Time { return now }))
+
+	// Mock data
+	dispatch := &domain.WebhookDispatch{Delivery: domain.WebhookDelivery{ID: 7, Payload: []byte(`{}`)}, URL: receiver.URL}
+
+	// Mock behavior
+	mockRepo.On("ClaimDeliveries", now, DefaultBatchSize, mock.AnythingOfType("time.Duration")).Return([]*domain.WebhookDispatch{dispatch}, nil)
+	mockRepo.On("MarkFailed", 7, 0, mock.MatchedBy(func(reason string) bool {
+		return strings.Contains(reason, ErrForbiddenTarget.Error())
+	}), mock.AnythingOfType("*time.Time")).Return(nil)
+
+	// Execute
+	_, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, called)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchOnce_DoesNotFollowRedirects(t *testing.T) {
+	// Setup
+	redirected := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	now := time.Now()
+	client := NewHTTPClient(time.Second)
+	client.Transport = receiver.Client().Transport // Allow the loopback receiver, keep the redirect policy
+	mockRepo := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(mockRepo, WithHTTPClient(client), WithClock(func() time.Time { return now }))
+
+	// Mock data
+	dispatch := &domain.WebhookDispatch{Delivery: domain.WebhookDelivery{ID: 8, Payload: []byte(`{}`)}, URL: receiver.URL + "/hook"}
+
+	// Mock behavior
+	mockRepo.On("ClaimDeliveries", now, DefaultBatchSize, mock.AnythingOfType("time.Duration")).Return([]*domain.WebhookDispatch{dispatch}, nil)
+	mockRepo.On("MarkFailed", 8, http.StatusTemporaryRedirect, mock.AnythingOfType("string"), mock.AnythingOfType("*time.Time")).Return(nil)
+
+	// Execute
+	_, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.False(t, redirected)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIsAllowedHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{"hooks.acme.test", true},
+		{"203.0.113.10", true},
+		{"localhost", false},
+		{"api.localhost", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"10.0.0.5", false},
+		{"192.168.1.1", false},
+		{"172.16.0.1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, IsAllowedHost(tt.host))
+		})
+	}
+}
+
+func TestFanout_QueuesForInterestedSubscriptions(t *testing.T) {
+	// Setup
+	mockRepo := new(repository.MockWebhookRepository)
+	fanout := NewFanout(mockRepo)
+
+	// Mock data
+	subs := []*domain.WebhookSubscription{
+		{ID: 1, Active: true},
+		{ID: 2, Active: true, Events: []string{domain.EventJobClosed}},
+		{ID: 3, Active: true, Events: []string{domain.EventJobPublished}},
+	}
+
+	// Mock behavior
+	mockRepo.On("FindActiveSubscriptions").Return(subs, nil)
+	mockRepo.On("EnqueueDeliveries", mock.MatchedBy(func(deliveries []*domain.WebhookDelivery) bool {
+		return len(deliveries) == 2 && deliveries[0].SubscriptionID == 1 && deliveries[1].SubscriptionID == 3 &&
+			deliveries[0].EventID == "e9"
+	})).Return(nil)
+
+	// Execute
+	err := fanout.Publish(context.Background(), domain.Event{ID: "e9", Type: domain.EventJobPublished})
+
+	// Assertions
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
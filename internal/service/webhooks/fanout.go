@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/repository"
+)
+
+// Fanout turns each published event into one queued delivery per interested subscription
+// It implements events.EventPublisher so the outbox relay feeds it; the relay retries the event
+// if queuing fails, and deliveries already queued for the event are not duplicated.
+type Fanout struct {
+	repo repository.WebhookRepository // Subscriptions and delivery queue
+	now  func() time.Time             // Clock, replaced in tests
+}
+
+// NewFanout creates a new Fanout
+// @param repo repository.WebhookRepository - The repository holding subscriptions and deliveries
+// @return *Fanout - The fanout
+func NewFanout(repo repository.WebhookRepository) *Fanout {
+	return &Fanout{repo: repo, now: time.Now}
+}
+
+// Publish queues the event for every active subscription accepting its type
+// @param ctx context.Context - Unused
+// @param event domain.Event - The event to deliver
+// @return error - An error if the subscriptions cannot be read or the deliveries stored
+func (f *Fanout) Publish(_ context.Context, event domain.Event) error {
+	subs, err := f.repo.FindActiveSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := f.now()
+	var deliveries []*domain.WebhookDelivery
+	for _, sub := range subs {
+		if !sub.Accepts(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        body,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	return f.repo.EnqueueDeliveries(deliveries)
+}
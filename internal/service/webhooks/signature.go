@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every webhook request
+const (
+	HeaderDelivery  = "X-Webhook-Delivery"  // ID of the delivery; identical on every retry
+	HeaderEvent     = "X-Webhook-Event"     // Event type (e.g., "job.created")
+	HeaderEventID   = "X-Webhook-Event-ID"  // ID of the event, used to discard duplicates
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix time the request was signed at
+	HeaderSignature = "X-Webhook-Signature" // "sha256=" followed by the hex HMAC of the signed content
+)
+
+// signaturePrefix names the algorithm in the signature header
+const signaturePrefix = "sha256="
+
+// Sign computes the signature header value of a webhook request
+// The signed content is the timestamp, a dot and the raw body, so a captured request
+// cannot be replayed with another timestamp.
+// @param secret string - The subscription secret
+// @param timestamp time.Time - The signing time, sent in HeaderTimestamp
+// @param body []byte - The request body
+// @return string - The value of HeaderSignature
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a received webhook request
+// Receivers can use it as reference implementation.
+// @param secret string - The subscription secret
+// @param timestamp string - The value of HeaderTimestamp
+// @param signature string - The value of HeaderSignature
+// @param body []byte - The raw request body
+// @param tolerance time.Duration - Maximum age of the request, 0 to skip the check
+// @param now time.Time - The current time
+// @return bool - True if the signature is valid and the request is recent enough
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	signedAt := time.Unix(unix, 0)
+	if tolerance > 0 && (now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, signedAt, body)), []byte(signature))
+}
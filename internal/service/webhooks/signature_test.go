@@ -0,0 +1,20 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"e1"}`)
+	signature := Sign("secret", now, body)
+
+	assert.True(t, Verify("secret", "1700000000", signature, body, time.Minute, now))
+	assert.False(t, Verify("other", "1700000000", signature, body, time.Minute, now), "wrong secret")
+	assert.False(t, Verify("secret", "1700000000", signature, []byte(`{"id":"e2"}`), time.Minute, now), "tampered body")
+	assert.False(t, Verify("secret", "1700000001", signature, body, time.Minute, now), "replayed with another timestamp")
+	assert.False(t, Verify("secret", "1700000000", signature, body, time.Minute, now.Add(time.Hour)), "too old")
+}
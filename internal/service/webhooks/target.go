@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenTarget is returned when a webhook endpoint resolves to an address of the service's own network
+var ErrForbiddenTarget = errors.New("webhook endpoint resolves to a loopback, link-local or private address")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), private in practice but not covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicAddress reports whether webhooks may be sent to an IP address
+// Loopback, link-local (including the cloud metadata endpoint 169.254.169.254), private,
+// shared, unspecified and multicast addresses are refused.
+// @param ip net.IP - The address
+// @return bool - True if the address is a public unicast address
+func IsPublicAddress(ip net.IP) bool {
+	return ip != nil && ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// IsAllowedHost reports whether the host of a webhook URL may be subscribed
+// IP literals must be public and "localhost" names are refused. Other names are accepted here;
+// the address they resolve to is checked when the dispatcher connects, so DNS rebinding is covered.
+// @param host string - The host of the URL, without port
+// @return bool - True if the host may be subscribed
+func IsAllowedHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicAddress(ip)
+	}
+	return true
+}
+
+// NewHTTPClient creates the client used to call the webhook endpoints
+// It only connects to public addresses, checked on the resolved address of every connection,
+// ignores the proxy settings of the environment and does not follow redirects, so a 3xx answer
+// counts as a failed attempt.
+// @param timeout time.Duration - The timeout of a whole request
+// @return *http.Client - The client
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: denyNonPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// denyNonPublic is a net.Dialer Control hook refusing connections to non-public addresses
+func denyNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !IsPublicAddress(net.ParseIP(host)) {
+		return ErrForbiddenTarget
+	}
+	return nil
+}
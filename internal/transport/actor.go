@@ -0,0 +1,20 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/requestid"
+)
+
+// actorContext returns the request context carrying the authenticated subject, the request ID and the client IP
+// Services record this actor in the audit log.
+func actorContext(c *gin.Context) context.Context {
+	return domain.ContextWithActor(c.Request.Context(), domain.Actor{
+		Subject:   jwtUtil.Subject(c),
+		RequestID: requestid.Get(c),
+		IP:        c.ClientIP(),
+	})
+}
@@ -0,0 +1,166 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+)
+
+// APIKeyHandler handles HTTP requests related to API keys
+// This struct acts as the controller for the admin endpoints issuing, rotating and revoking API keys.
+type APIKeyHandler struct {
+	service service.APIKeyService // Dependency on APIKeyService for business logic
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+// This is a constructor function to initialize the APIKeyHandler with an APIKeyService dependency.
+func NewAPIKeyHandler(service service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// IssueKey handles the creation of an API key
+// @Summary Issue an API key
+// @Description Create an API key for a machine client, sent in the X-API-Key header instead of a bearer token.
+// @Description The key is only returned in this response; store it now, it cannot be retrieved again.
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Param request body domain.APIKeyRequest true "Name, scopes and optional expiry"
+// @Success 201 {object} domain.IssuedAPIKey "API key issued, with its secret"
+// @Failure 400 {object} map[string]string "Invalid name, scope or expiry"
+// @Failure 500 {object} map[string]string "Failed to issue API key"
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) IssueKey(c *gin.Context) {
+	var req domain.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, err := h.service.IssueKey(actorContext(c), req)
+	if err != nil {
+		respondError(c, err, "failed to issue api key")
+		return
+	}
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListKeys handles the retrieval of every API key
+// @Summary List API keys
+// @Description Retrieve every API key, revoked ones included, without their secrets
+// @Tags API keys
+// @Produce json
+// @Success 200 {array} domain.APIKey "List of API keys"
+// @Failure 500 {object} map[string]string "Failed to fetch API keys"
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	keys, err := h.service.ListKeys()
+	if err != nil {
+		respondError(c, err, "failed to fetch api keys")
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// RotateKey handles the replacement of the secret of an API key
+// @Summary Rotate an API key
+// @Description Issue a new secret for an API key, keeping its name and scopes. The previous secret keeps working
+// @Description for the grace period (0 to 168h, default 0) so clients can be updated without downtime.
+// @Tags API keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Param grace query string false "Grace period of the previous secret, as a Go duration (e.g., 1h)"
+// @Success 200 {object} domain.IssuedAPIKey "API key rotated, with its new secret"
+// @Failure 400 {object} map[string]string "Invalid API key ID or grace period"
+// @Failure 404 {object} map[string]string "API key not found or revoked"
+// @Failure 500 {object} map[string]string "Failed to rotate API key"
+// @Router /admin/api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateKey(c *gin.Context) {
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+	var grace time.Duration
+	if raw := c.Query("grace"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid grace period"})
+			return
+		}
+		grace = parsed
+	}
+
+	key, err := h.service.RotateKey(actorContext(c), id, grace)
+	if err != nil {
+		respondError(c, err, "failed to rotate api key")
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// RevokeKey handles the revocation of an API key
+// @Summary Revoke an API key
+// @Description Disable an API key immediately, including a previous secret still in its grace period
+// @Tags API keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} map[string]string "API key revoked successfully"
+// @Failure 400 {object} map[string]string "Invalid API key ID"
+// @Failure 404 {object} map[string]string "API key not found or already revoked"
+// @Failure 500 {object} map[string]string "Failed to revoke API key"
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeKey(actorContext(c), id); err != nil {
+		respondError(c, err, "failed to revoke api key")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "api key revoked successfully"})
+}
+
+// parseAPIKeyID reads the id path parameter, responding 400 when it is not a positive integer
+func parseAPIKeyID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return 0, false
+	}
+	return id, true
+}
+
+// apiKeyAuthenticator adapts an APIKeyService to the authentication middleware
+type apiKeyAuthenticator struct {
+	service service.APIKeyService
+}
+
+// NewAPIKeyAuthenticator lets jwtUtil.Middleware accept the API keys of the given service
+// Authenticated keys become principals with the subject "apikey:<id>" and the scopes they were issued with.
+func NewAPIKeyAuthenticator(service service.APIKeyService) jwtUtil.KeyAuthenticator {
+	return &apiKeyAuthenticator{service: service}
+}
+
+// AuthenticateKey resolves an API key to its principal
+func (a *apiKeyAuthenticator) AuthenticateKey(key string) (*jwtUtil.Principal, error) {
+	apiKey, err := a.service.Authenticate(key)
+	if err != nil {
+		return nil, err
+	}
+	scopes := apiKey.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &jwtUtil.Principal{
+		Subject: "apikey:" + strconv.Itoa(apiKey.ID),
+		Scopes:  scopes,
+		Kind:    jwtUtil.KindAPIKey,
+	}, nil
+}
@@ -0,0 +1,236 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const apiKeyTestSecret = "api-key-test-secret"
+
+func TestIssueKey(t *testing.T) {
+	// Setup
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	apiKeyHandler := NewAPIKeyHandler(mockAPIKeyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/api-keys", withClaims(jwt.MapClaims{"sub": "admin-1", "role": "admin"}), apiKeyHandler.IssueKey)
+
+	// Mock behavior: the key is issued by the token subject
+	issued := &domain.IssuedAPIKey{APIKey: domain.APIKey{ID: 1, Name: "ATS", Scopes: []string{domain.ScopeJobsRead}}, Key: "jk_abc_def"}
+	mockAPIKeyService.On("IssueKey", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.ActorFromContext(ctx).Subject == "admin-1"
+	}), domain.APIKeyRequest{Name: "ATS", Scopes: []string{domain.ScopeJobsRead}}).Return(issued, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/admin/api-keys", bytes.NewBufferString(`{"name":"ATS","scopes":["jobs:read"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"key":"jk_abc_def"`)
+	assert.NotContains(t, rec.Body.String(), "KeyHash")
+	mockAPIKeyService.AssertExpectations(t)
+}
+
+func TestRotateKey(t *testing.T) {
+	// Setup
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	apiKeyHandler := NewAPIKeyHandler(mockAPIKeyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/api-keys/:id/rotate", apiKeyHandler.RotateKey)
+
+	// Mock behavior
+	issued := &domain.IssuedAPIKey{APIKey: domain.APIKey{ID: 4}, Key: "jk_new_secret"}
+	mockAPIKeyService.On("RotateKey", mock.Anything, 4, 90*time.Minute).Return(issued, nil)
+	mockAPIKeyService.On("RotateKey", mock.Anything, 5, time.Duration(0)).Return(nil, domain.ErrAPIKeyNotFound)
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/admin/api-keys/4/rotate?grace=90m", http.StatusOK},
+		{"/admin/api-keys/5/rotate", http.StatusNotFound},
+		{"/admin/api-keys/4/rotate?grace=soon", http.StatusBadRequest},
+		{"/admin/api-keys/abc/rotate", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		// Execute
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+
+		// Assertions
+		assert.Equal(t, tt.want, rec.Code, tt.target)
+	}
+	mockAPIKeyService.AssertExpectations(t)
+}
+
+func TestRevokeKey_NotFound(t *testing.T) {
+	// Setup
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	apiKeyHandler := NewAPIKeyHandler(mockAPIKeyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.DELETE("/admin/api-keys/:id", apiKeyHandler.RevokeKey)
+
+	// Mock behavior
+	mockAPIKeyService.On("RevokeKey", mock.Anything, 9).Return(domain.ErrAPIKeyNotFound)
+
+	// Execute
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/api-keys/9", nil))
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockAPIKeyService.AssertExpectations(t)
+}
+
+// newAuthTestRouter serves a read and a write route guarded like the job routes in main
+func newAuthTestRouter(apiKeyService service.APIKeyService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	auth := jwtUtil.Middleware(newTestVerifier(apiKeyTestSecret), NewAPIKeyAuthenticator(apiKeyService))
+	whoami := func(c *gin.Context) {
+		principal := jwtUtil.PrincipalFrom(c)
+		c.JSON(http.StatusOK, gin.H{"subject": jwtUtil.Subject(c), "kind": principal.Kind})
+	}
+	router.GET("/jobs", auth, jwtUtil.RequireScope(domain.ScopeJobsRead), whoami)
+	router.POST("/jobs", auth, jwtUtil.RequireScope(domain.ScopeJobsWrite), whoami)
+	router.GET("/admin", auth, jwtUtil.RequireRole("admin"), whoami)
+	return router
+}
+
+func TestAuth_APIKey(t *testing.T) {
+	// Setup
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	router := newAuthTestRouter(mockAPIKeyService)
+
+	// Mock behavior: a read-only key
+	mockAPIKeyService.On("Authenticate", "jk_read").Return(&domain.APIKey{ID: 7, Scopes: []string{domain.ScopeJobsRead}}, nil)
+	mockAPIKeyService.On("Authenticate", "jk_bad").Return(nil, domain.ErrInvalidAPIKey)
+
+	tests := []struct {
+		method, target, key string
+		want                int
+	}{
+		{http.MethodGet, "/jobs", "jk_read", http.StatusOK},
+		{http.MethodPost, "/jobs", "jk_read", http.StatusForbidden},
+		{http.MethodGet, "/admin", "jk_read", http.StatusForbidden},
+		{http.MethodGet, "/jobs", "jk_bad", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		// Prepare HTTP request
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+		req.Header.Set(jwtUtil.APIKeyHeader, tt.key)
+		rec := httptest.NewRecorder()
+
+		// Execute
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, tt.want, rec.Code, tt.method+" "+tt.target+" "+tt.key)
+		if rec.Code == http.StatusOK {
+			assert.JSONEq(t, `{"subject":"apikey:7","kind":"api_key"}`, rec.Body.String())
+		}
+	}
+}
+
+func TestAuth_BearerToken(t *testing.T) {
+	// Setup
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	router := newAuthTestRouter(mockAPIKeyService)
+	token, _ := jwtUtil.GenerateToken(apiKeyTestSecret, jwt.MapClaims{"sub": "recruiter-1", "exp": time.Now().Add(time.Hour).Unix()})
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		// Prepare HTTP request
+		req := httptest.NewRequest(method, "/jobs", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		// Execute
+		router.ServeHTTP(rec, req)
+
+		// Assertions: users are not restricted by scopes
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"subject":"recruiter-1","kind":"user"}`, rec.Body.String())
+	}
+
+	// A token listing its scopes is restricted to them, like an API key
+	readOnly, _ := jwtUtil.GenerateToken(apiKeyTestSecret, jwt.MapClaims{"sub": "7", "role": "user", "scope": domain.ScopeJobsRead, "exp": time.Now().Add(time.Hour).Unix()})
+	for method, want := range map[string]int{http.MethodGet: http.StatusOK, http.MethodPost: http.StatusForbidden} {
+		req := httptest.NewRequest(method, "/jobs", nil)
+		req.Header.Set("Authorization", "Bearer "+readOnly)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, method)
+	}
+
+	// Without any credential
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockAPIKeyService.AssertNotCalled(t, "Authenticate", mock.Anything)
+}
+
+func TestAuth_APIKeyWebhookScopes(t *testing.T) {
+	// Setup: the webhook routes guarded like in main
+	mockAPIKeyService := new(service.MockAPIKeyService)
+	mockWebhookService := new(service.MockWebhookService)
+	webhookHandler := NewWebhookHandler(mockWebhookService)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	hooks := router.Group("/webhooks", jwtUtil.Middleware(newTestVerifier(apiKeyTestSecret), NewAPIKeyAuthenticator(mockAPIKeyService)))
+	hooks.POST("", jwtUtil.RequireScope(domain.ScopeJobsWrite), webhookHandler.CreateSubscription)
+	hooks.GET("", jwtUtil.RequireScope(domain.ScopeJobsRead), webhookHandler.ListSubscriptions)
+	hooks.DELETE("/:id", jwtUtil.RequireScope(domain.ScopeJobsWrite), webhookHandler.DeleteSubscription)
+	hooks.POST("/:id/deliveries/:delivery_id/redeliver", jwtUtil.RequireScope(domain.ScopeJobsWrite), webhookHandler.Redeliver)
+
+	// Mock behavior: a read-only key may list its subscriptions but not change them
+	mockAPIKeyService.On("Authenticate", "jk_read").Return(&domain.APIKey{ID: 7, Scopes: []string{domain.ScopeJobsRead}}, nil)
+	mockWebhookService.On("ListSubscriptions", mock.Anything).Return([]*domain.WebhookSubscription{}, nil)
+
+	tests := []struct {
+		method, target string
+		want           int
+	}{
+		{http.MethodGet, "/webhooks", http.StatusOK},
+		{http.MethodPost, "/webhooks", http.StatusForbidden},
+		{http.MethodDelete, "/webhooks/1", http.StatusForbidden},
+		{http.MethodPost, "/webhooks/1/deliveries/2/redeliver", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		// Prepare HTTP request
+		req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(`{"url":"https://acme.test/hook"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(jwtUtil.APIKeyHeader, "jk_read")
+		rec := httptest.NewRecorder()
+
+		// Execute
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, tt.want, rec.Code, tt.method+" "+tt.target)
+	}
+	mockWebhookService.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	mockWebhookService.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything)
+	mockWebhookService.AssertNotCalled(t, "Redeliver", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// AuditHandler handles HTTP requests related to the audit log
+// This struct acts as the controller for reading the recorded job mutations.
+type AuditHandler struct {
+	service service.AuditService // Dependency on AuditService for business logic
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+// This is a constructor function to initialize the AuditHandler with an AuditService dependency.
+func NewAuditHandler(service service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetJobHistory handles the retrieval of the change history of a job
+// @Summary Get the history of a job
+// @Description Retrieve who changed a job, when and what, newest first. Deleted jobs keep their history.
+// @Tags Audit
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {array} domain.AuditEntry "Audit entries of the job"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 500 {object} map[string]string "Failed to fetch job history"
+// @Router /jobs/{id}/history [get]
+func (h *AuditHandler) GetJobHistory(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.GetJobHistory(id)
+	if err != nil {
+		respondError(c, err, "failed to fetch job history")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// SearchAuditLog handles queries over the whole audit log
+// @Summary Search the audit log
+// @Description Retrieve audit entries filtered by entity, action, actor, request and time range, newest first.
+// @Description Requires the admin role.
+// @Tags Audit
+// @Produce json
+// @Param entity_type query string false "Filter by entity type" Enums(job, user)
+// @Param entity_id query int false "Filter by entity ID"
+// @Param action query string false "Filter by action" Enums(create, update, status_change, delete, restore, login_failed, lockout, unlock)
+// @Param actor query string false "Filter by actor"
+// @Param request_id query string false "Filter by request ID"
+// @Param from query string false "Only entries recorded at or after this instant (RFC 3339)"
+// @Param to query string false "Only entries recorded before this instant (RFC 3339)"
+// @Param limit query int false "Maximum number of entries (default 50, max 500)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {array} domain.AuditEntry "Matching audit entries"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Failed to fetch audit log"
+// @Router /admin/audit [get]
+func (h *AuditHandler) SearchAuditLog(c *gin.Context) {
+	var filter domain.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.service.SearchAuditLog(filter)
+	if err != nil {
+		respondError(c, err, "failed to fetch audit log")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/poolcamacho/jobs-service/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetJobHistory(t *testing.T) {
+	// Setup
+	mockAuditService := new(service.MockAuditService)
+	auditHandler := NewAuditHandler(mockAuditService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/:id/history", auditHandler.GetJobHistory)
+
+	// Mock behavior
+	entries := []*domain.AuditEntry{{ID: 1, EntityType: domain.AuditEntityJob, EntityID: 9, Action: domain.AuditActionCreate}}
+	mockAuditService.On("GetJobHistory", 9).Return(entries, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/9/history", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"action":"create"`)
+	mockAuditService.AssertExpectations(t)
+}
+
+func TestSearchAuditLog_BindsFilter(t *testing.T) {
+	// Setup
+	mockAuditService := new(service.MockAuditService)
+	auditHandler := NewAuditHandler(mockAuditService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/admin/audit", auditHandler.SearchAuditLog)
+
+	// Mock behavior
+	mockAuditService.On("SearchAuditLog", mock.MatchedBy(func(filter domain.AuditFilter) bool {
+		return filter.Actor == "user-7" && filter.Action == domain.AuditActionDelete && filter.From.Year() == 2024
+	})).Return([]*domain.AuditEntry{}, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?actor=user-7&action=delete&from=2024-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockAuditService.AssertExpectations(t)
+}
+
+func TestChangeJobStatus_PassesActor(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(requestid.Middleware())
+	router.PATCH("/jobs/:id/status", withClaims(jwt.MapClaims{"sub": "user-7"}), jobHandler.ChangeJobStatus)
+
+	// Mock behavior: the actor is taken from the token and the X-Request-ID header
+	mockJobService.On("ChangeJobStatus", mock.MatchedBy(func(ctx context.Context) bool {
+		actor := domain.ActorFromContext(ctx)
+		return actor.Subject == "user-7" && actor.RequestID == "req-42"
+	}), 6, domain.JobStatusClosed, 3).Return(nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPatch, "/jobs/6/status", bytes.NewBufferString(`{"status":"closed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(requestid.Header, "req-42")
+	req.Header.Set("If-Match", `"3"`)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "req-42", rec.Header().Get(requestid.Header))
+	mockJobService.AssertExpectations(t)
+}
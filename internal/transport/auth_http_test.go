@@ -0,0 +1,127 @@
+package transport
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestVerifier returns a verifier accepting HMAC tokens signed with secret
+func newTestVerifier(secret string) *jwtUtil.Verifier {
+	verifier, err := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret(secret))
+	if err != nil {
+		panic(err)
+	}
+	return verifier
+}
+
+// writeJWKS writes a JWKS document holding the given keys, by kid, to path
+func writeJWKS(t *testing.T, path string, keys map[string]any) {
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+				"n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": encode(key)})
+		}
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// signToken signs claims (by default a subject and an expiry) with the given method and key, setting the kid header
+func signToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims ...jwt.MapClaims) string {
+	payload := jwt.MapClaims{"sub": "recruiter-1", "exp": time.Now().Add(time.Hour).Unix()}
+	if len(claims) > 0 {
+		payload = claims[0]
+	}
+	token := jwt.NewWithClaims(method, payload)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// requestWithToken sends a GET /jobs with the given bearer token and returns the status
+func requestWithToken(router *gin.Engine, token string) int {
+	req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// newVerifierTestRouter serves GET /jobs behind Middleware with the given verifier
+func newVerifierTestRouter(verifier *jwtUtil.Verifier) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/jobs", jwtUtil.Middleware(verifier, nil), func(c *gin.Context) {
+		c.String(http.StatusOK, jwtUtil.Subject(c))
+	})
+	return router
+}
+
+func TestAuth_JWKS(t *testing.T) {
+	// Setup: the signature rules themselves are covered in pkg/jwt
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"rsa-1": &rsaKey.PublicKey, "ed-1": edPublic})
+
+	keys, err := jwtUtil.NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	verifier, err := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret("shared-secret"), jwtUtil.WithKeySet(keys))
+	require.NoError(t, err)
+	router := newVerifierTestRouter(verifier)
+
+	// Execute and assert
+	assert.Equal(t, http.StatusOK, requestWithToken(router, signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1")))
+	assert.Equal(t, http.StatusOK, requestWithToken(router, signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), "")))
+	assert.Equal(t, http.StatusUnauthorized, requestWithToken(router, signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-9")))
+}
+
+func TestAuth_SanitisedError(t *testing.T) {
+	// Setup
+	router := newVerifierTestRouter(newTestVerifier("shared-secret"))
+	expired := signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), "",
+		jwt.MapClaims{"sub": "recruiter-1", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+expired)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions: the reason is only logged
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Invalid token"}`, rec.Body.String())
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,82 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+)
+
+// CachePolicy holds the Cache-Control directives sent with the cacheable job responses
+type CachePolicy struct {
+	Public        string // For requests without credentials (e.g., the feeds fetched by job boards)
+	Authenticated string // For authenticated requests or ones carrying credentials; must keep shared caches from storing them
+}
+
+// DefaultCachePolicy lets proxies reuse public responses for five minutes and makes
+// browsers revalidate authenticated ones on every use
+var DefaultCachePolicy = CachePolicy{
+	Public:        "public, max-age=300",
+	Authenticated: "private, no-cache",
+}
+
+// directive returns the Cache-Control value for the request
+// A request counts as authenticated when the auth middleware resolved a principal, or when it carries
+// a bearer token or an API key, so the public routes never let shared caches store a per-client response.
+func (p CachePolicy) directive(c *gin.Context) string {
+	if jwtUtil.PrincipalFrom(c) != nil || c.GetHeader("Authorization") != "" || c.GetHeader(jwtUtil.APIKeyHeader) != "" {
+		return p.Authenticated
+	}
+	return p.Public
+}
+
+// setValidators sets the ETag, Last-Modified and Cache-Control headers of a response
+// A zero lastModified or an empty cacheControl leaves the header out.
+func setValidators(c *gin.Context, etag string, lastModified time.Time, cacheControl string) {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+}
+
+// notModified reports whether the client already holds the current representation
+// If-None-Match is evaluated when present; If-Modified-Since only otherwise, and only for GET and HEAD.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	// HTTP dates have a one second resolution
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// writeConditionalJSON answers with a JSON value and a weak ETag hashed from it, or 304 when the client already has it
+// The ETag is weak because it covers the JSON encoding rather than the exact bytes a proxy may serve (e.g., compressed).
+func writeConditionalJSON(c *gin.Context, value any, lastModified time.Time, cacheControl string) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode response"})
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	setValidators(c, etag, lastModified, cacheControl)
+	if notModified(c, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, gin.MIMEJSON+"; charset=utf-8", body)
+}
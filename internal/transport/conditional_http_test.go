@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConditionalJobRouter(mockJobService *service.MockJobService, opts ...JobHandlerOption) *gin.Engine {
+	jobHandler := NewJobHandler(mockJobService, opts...)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs", jobHandler.GetJobs)
+	router.GET("/jobs/:id", jobHandler.GetJobByID)
+	return router
+}
+
+// getWithHeaders sends a GET request with the given headers
+func getWithHeaders(router *gin.Engine, target string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetJobs_ConditionalGet(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newConditionalJobRouter(mockJobService)
+	updated := time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+
+	// Mock behavior
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{
+		{ID: 1, Title: "Developer", UpdatedAt: updated.Add(-time.Hour)},
+		{ID: 2, Title: "Designer", UpdatedAt: updated},
+	}, nil)
+
+	// Execute
+	first := getWithHeaders(router, "/jobs", nil)
+	etag := first.Header().Get("ETag")
+	byETag := getWithHeaders(router, "/jobs", map[string]string{"If-None-Match": etag})
+	byDate := getWithHeaders(router, "/jobs", map[string]string{"If-Modified-Since": "Wed, 01 May 2024 12:30:15 GMT"})
+	stale := getWithHeaders(router, "/jobs", map[string]string{"If-None-Match": `W/"other"`, "If-Modified-Since": "Wed, 01 May 2024 12:30:15 GMT"})
+
+	// Assertions: listings have no Last-Modified, as a job leaving the list would not move it forward
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Empty(t, first.Header().Get("Last-Modified"))
+	assert.Equal(t, DefaultCachePolicy.Public, first.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusNotModified, byETag.Code)
+	assert.Empty(t, byETag.Body.String())
+	assert.Equal(t, etag, byETag.Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, byDate.Code, "If-Modified-Since alone never matches a listing")
+	assert.Equal(t, http.StatusOK, stale.Code)
+}
+
+func TestGetJobs_ETagChangesWithContent(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newConditionalJobRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1, Version: 1}}, nil).Once()
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1, Version: 2}}, nil).Once()
+
+	// Execute
+	first := getWithHeaders(router, "/jobs", nil)
+	second := getWithHeaders(router, "/jobs", map[string]string{"If-None-Match": first.Header().Get("ETag")})
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.NotEqual(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+}
+
+func TestGetJobByID_ConditionalGet(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newConditionalJobRouter(mockJobService, WithCachePolicy(CachePolicy{Public: "public, max-age=60", Authenticated: "private, max-age=10"}))
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Mock behavior
+	mockJobService.On("GetJobByID", 5).Return(&domain.Job{ID: 5, Version: 4, UpdatedAt: updated}, nil)
+
+	// Execute
+	notModified := getWithHeaders(router, "/jobs/5", map[string]string{"If-None-Match": `W/"4"`, "Authorization": "Bearer token"})
+	modified := getWithHeaders(router, "/jobs/5", map[string]string{"If-Modified-Since": "Wed, 01 May 2024 11:59:59 GMT"})
+
+	// Assertions
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Equal(t, `"4"`, notModified.Header().Get("ETag"))
+	assert.Equal(t, "private, max-age=10", notModified.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusOK, modified.Code)
+	assert.Equal(t, "public, max-age=60", modified.Header().Get("Cache-Control"))
+	assert.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", modified.Header().Get("Last-Modified"))
+}
+
+func TestCachePolicy_APIKeyAndPrincipal(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs", jobHandler.GetJobs)
+	router.GET("/authenticated/jobs", func(c *gin.Context) {
+		c.Set("principal", &jwtUtil.Principal{Subject: "apikey:3", Kind: jwtUtil.KindAPIKey})
+		c.Next()
+	}, jobHandler.GetJobs)
+
+	// Mock behavior
+	mockJobService.On("GetAllJobs").Return([]*domain.Job{{ID: 1}}, nil)
+
+	// Execute
+	anonymous := getWithHeaders(router, "/jobs", nil)
+	withKey := getWithHeaders(router, "/jobs", map[string]string{jwtUtil.APIKeyHeader: "jsk_3_secret"})
+	withPrincipal := getWithHeaders(router, "/authenticated/jobs", nil)
+
+	// Assertions
+	assert.Equal(t, DefaultCachePolicy.Public, anonymous.Header().Get("Cache-Control"))
+	assert.Equal(t, DefaultCachePolicy.Authenticated, withKey.Header().Get("Cache-Control"))
+	assert.Equal(t, DefaultCachePolicy.Authenticated, withPrincipal.Header().Get("Cache-Control"))
+}
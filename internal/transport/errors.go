@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// respondError maps a service error to an HTTP error response
+// Validation errors become 400, rejected credentials 401, missing resources 404, duplicates and requests still in progress 409,
+// stale versions 412, reused idempotency keys 422 and throttled logins 429 with a Retry-After header;
+// anything else is reported as 500 with the given fallback message.
+func respondError(c *gin.Context, err error, fallback string) {
+	var validationErr *domain.ValidationError
+	var throttledErr *domain.LoginThrottledError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error()})
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrInvalidRefreshToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrJobNotFound), errors.Is(err, domain.ErrTaxonomyTermNotFound),
+		errors.Is(err, domain.ErrSkillNotFound), errors.Is(err, domain.ErrWebhookNotFound),
+		errors.Is(err, domain.ErrDeliveryNotFound), errors.Is(err, domain.ErrAPIKeyNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTaxonomyTermExists), errors.Is(err, domain.ErrSkillExists),
+		errors.Is(err, domain.ErrRequestInProgress), errors.Is(err, domain.ErrUsernameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.As(err, &throttledErr):
+		c.Header("Retry-After", ceilSeconds(throttledErr.RetryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": throttledErr.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+)
+
+// jobETag returns the strong ETag of a job version, sent back in If-Match to update the job
+func jobETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// jobRepresentationETag returns the ETag of a job version in one of its representations
+// The JSON representation carries the strong ETag sent back in If-Match. The JSON-LD one gets a
+// weak validator of its own, so a cache holding the JSON one never revalidates it for an
+// application/ld+json request, and it cannot be used to update the job.
+func jobRepresentationETag(version int, jsonLD bool) string {
+	if jsonLD {
+		return `W/"` + strconv.Itoa(version) + `-ld"`
+	}
+	return jobETag(version)
+}
+
+// ifMatchVersion reads the job version a write is conditioned on from the If-Match header
+// It answers 428 when the header is missing and 412 when it is not a job ETag, returning false.
+func ifMatchVersion(c *gin.Context, fallback string) (int, bool) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return 0, false
+	}
+	version, ok := parseJobETag(ifMatch)
+	if !ok {
+		respondError(c, domain.ErrVersionConflict, fallback)
+		return 0, false
+	}
+	return version, true
+}
+
+// parseJobETag reads the job version from an If-Match header
+// "*" gives version 0, which updates the job whatever its version. Weak tags never match,
+// as If-Match uses the strong comparison.
+// It returns false if the header is not a job ETag.
+func parseJobETag(header string) (int, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+	if len(header) < 3 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
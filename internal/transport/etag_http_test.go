@@ -0,0 +1,193 @@
+package transport
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newVersionedJobRouter(mockJobService *service.MockJobService) *gin.Engine {
+	jobHandler := NewJobHandler(mockJobService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/:id", jobHandler.GetJobByID)
+	router.PUT("/jobs/:id", jobHandler.UpdateJob)
+	router.PATCH("/jobs/:id/status", jobHandler.ChangeJobStatus)
+	router.DELETE("/jobs/:id", jobHandler.DeleteJob)
+	return router
+}
+
+// putJob sends a job update with the given If-Match header, omitted when empty
+func putJob(router *gin.Engine, ifMatch string) *httptest.ResponseRecorder {
+	body := `{"title":"Designer","description":"Design things"}`
+	req := httptest.NewRequest(http.MethodPut, "/jobs/5", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetJobByID_ETag(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("GetJobByID", 5).Return(&domain.Job{ID: 5, Title: "Designer", Version: 4}, nil)
+
+	// Execute
+	req := httptest.NewRequest(http.MethodGet, "/jobs/5", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), `"version":4`)
+}
+
+func TestGetJobByID_ETagPerRepresentation(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("GetJobByID", 5).Return(&domain.Job{ID: 5, Title: "Designer", Version: 4}, nil)
+
+	// Execute: revalidate the JSON-LD representation with the ETag of the JSON one
+	req := httptest.NewRequest(http.MethodGet, "/jobs/5", nil)
+	req.Header.Set("Accept", "application/ld+json")
+	req.Header.Set("If-None-Match", `"4"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `W/"4-ld"`, rec.Header().Get("ETag"))
+}
+
+func TestUpdateJob_IfMatch(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Mock behavior: the service bumps the version it was given
+	mockJobService.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *domain.Job) bool {
+		return job.ID == 5 && job.Version == 4
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Job).Version = 5
+	}).Return(nil)
+
+	// Execute
+	rec := putJob(router, `"4"`)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+	mockJobService.AssertExpectations(t)
+}
+
+func TestUpdateJob_IfMatchFromGet(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("GetJobByID", 5).Return(&domain.Job{ID: 5, Title: "Designer", Version: 4}, nil)
+	mockJobService.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *domain.Job) bool {
+		return job.ID == 5 && job.Version == 4
+	})).Return(nil)
+
+	// Execute: read the job, then update it with the ETag of the read
+	read := httptest.NewRecorder()
+	router.ServeHTTP(read, httptest.NewRequest(http.MethodGet, "/jobs/5", nil))
+	rec := putJob(router, read.Header().Get("ETag"))
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockJobService.AssertExpectations(t)
+}
+
+func TestUpdateJob_VersionConflict(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("UpdateJob", mock.Anything, mock.Anything).Return(domain.ErrVersionConflict)
+
+	// Execute
+	rec := putJob(router, `"3"`)
+
+	// Assertions
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	mockJobService.AssertExpectations(t)
+}
+
+func TestUpdateJob_PreconditionHeader(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+
+	// Execute and assert: a missing header is required, a weak or malformed one never matches
+	assert.Equal(t, http.StatusPreconditionRequired, putJob(router, "").Code)
+	assert.Equal(t, http.StatusPreconditionFailed, putJob(router, `W/"3"`).Code)
+	assert.Equal(t, http.StatusPreconditionFailed, putJob(router, "3").Code)
+	mockJobService.AssertNotCalled(t, "UpdateJob", mock.Anything, mock.Anything)
+}
+
+func TestChangeJobStatusAndDeleteJob_IfMatch(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newVersionedJobRouter(mockJobService)
+	send := func(method, target, body, ifMatch string) int {
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Mock behavior: the job is at version 5
+	mockJobService.On("ChangeJobStatus", mock.Anything, 5, domain.JobStatusClosed, 4).Return(domain.ErrVersionConflict)
+	mockJobService.On("ChangeJobStatus", mock.Anything, 5, domain.JobStatusClosed, 5).Return(nil)
+	mockJobService.On("DeleteJob", mock.Anything, 5, 4).Return(domain.ErrVersionConflict)
+	mockJobService.On("DeleteJob", mock.Anything, 5, 0).Return(nil)
+
+	// Execute and assert: a missing header is required, a stale version is rejected
+	status := `{"status":"closed"}`
+	assert.Equal(t, http.StatusPreconditionRequired, send(http.MethodPatch, "/jobs/5/status", status, ""))
+	assert.Equal(t, http.StatusPreconditionFailed, send(http.MethodPatch, "/jobs/5/status", status, `"4"`))
+	assert.Equal(t, http.StatusOK, send(http.MethodPatch, "/jobs/5/status", status, `"5"`))
+	assert.Equal(t, http.StatusPreconditionRequired, send(http.MethodDelete, "/jobs/5", "", ""))
+	assert.Equal(t, http.StatusPreconditionFailed, send(http.MethodDelete, "/jobs/5", "", `W/"5"`))
+	assert.Equal(t, http.StatusPreconditionFailed, send(http.MethodDelete, "/jobs/5", "", `"4"`))
+	assert.Equal(t, http.StatusOK, send(http.MethodDelete, "/jobs/5", "", "*"))
+	mockJobService.AssertExpectations(t)
+}
+
+func TestParseJobETag(t *testing.T) {
+	version, ok := parseJobETag(` "12" `)
+	assert.True(t, ok)
+	assert.Equal(t, 12, version)
+
+	version, ok = parseJobETag("*")
+	assert.True(t, ok)
+	assert.Zero(t, version)
+
+	_, ok = parseJobETag(`"0"`)
+	assert.False(t, ok)
+}
@@ -0,0 +1,205 @@
+package transport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/pkg/xlsx"
+)
+
+// Supported export formats
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	exportFormatXLSX   = "xlsx"
+)
+
+// exportContentTypes maps the export formats to their media type
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv; charset=utf-8",
+	exportFormatNDJSON: "application/x-ndjson",
+	exportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportColumns maps the exportable column names to the value of a job
+// Values are ints, strings, or nil for a missing timestamp.
+var exportColumns = map[string]func(job *domain.Job) interface{}{
+	"id":              func(job *domain.Job) interface{} { return job.ID },
+	"title":           func(job *domain.Job) interface{} { return job.Title },
+	"description":     func(job *domain.Job) interface{} { return job.Description },
+	"salary_range":    func(job *domain.Job) interface{} { return job.SalaryRange },
+	"employment_type": func(job *domain.Job) interface{} { return job.EmploymentType },
+	"seniority":       func(job *domain.Job) interface{} { return job.Seniority },
+	"department":      func(job *domain.Job) interface{} { return job.Department },
+	"status":          func(job *domain.Job) interface{} { return job.Status },
+	"publish_at":      func(job *domain.Job) interface{} { return exportTime(job.PublishAt) },
+	"expires_at":      func(job *domain.Job) interface{} { return exportTime(job.ExpiresAt) },
+	"created_at":      func(job *domain.Job) interface{} { return exportTime(&job.CreatedAt) },
+	"updated_at":      func(job *domain.Job) interface{} { return exportTime(&job.UpdatedAt) },
+	"deleted_at":      func(job *domain.Job) interface{} { return exportTime(job.DeletedAt) },
+}
+
+// defaultExportColumns are exported when no columns are requested
+var defaultExportColumns = []string{
+	"id", "title", "description", "salary_range", "employment_type", "seniority", "department", "status",
+	"publish_at", "expires_at", "created_at", "updated_at",
+}
+
+// parseExportColumns reads a comma separated column list, falling back to defaultExportColumns
+func parseExportColumns(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return defaultExportColumns, nil
+	}
+	var columns []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := exportColumns[name]; !ok {
+			return nil, &domain.ValidationError{Field: "columns", Message: "unknown column " + name}
+		}
+		if !seen[name] {
+			seen[name] = true
+			columns = append(columns, name)
+		}
+	}
+	return columns, nil
+}
+
+// exportTime formats a timestamp as RFC 3339, nil when missing
+func exportTime(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportCell formats a column value as text
+func exportCell(value interface{}) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+// exportWriter writes exported jobs in one format
+type exportWriter interface {
+	writeHeader(columns []string) error
+	writeRow(values []interface{}) error
+	close() error
+}
+
+// newExportWriter returns the writer for the given format
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case exportFormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(w)}, nil
+	case exportFormatNDJSON:
+		return &ndjsonExportWriter{writer: w}, nil
+	case exportFormatXLSX:
+		return &xlsxExportWriter{writer: w}, nil
+	default:
+		return nil, &domain.ValidationError{Field: "format", Message: "must be csv, ndjson or xlsx"}
+	}
+}
+
+// csvExportWriter writes a header line followed by one line per job
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvExportWriter) writeHeader(columns []string) error {
+	return w.writer.Write(columns)
+}
+
+func (w *csvExportWriter) writeRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = neutraliseFormula(exportCell(value))
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvExportWriter) close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// neutraliseFormula prefixes text a spreadsheet would evaluate as a formula with a quote
+func neutraliseFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ndjsonExportWriter writes one JSON object per job, with the columns as keys in order
+type ndjsonExportWriter struct {
+	writer  io.Writer
+	columns []string
+}
+
+func (w *ndjsonExportWriter) writeHeader(columns []string) error {
+	w.columns = columns
+	return nil
+}
+
+func (w *ndjsonExportWriter) writeRow(values []interface{}) error {
+	var line strings.Builder
+	line.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, _ := json.Marshal(w.columns[i])
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(encoded)
+	}
+	line.WriteString("}\n")
+	_, err := io.WriteString(w.writer, line.String())
+	return err
+}
+
+func (w *ndjsonExportWriter) close() error {
+	return nil
+}
+
+// xlsxExportWriter writes a workbook whose first row holds the column names
+type xlsxExportWriter struct {
+	writer io.Writer
+	sheet  *xlsx.Writer
+}
+
+func (w *xlsxExportWriter) writeHeader(columns []string) error {
+	sheet, err := xlsx.NewWriter(w.writer, "Jobs")
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+	return sheet.WriteRow(columns)
+}
+
+func (w *xlsxExportWriter) writeRow(values []interface{}) error {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = exportCell(value)
+	}
+	return w.sheet.WriteRow(cells)
+}
+
+func (w *xlsxExportWriter) close() error {
+	return w.sheet.Close()
+}
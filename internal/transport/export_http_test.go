@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestJobs() []*domain.Job {
+	created := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	return []*domain.Job{
+		{ID: 1, Title: "Backend Engineer", Description: "Go services", Department: "Engineering", CreatedAt: created},
+		{ID: 2, Title: "=HYPERLINK(\"x\")", Description: "Formula", CreatedAt: created},
+	}
+}
+
+func TestExportJobs_CSVSelectedColumns(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	// Mock behavior
+	filter := domain.JobFilter{Department: "Engineering"}
+	mockJobService.On("ExportJobs", filter, mock.Anything).Return(exportTestJobs(), nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/export?format=csv&columns=id,title,created_at&department=Engineering", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), `filename="jobs.csv"`)
+	assert.Equal(t, "id,title,created_at\n"+
+		"1,Backend Engineer,2024-05-01T09:30:00Z\n"+
+		"2,\"'=HYPERLINK(\"\"x\"\")\",2024-05-01T09:30:00Z\n", rec.Body.String())
+	mockJobService.AssertExpectations(t)
+}
+
+func TestExportJobs_NDJSON(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	// Mock behavior
+	mockJobService.On("ExportJobs", domain.JobFilter{}, mock.Anything).Return(exportTestJobs()[:1], nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/export?format=ndjson&columns=title,id,deleted_at", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"title":"Backend Engineer","id":1,"deleted_at":null}`+"\n", rec.Body.String())
+	mockJobService.AssertExpectations(t)
+}
+
+func TestExportJobs_XLSX(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	// Mock behavior
+	mockJobService.On("ExportJobs", domain.JobFilter{}, mock.Anything).Return(exportTestJobs(), nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/export?format=xlsx&columns=id,title", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			r, err := file.Open()
+			require.NoError(t, err)
+			content, _ := io.ReadAll(r)
+			sheet = string(content)
+		}
+	}
+	assert.Contains(t, sheet, ">Backend Engineer</t>")
+	assert.Contains(t, sheet, ">=HYPERLINK(&#34;x&#34;)</t>")
+	mockJobService.AssertExpectations(t)
+}
+
+func TestExportJobs_EmptyResultWritesHeader(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	// Mock behavior
+	mockJobService.On("ExportJobs", domain.JobFilter{}, mock.Anything).Return(nil, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/export?format=csv&columns=id", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "id\n", rec.Body.String())
+}
+
+func TestExportJobs_InvalidRequest(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	for _, query := range []string{"format=pdf", "format=csv&columns=id,salary"} {
+		// Prepare HTTP request
+		req := httptest.NewRequest(http.MethodGet, "/jobs/export?"+query, nil)
+		rec := httptest.NewRecorder()
+
+		// Execute
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+	mockJobService.AssertNotCalled(t, "ExportJobs", mock.Anything, mock.Anything)
+}
+
+func TestExportJobs_ServiceError(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/export", jobHandler.ExportJobs)
+
+	// Mock behavior
+	mockJobService.On("ExportJobs", domain.JobFilter{Status: "archived"}, mock.Anything).
+		Return(nil, &domain.ValidationError{Field: "status", Message: "must be one of draft, published, closed"})
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/export?format=csv&status=archived", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "status")
+}
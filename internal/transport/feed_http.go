@@ -0,0 +1,224 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/poolcamacho/jobs-service/internal/service/feeds"
+)
+
+// feedEntryLimit is the number of newest jobs listed in the Atom and RSS feeds
+const feedEntryLimit = 50
+
+// FeedHandler handles HTTP requests for the job board syndication feeds
+// This struct acts as the controller exposing the published jobs in the job board XML formats.
+type FeedHandler struct {
+	service service.JobService       // Dependency on JobService for the published jobs
+	profile domain.JobPostingProfile // Employer and careers site details written in the feeds
+	cache   CachePolicy              // Cache-Control directives of the feeds
+	baseURL string                   // Public URL of the API, used for the feed self links and the job links
+	now     func() time.Time         // Clock telling live jobs apart, replaced in tests
+}
+
+// FeedHandlerOption configures optional settings of a FeedHandler
+type FeedHandlerOption func(*FeedHandler)
+
+// WithFeedCachePolicy sets the Cache-Control directives of the feeds
+// Without it, DefaultCachePolicy is used.
+func WithFeedCachePolicy(policy CachePolicy) FeedHandlerOption {
+	return func(h *FeedHandler) {
+		h.cache = policy
+	}
+}
+
+// WithPublicBaseURL sets the public URL of the API (e.g., "https://api.acme.com")
+// The Atom and RSS feeds link to themselves, and to the jobs when no careers site is configured, under it.
+// Links are never built from the Host or X-Forwarded-* request headers, as the feeds are cached publicly.
+func WithPublicBaseURL(baseURL string) FeedHandlerOption {
+	return func(h *FeedHandler) {
+		h.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// NewFeedHandler creates a new FeedHandler instance
+// This is a constructor function to initialize the FeedHandler with a JobService dependency.
+func NewFeedHandler(service service.JobService, profile domain.JobPostingProfile, opts ...FeedHandlerOption) *FeedHandler {
+	h := &FeedHandler{service: service, profile: profile, cache: DefaultCachePolicy, now: time.Now}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// GetFeed handles the retrieval of a job board feed
+// @Summary Get a job board feed
+// @Description Syndicate the published jobs in the XML format of a job board (indeed or linkedin).
+// @Description The response carries an ETag; send it back in If-None-Match to get a 304 when nothing changed.
+// @Tags Feeds
+// @Produce xml
+// @Param format path string true "Feed file" Enums(indeed.xml, linkedin.xml)
+// @Param If-None-Match header string false "ETag of a previously fetched feed"
+// @Success 200 {string} string "The feed"
+// @Success 304 {string} string "The feed has not changed"
+// @Failure 404 {object} map[string]string "Unknown feed format"
+// @Failure 500 {object} map[string]string "Failed to generate feed"
+// @Router /feeds/{format} [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	name, ok := strings.CutSuffix(c.Param("format"), ".xml")
+	generator, known := feeds.Lookup(name)
+	if !ok || !known {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown feed format, expected one of " + strings.Join(feeds.Formats(), ", ")})
+		return
+	}
+
+	jobs, err := h.service.SearchJobs(domain.JobFilter{Status: domain.JobStatusPublished})
+	if err != nil {
+		respondError(c, err, "failed to generate feed")
+		return
+	}
+	var body bytes.Buffer
+	if err := generator.Generate(&body, liveJobs(jobs, h.now()), h.profile); err != nil {
+		respondError(c, err, "failed to generate feed")
+		return
+	}
+
+	h.writeFeed(c, feeds.ContentType, body.Bytes())
+}
+
+// GetAtomFeed handles the retrieval of the Atom feed of published jobs
+// @Summary Get the Atom feed of jobs
+// @Description Atom feed of the newest published jobs matching the listing filters, for feed readers and bots.
+// @Description Entries are published at the job creation and updated at its last modification.
+// @Tags Feeds
+// @Produce application/atom+xml
+// @Param employment_type query string false "Filter by employment type"
+// @Param seniority query string false "Filter by seniority level"
+// @Param department query string false "Filter by department"
+// @Param skills query []string false "Filter by skills (names or aliases, repeated or comma separated)" collectionFormat(multi)
+// @Param skill_match query string false "Whether jobs must have any (default) or all of the skills" Enums(any, all)
+// @Param If-None-Match header string false "ETag of a previously fetched feed"
+// @Success 200 {string} string "The feed"
+// @Success 304 {string} string "The feed has not changed"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 500 {object} map[string]string "Failed to generate feed"
+// @Failure 503 {object} map[string]string "The public URL of the API is not configured"
+// @Router /jobs/feed.atom [get]
+func (h *FeedHandler) GetAtomFeed(c *gin.Context) {
+	h.writeJobsFeed(c, feeds.AtomContentType, feeds.WriteAtom)
+}
+
+// GetRSSFeed handles the retrieval of the RSS feed of published jobs
+// @Summary Get the RSS feed of jobs
+// @Description RSS 2.0 feed of the newest published jobs matching the listing filters, for feed readers and bots.
+// @Tags Feeds
+// @Produce application/rss+xml
+// @Param employment_type query string false "Filter by employment type"
+// @Param seniority query string false "Filter by seniority level"
+// @Param department query string false "Filter by department"
+// @Param skills query []string false "Filter by skills (names or aliases, repeated or comma separated)" collectionFormat(multi)
+// @Param skill_match query string false "Whether jobs must have any (default) or all of the skills" Enums(any, all)
+// @Param If-None-Match header string false "ETag of a previously fetched feed"
+// @Success 200 {string} string "The feed"
+// @Success 304 {string} string "The feed has not changed"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 500 {object} map[string]string "Failed to generate feed"
+// @Failure 503 {object} map[string]string "The public URL of the API is not configured"
+// @Router /jobs/feed.rss [get]
+func (h *FeedHandler) GetRSSFeed(c *gin.Context) {
+	h.writeJobsFeed(c, feeds.RSSContentType, feeds.WriteRSS)
+}
+
+// writeJobsFeed answers with the newest published jobs matching the query filter, written by write
+func (h *FeedHandler) writeJobsFeed(c *gin.Context, contentType string,
+	write func(w io.Writer, channel feeds.Channel, jobs []*domain.Job) error) {
+	// The links are never built from the request headers, so there is nothing to link to without the public URL
+	if h.baseURL == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "feed unavailable: the public URL of the API is not configured"})
+		return
+	}
+	var filter domain.JobFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The feeds are public, so they only ever list live published jobs
+	if filter.IncludeDeleted || (filter.Status != "" && filter.Status != domain.JobStatusPublished) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "feeds only list published jobs"})
+		return
+	}
+	filter.Status = domain.JobStatusPublished
+
+	jobs, err := h.service.SearchJobs(filter)
+	if err != nil {
+		respondError(c, err, "failed to generate feed")
+		return
+	}
+
+	base := h.baseURL
+	title := "Jobs"
+	if h.profile.OrganizationName != "" {
+		title = h.profile.OrganizationName + " jobs"
+	}
+	channel := feeds.Channel{
+		Title:   title,
+		SelfURL: base + c.Request.URL.RequestURI(),
+		SiteURL: h.profile.CareersURL,
+		Author:  title,
+		JobURL: func(id int) string {
+			if url := h.profile.JobURL(id); url != "" {
+				return url
+			}
+			return base + "/jobs/" + strconv.Itoa(id)
+		},
+	}
+	var body bytes.Buffer
+	if err := write(&body, channel, feeds.NewestFirst(liveJobs(jobs, h.now()), feedEntryLimit)); err != nil {
+		respondError(c, err, "failed to generate feed")
+		return
+	}
+	h.writeFeed(c, contentType, body.Bytes())
+}
+
+// liveJobs keeps the jobs live at now, dropping the ones expired or scheduled since the scheduler last ran
+func liveJobs(jobs []*domain.Job, now time.Time) []*domain.Job {
+	live := make([]*domain.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if job.IsLive(now) {
+			live = append(live, job)
+		}
+	}
+	return live
+}
+
+// writeFeed answers with a feed and its ETag, or 304 when the client already has it
+func (h *FeedHandler) writeFeed(c *gin.Context, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	setValidators(c, etag, time.Time{}, h.cache.directive(c))
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// etagMatches reports whether an If-None-Match header lists the given ETag
+// Weak validators match their strong counterpart, as If-None-Match uses the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newFeedRouter(mockJobService *service.MockJobService) *gin.Engine {
+	feedHandler := NewFeedHandler(mockJobService, domain.JobPostingProfile{OrganizationName: "Acme"},
+		WithPublicBaseURL("https://api.acme.example/"))
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/feeds/:format", feedHandler.GetFeed)
+	router.GET("/jobs/feed.atom", feedHandler.GetAtomFeed)
+	router.GET("/jobs/feed.rss", feedHandler.GetRSSFeed)
+	return router
+}
+
+func TestGetFeed_ETag(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+
+	// Mock behavior
+	jobs := []*domain.Job{{ID: 1, Title: "Go Developer", Status: domain.JobStatusPublished,
+		CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}}
+	mockJobService.On("SearchJobs", domain.JobFilter{Status: domain.JobStatusPublished}).Return(jobs, nil)
+
+	// Execute
+	req := httptest.NewRequest(http.MethodGet, "/feeds/indeed.xml", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<title><![CDATA[Go Developer]]></title>")
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// Revalidate with the ETag
+	req = httptest.NewRequest(http.MethodGet, "/feeds/indeed.xml", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Body.String())
+	mockJobService.AssertExpectations(t)
+}
+
+func TestGetFeed_UnknownFormat(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+
+	for _, path := range []string{"/feeds/monster.xml", "/feeds/indeed.json"} {
+		// Execute
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+	}
+	mockJobService.AssertNotCalled(t, "SearchJobs", domain.JobFilter{Status: domain.JobStatusPublished})
+}
+
+func TestGetAtomFeed_Filters(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+	router.GET("/jobs/:id", jobHandler.GetJobByID)
+
+	// Mock behavior
+	created := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []*domain.Job{{ID: 4, Title: "SRE", Department: "Engineering", Status: domain.JobStatusPublished,
+		CreatedAt: created, UpdatedAt: created.Add(48 * time.Hour)}}
+	mockJobService.On("SearchJobs", domain.JobFilter{Department: "Engineering", Status: domain.JobStatusPublished}).Return(jobs, nil)
+
+	// Execute
+	req := httptest.NewRequest(http.MethodGet, "http://api.acme.example/jobs/feed.atom?department=Engineering", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), "<title>Acme jobs</title>")
+	assert.Contains(t, rec.Body.String(), "<id>https://api.acme.example/jobs/feed.atom?department=Engineering</id>")
+	assert.Contains(t, rec.Body.String(), "<id>https://api.acme.example/jobs/4</id>")
+	assert.Contains(t, rec.Body.String(), "<updated>2024-02-03T00:00:00Z</updated>")
+	mockJobService.AssertExpectations(t)
+}
+
+func TestGetRSSFeed(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", domain.JobFilter{Status: domain.JobStatusPublished}).Return([]*domain.Job{}, nil)
+
+	// Execute
+	req := httptest.NewRequest(http.MethodGet, "http://attacker.example/jobs/feed.rss", nil)
+	req.Header.Set("X-Forwarded-Proto", "http")
+	req.Header.Set("X-Forwarded-Host", "attacker.example")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	// Assertions: links use the configured base URL, whatever the request headers say
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `href="https://api.acme.example/jobs/feed.rss"`)
+	assert.NotContains(t, rec.Body.String(), "attacker.example")
+	mockJobService.AssertExpectations(t)
+}
+
+func TestGetAtomFeed_OnlyPublished(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+
+	for _, query := range []string{"status=draft", "include_deleted=true"} {
+		// Execute
+		req := httptest.NewRequest(http.MethodGet, "/jobs/feed.atom?"+query, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestGetJobsFeeds_NoPublicBaseURL(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	feedHandler := NewFeedHandler(mockJobService, domain.JobPostingProfile{})
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/feed.atom", feedHandler.GetAtomFeed)
+	router.GET("/jobs/feed.rss", feedHandler.GetRSSFeed)
+
+	for _, path := range []string{"/jobs/feed.atom", "/jobs/feed.rss"} {
+		// Execute
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Host = "attacker.example"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), "public URL", path)
+	}
+	mockJobService.AssertNotCalled(t, "SearchJobs", mock.Anything)
+}
+
+func TestGetFeeds_OnlyLiveJobs(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	router := newFeedRouter(mockJobService)
+
+	// Mock behavior: the scheduler has not closed the expired job yet
+	created := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	expired := time.Now().Add(-time.Minute)
+	jobs := []*domain.Job{
+		{ID: 1, Title: "Go Developer", Status: domain.JobStatusPublished, CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "Expired Designer", Status: domain.JobStatusPublished, ExpiresAt: &expired, CreatedAt: created, UpdatedAt: created},
+	}
+	mockJobService.On("SearchJobs", domain.JobFilter{Status: domain.JobStatusPublished}).Return(jobs, nil)
+
+	for _, path := range []string{"/feeds/indeed.xml", "/jobs/feed.atom", "/jobs/feed.rss"} {
+		// Execute
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		// Assertions
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), "Go Developer", path)
+		assert.NotContains(t, rec.Body.String(), "Expired Designer", path)
+	}
+}
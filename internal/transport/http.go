@@ -1,23 +1,55 @@
 package transport
 
 import (
+	"errors"
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// mimeJSONLD is the media type of JSON-LD documents
+const mimeJSONLD = "application/ld+json"
+
 // JobHandler handles HTTP requests related to jobs
 // This struct acts as the controller for handling HTTP endpoints related to jobs.
 type JobHandler struct {
-	service service.JobService // Dependency on JobService for business logic
+	service service.JobService       // Dependency on JobService for business logic
+	profile domain.JobPostingProfile // Employer details added to the JSON-LD job postings
+	cache   CachePolicy              // Cache-Control directives of the job and listing responses
+}
+
+// JobHandlerOption configures optional settings of a JobHandler
+type JobHandlerOption func(*JobHandler)
+
+// WithJobPostingProfile sets the employer details rendered in the JSON-LD job postings
+func WithJobPostingProfile(profile domain.JobPostingProfile) JobHandlerOption {
+	return func(h *JobHandler) {
+		h.profile = profile
+	}
+}
+
+// WithCachePolicy sets the Cache-Control directives of the job and listing responses
+// Without it, DefaultCachePolicy is used.
+func WithCachePolicy(policy CachePolicy) JobHandlerOption {
+	return func(h *JobHandler) {
+		h.cache = policy
+	}
 }
 
 // NewJobHandler creates a new JobHandler instance
 // This is a constructor function to initialize the JobHandler with a JobService dependency.
-func NewJobHandler(service service.JobService) *JobHandler {
-	return &JobHandler{service: service}
+func NewJobHandler(service service.JobService, opts ...JobHandlerOption) *JobHandler {
+	h := &JobHandler{service: service, cache: DefaultCachePolicy}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // HealthCheck provides a simple health status of the service
@@ -34,33 +66,136 @@ func (h *JobHandler) HealthCheck(c *gin.Context) {
 
 // GetJobs handles the retrieval of all jobs
 // @Summary Get all jobs
-// @Description Retrieve a list of all jobs in the system
+// @Description Retrieve a list of jobs, optionally filtered by classification.
+// @Description With facets=true the response also holds the job count per classification value.
+// @Description The response carries a weak ETag for conditional requests. It has no Last-Modified date, as a job
+// @Description leaving the listing (e.g., deleted or closed) would not move it forward.
 // @Tags Jobs
 // @Produce json
-// @Success 200 {array} domain.Job "List of jobs"
+// @Param employment_type query string false "Filter by employment type"
+// @Param seniority query string false "Filter by seniority level"
+// @Param department query string false "Filter by department"
+// @Param status query string false "Filter by status" Enums(draft, published, closed)
+// @Param skills query []string false "Filter by skills (names or aliases, repeated or comma separated)" collectionFormat(multi)
+// @Param skill_match query string false "Whether jobs must have any (default) or all of the skills" Enums(any, all)
+// @Param facets query bool false "Include facet counts in the response"
+// @Param include_deleted query bool false "Also return soft-deleted jobs (admin only)"
+// @Param If-None-Match header string false "ETag of a previously fetched listing"
+// @Success 200 {array} domain.Job "List of jobs (a domain.JobList object when facets=true)"
+// @Success 304 {string} string "The listing has not changed"
+// @Header 200 {string} ETag "Weak validator of the listing"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 403 {object} map[string]string "include_deleted requires the admin role"
 // @Failure 500 {object} map[string]string "Failed to fetch jobs"
 // @Router /jobs [get]
 func (h *JobHandler) GetJobs(c *gin.Context) {
-	// Fetch all jobs using the service
-	jobs, err := h.service.GetAllJobs()
+	var filter domain.JobFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.IncludeDeleted && !jwtUtil.HasRole(c, "admin") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "include_deleted requires the admin role"})
+		return
+	}
+
+	// Fetch the jobs using the service; the unfiltered listing keeps its dedicated path
+	var jobs []*domain.Job
+	var err error
+	if filter.IsEmpty() {
+		jobs, err = h.service.GetAllJobs()
+	} else {
+		jobs, err = h.service.SearchJobs(filter)
+	}
 	if err != nil {
-		// Return 500 Internal Server Error if fetching fails
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch jobs"})
+		// Return 400 for an invalid filter, 500 Internal Server Error if fetching fails
+		respondError(c, err, "failed to fetch jobs")
+		return
+	}
+
+	if c.Query("facets") != "true" {
+		// Return the list of jobs in JSON format, or 304 if the client has it already
+		writeConditionalJSON(c, jobs, time.Time{}, h.cache.directive(c))
+		return
+	}
+
+	facets, err := h.service.GetJobFacets(filter)
+	if err != nil {
+		respondError(c, err, "failed to fetch job facets")
+		return
+	}
+	writeConditionalJSON(c, domain.JobList{Jobs: jobs, Facets: facets}, time.Time{}, h.cache.directive(c))
+}
+
+// GetJobByID handles the retrieval of a single job
+// @Summary Get a job
+// @Description Retrieve a job by its ID.
+// @Description With Accept: application/ld+json the job is rendered as a schema.org JobPosting.
+// @Description The ETag header holds the job version, to send back in If-Match when updating it; the JSON-LD
+// @Description representation has a weak ETag of its own instead.
+// @Description Send it in If-None-Match (or Last-Modified in If-Modified-Since) to get a 304 when the job has not changed.
+// @Tags Jobs
+// @Produce json
+// @Produce application/ld+json
+// @Param id path int true "Job ID"
+// @Param include_deleted query bool false "Also look up soft-deleted jobs (admin only)"
+// @Param If-None-Match header string false "ETag of a previously fetched version"
+// @Param If-Modified-Since header string false "Last-Modified of a previously fetched version"
+// @Success 200 {object} domain.Job "The job (a domain.JobPosting for application/ld+json)"
+// @Success 304 {string} string "The job has not changed"
+// @Header 200 {string} ETag "Version of the job (weak for JSON-LD)"
+// @Header 200 {string} Last-Modified "Last update of the job"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 403 {object} map[string]string "include_deleted requires the admin role"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 500 {object} map[string]string "Failed to fetch job"
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJobByID(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	var job *domain.Job
+	var err error
+	if c.Query("include_deleted") == "true" {
+		if !jwtUtil.HasRole(c, "admin") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "include_deleted requires the admin role"})
+			return
+		}
+		job, err = h.service.GetJobByIDIncludingDeleted(id)
+	} else {
+		job, err = h.service.GetJobByID(id)
+	}
+	if err != nil {
+		respondError(c, err, "failed to fetch job")
+		return
+	}
+	jsonLD := c.NegotiateFormat(gin.MIMEJSON, mimeJSONLD) == mimeJSONLD
+	etag := jobRepresentationETag(job.Version, jsonLD)
+	setValidators(c, etag, job.UpdatedAt, h.cache.directive(c))
+	c.Header("Vary", "Accept")
+	if notModified(c, etag, job.UpdatedAt) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	if jsonLD {
+		c.Header("Content-Type", mimeJSONLD+"; charset=utf-8")
+		c.JSON(http.StatusOK, service.NewJobPosting(job, h.profile))
 		return
 	}
-	// Return the list of jobs in JSON format
-	c.JSON(http.StatusOK, jobs)
+	c.JSON(http.StatusOK, job)
 }
 
 // CreateJob handles the creation of a new job
 // @Summary Create a new job
-// @Description Add a new job by providing title, description, and salary range
+// @Description Add a new job by providing title, description, salary range and optional classification
 // @Tags Jobs
 // @Accept json
 // @Produce json
 // @Param request body domain.Job true "Job Creation Request"
 // @Success 201 {object} map[string]string "Job created successfully"
-// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 400 {object} map[string]string "Bad request or unknown classification value"
 // @Failure 500 {object} map[string]string "Failed to create job"
 // @Router /jobs [post]
 func (h *JobHandler) CreateJob(c *gin.Context) {
@@ -79,12 +214,311 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 	}
 
 	// Add the job using the service
-	if err := h.service.AddJob(&job); err != nil {
-		// Return 500 Internal Server Error if creation fails
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create job"})
+	if err := h.service.AddJob(actorContext(c), &job); err != nil {
+		// Return 400 for invalid classifications, 500 Internal Server Error otherwise
+		respondError(c, err, "failed to create job")
 		return
 	}
 
 	// Return 201 Created status with a success message
 	c.JSON(http.StatusCreated, gin.H{"message": "job created successfully"})
 }
+
+// UpdateJob handles the modification of an existing job
+// @Summary Update a job
+// @Description Replace the fields of an existing job; classification values must exist in their vocabularies.
+// @Description If-Match must hold the ETag returned by GET /jobs/{id}; the update is rejected with 412 if the job
+// @Description changed since, so concurrent edits do not overwrite each other. "*" updates whatever the version.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param If-Match header string true "ETag of the job version being edited"
+// @Param request body domain.Job true "Job Update Request"
+// @Success 200 {object} map[string]string "Job updated successfully"
+// @Header 200 {string} ETag "New version of the job"
+// @Failure 400 {object} map[string]string "Bad request or unknown classification value"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 412 {object} map[string]string "The job was modified since it was read"
+// @Failure 428 {object} map[string]string "If-Match header is required"
+// @Failure 500 {object} map[string]string "Failed to update job"
+// @Router /jobs/{id} [put]
+func (h *JobHandler) UpdateJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	var job domain.Job
+	if err := c.ShouldBindJSON(&job); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if job.Description == "" || job.Title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title and description are required"})
+		return
+	}
+	job.ID = id
+
+	version, ok := ifMatchVersion(c, "failed to update job")
+	if !ok {
+		return
+	}
+	job.Version = version
+
+	if err := h.service.UpdateJob(actorContext(c), &job); err != nil {
+		respondError(c, err, "failed to update job")
+		return
+	}
+	c.Header("ETag", jobETag(job.Version))
+	c.JSON(http.StatusOK, gin.H{"message": "job updated successfully"})
+}
+
+// DeleteJob handles the soft deletion of a job
+// @Summary Delete a job
+// @Description Soft-delete a job. It disappears from listings and lookups but is kept, and can be restored,
+// @Description until the retention period expires.
+// @Description If-Match must hold the ETag returned by GET /jobs/{id}; the deletion is rejected with 412 if the job
+// @Description changed since. "*" deletes whatever the version.
+// @Tags Jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param If-Match header string true "ETag of the job version being deleted"
+// @Success 200 {object} map[string]string "Job deleted successfully"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 412 {object} map[string]string "The job was modified since it was read"
+// @Failure 428 {object} map[string]string "If-Match header is required"
+// @Failure 500 {object} map[string]string "Failed to delete job"
+// @Router /jobs/{id} [delete]
+func (h *JobHandler) DeleteJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c, "failed to delete job")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteJob(actorContext(c), id, version); err != nil {
+		respondError(c, err, "failed to delete job")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "job deleted successfully"})
+}
+
+// RestoreJob handles the restoration of a soft-deleted job
+// @Summary Restore a job
+// @Description Restore a soft-deleted job that has not been purged yet. Requires the admin role.
+// @Tags Jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} map[string]string "Job restored successfully"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 404 {object} map[string]string "Deleted job not found"
+// @Failure 500 {object} map[string]string "Failed to restore job"
+// @Router /jobs/{id}/restore [post]
+func (h *JobHandler) RestoreJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RestoreJob(actorContext(c), id); err != nil {
+		respondError(c, err, "failed to restore job")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "job restored successfully"})
+}
+
+// ChangeJobStatus handles moving a job between draft, published and closed
+// @Summary Change the status of a job
+// @Description Set the publication status of a job. The change is recorded in the audit log.
+// @Description If-Match must hold the ETag returned by GET /jobs/{id}; the change is rejected with 412 if the job
+// @Description changed since. "*" changes the status whatever the version.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param If-Match header string true "ETag of the job version being changed"
+// @Param request body domain.JobStatusRequest true "New status"
+// @Success 200 {object} map[string]string "Job status changed successfully"
+// @Failure 400 {object} map[string]string "Invalid job ID or status"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 412 {object} map[string]string "The job was modified since it was read"
+// @Failure 428 {object} map[string]string "If-Match header is required"
+// @Failure 500 {object} map[string]string "Failed to change job status"
+// @Router /jobs/{id}/status [patch]
+func (h *JobHandler) ChangeJobStatus(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.JobStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := ifMatchVersion(c, "failed to change job status")
+	if !ok {
+		return
+	}
+
+	if err := h.service.ChangeJobStatus(actorContext(c), id, req.Status, version); err != nil {
+		respondError(c, err, "failed to change job status")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "job status changed successfully"})
+}
+
+// ExportJobs handles the download of the job listing as a file
+// @Summary Export jobs
+// @Description Download the jobs matching the listing filters as CSV, NDJSON or XLSX, in ID order.
+// @Description Rows are streamed as they are read. In CSV, values starting with =, +, - or @ are prefixed
+// @Description with a quote so spreadsheets do not evaluate them.
+// @Tags Jobs
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string true "File format" Enums(csv, ndjson, xlsx)
+// @Param columns query string false "Comma separated columns (default id,title,description,salary_range,employment_type,seniority,department,status,publish_at,expires_at,created_at,updated_at; deleted_at is also available)"
+// @Param employment_type query string false "Filter by employment type"
+// @Param seniority query string false "Filter by seniority level"
+// @Param department query string false "Filter by department"
+// @Param status query string false "Filter by status" Enums(draft, published, closed)
+// @Param skills query []string false "Filter by skills (names or aliases, repeated or comma separated)" collectionFormat(multi)
+// @Param skill_match query string false "Whether jobs must have any (default) or all of the skills" Enums(any, all)
+// @Param include_deleted query bool false "Also export soft-deleted jobs (admin only)"
+// @Success 200 {file} file "The exported jobs"
+// @Failure 400 {object} map[string]string "Invalid format, columns or filter"
+// @Failure 403 {object} map[string]string "include_deleted requires the admin role"
+// @Failure 500 {object} map[string]string "Failed to export jobs"
+// @Router /jobs/export [get]
+func (h *JobHandler) ExportJobs(c *gin.Context) {
+	var filter domain.JobFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.IncludeDeleted && !jwtUtil.HasRole(c, "admin") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "include_deleted requires the admin role"})
+		return
+	}
+	columns, err := parseExportColumns(c.Query("columns"))
+	if err != nil {
+		respondError(c, err, "failed to export jobs")
+		return
+	}
+	format := c.Query("format")
+	writer, err := newExportWriter(format, c.Writer)
+	if err != nil {
+		respondError(c, err, "failed to export jobs")
+		return
+	}
+
+	// The response starts with the first job, so filter errors can still be answered with a status code
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		c.Header("Content-Type", exportContentTypes[format])
+		c.Header("Content-Disposition", `attachment; filename="jobs.`+format+`"`)
+		c.Status(http.StatusOK)
+		return writer.writeHeader(columns)
+	}
+	err = h.service.ExportJobs(filter, func(job *domain.Job) error {
+		if err := start(); err != nil {
+			return err
+		}
+		values := make([]interface{}, len(columns))
+		for i, column := range columns {
+			values[i] = exportColumns[column](job)
+		}
+		return writer.writeRow(values)
+	})
+	if err == nil {
+		err = start()
+	}
+	if err != nil && !started {
+		respondError(c, err, "failed to export jobs")
+		return
+	}
+	if err == nil {
+		err = writer.close()
+	}
+	if err != nil {
+		// The status line is already sent; the client sees a truncated file
+		log.Printf("Failed to export jobs: %v", err)
+		_ = c.Error(err)
+	}
+}
+
+// ImportJobs handles the bulk creation of jobs from a file
+// @Summary Import jobs
+// @Description Create jobs from a CSV file (header row with title, description, salary_range, employment_type,
+// @Description seniority, department and status columns) or an NDJSON file (one job object per line).
+// @Description Every row is validated and jobs are only created when all rows are valid. With dry_run=true
+// @Description nothing is written. The format is taken from the format parameter or the Content-Type header.
+// @Tags Jobs
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "File format" Enums(csv, ndjson)
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} domain.ImportReport "Jobs imported, or dry run report"
+// @Failure 400 {object} map[string]string "Unsupported format or unreadable file"
+// @Failure 413 {object} map[string]string "File too large"
+// @Failure 422 {object} domain.ImportReport "Some rows are invalid; nothing was imported"
+// @Failure 500 {object} map[string]string "Failed to import jobs"
+// @Router /jobs/import [post]
+func (h *JobHandler) ImportJobs(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		format = importFormatFromContentType(c.ContentType())
+	}
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	report, err := h.service.ImportJobs(actorContext(c), format, body, c.Query("dry_run") == "true")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file exceeds 10 MB"})
+		return
+	}
+	if err != nil {
+		respondError(c, err, "failed to import jobs")
+		return
+	}
+	if report.Invalid > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// maxImportSize bounds the size of an import file
+const maxImportSize = 10 << 20
+
+// importFormatFromContentType maps the request Content-Type to an import format
+func importFormatFromContentType(contentType string) string {
+	switch contentType {
+	case "text/csv", "application/csv":
+		return domain.ImportFormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return domain.ImportFormatNDJSON
+	}
+	return ""
+}
+
+// parseJobID reads the :id path parameter, answering 400 when it is not a positive integer
+func parseJobID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job id"})
+		return 0, false
+	}
+	return id, true
+}
@@ -6,10 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/poolcamacho/jobs-service/internal/domain"
 	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -63,7 +66,7 @@ func TestCreateJob(t *testing.T) {
 	}
 
 	// Mock behavior
-	mockJobService.On("AddJob", mock.AnythingOfType("*domain.Job")).Return(nil)
+	mockJobService.On("AddJob", mock.Anything, mock.AnythingOfType("*domain.Job")).Return(nil)
 
 	// Prepare HTTP request
 	body, _ := json.Marshal(requestBody)
@@ -132,3 +135,215 @@ func TestGetJobs_InternalServerError(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "error")
 	mockJobService.AssertExpectations(t)
 }
+
+func TestGetJobs_FilteredWithFacets(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs", jobHandler.GetJobs)
+
+	// Test data
+	filter := domain.JobFilter{EmploymentType: "contract", Department: "engineering"}
+	mockJobs := []*domain.Job{
+		{ID: 3, Title: "Go Contractor", Description: "Six month project", EmploymentType: "contract", Department: "engineering"},
+	}
+	facets := &domain.JobFacets{
+		EmploymentType: map[string]int{"contract": 1, "full-time": 4},
+		Seniority:      map[string]int{},
+		Department:     map[string]int{"engineering": 1, "sales": 2},
+	}
+
+	// Mock behavior
+	mockJobService.On("SearchJobs", filter).Return(mockJobs, nil)
+	mockJobService.On("GetJobFacets", filter).Return(facets, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs?employment_type=contract&department=engineering&facets=true", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	expectedResponse, _ := json.Marshal(domain.JobList{Jobs: mockJobs, Facets: facets})
+	assert.JSONEq(t, string(expectedResponse), rec.Body.String())
+	mockJobService.AssertNotCalled(t, "GetAllJobs")
+	mockJobService.AssertExpectations(t)
+}
+
+func TestCreateJob_UnknownClassification(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs", jobHandler.CreateJob)
+
+	// Mock behavior
+	validationErr := &domain.ValidationError{Field: domain.TaxonomyEmploymentType, Message: "unknown value freelance"}
+	mockJobService.On("AddJob", mock.Anything, mock.AnythingOfType("*domain.Job")).Return(validationErr)
+
+	// Prepare HTTP request
+	body := `{"title":"Designer","description":"Design things","employment_type":"freelance"}`
+	req := httptest.NewRequest(http.MethodPost, "/jobs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "employment_type")
+	mockJobService.AssertExpectations(t)
+}
+
+func TestUpdateJob_NotFound(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.PUT("/jobs/:id", jobHandler.UpdateJob)
+
+	// Mock behavior
+	mockJobService.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *domain.Job) bool { return job.ID == 42 })).Return(domain.ErrJobNotFound)
+
+	// Prepare HTTP request
+	body := `{"title":"Designer","description":"Design things"}`
+	req := httptest.NewRequest(http.MethodPut, "/jobs/42", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockJobService.AssertExpectations(t)
+}
+
+func TestGetJobs_IncludeDeletedRequiresAdmin(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs", withClaims(jwt.MapClaims{"sub": "7", "role": "recruiter"}), jobHandler.GetJobs)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs?include_deleted=true", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockJobService.AssertNotCalled(t, "SearchJobs", mock.Anything)
+}
+
+func TestGetJobByID_IncludeDeletedAsAdmin(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/:id", withClaims(jwt.MapClaims{"sub": "1", "role": "admin"}), jobHandler.GetJobByID)
+
+	// Test data
+	deletedAt := time.Date(2024, 12, 30, 10, 0, 0, 0, time.UTC)
+	job := &domain.Job{ID: 9, Title: "Archived role", Description: "No longer open", DeletedAt: &deletedAt}
+
+	// Mock behavior
+	mockJobService.On("GetJobByIDIncludingDeleted", 9).Return(job, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/9?include_deleted=true", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deleted_at":"2024-12-30T10:00:00Z"`)
+	mockJobService.AssertExpectations(t)
+}
+
+func TestRestoreJob_NotDeleted(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs/:id/restore", jobHandler.RestoreJob)
+
+	// Mock behavior
+	mockJobService.On("RestoreJob", mock.Anything, 4).Return(domain.ErrJobNotFound)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/jobs/4/restore", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockJobService.AssertExpectations(t)
+}
+
+// withClaims simulates AuthMiddleware by storing the given claims in the context
+func withClaims(mapClaims jwt.MapClaims) gin.HandlerFunc {
+	payload, _ := json.Marshal(mapClaims)
+	claims := &jwtUtil.Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		panic(err)
+	}
+	return func(c *gin.Context) {
+		c.Set("claims", claims)
+		c.Next()
+	}
+}
+
+func TestGetJobByID_JSONLD(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService, WithJobPostingProfile(domain.JobPostingProfile{OrganizationName: "Acme"}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/:id", jobHandler.GetJobByID)
+
+	// Mock behavior
+	job := &domain.Job{ID: 3, Title: "Designer", Description: "UI", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	mockJobService.On("GetJobByID", 3).Return(job, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/3", nil)
+	req.Header.Set("Accept", "application/ld+json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/ld+json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	assert.Contains(t, rec.Body.String(), `"@type":"JobPosting"`)
+	assert.Contains(t, rec.Body.String(), `"datePosted":"2024-01-02T00:00:00Z"`)
+	assert.Contains(t, rec.Body.String(), `"hiringOrganization":{"@type":"Organization","name":"Acme"}`)
+	mockJobService.AssertExpectations(t)
+}
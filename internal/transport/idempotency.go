@@ -0,0 +1,132 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentReplayedHeader marks a response replayed from a previous request with the same key
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotentBodySize bounds the body buffered to hash a keyed request, the largest any route accepts
+const maxIdempotentBodySize = maxImportSize
+
+// replayedHeaders are the response headers stored with the body and sent again on replay
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency makes the mutating requests carrying an Idempotency-Key header safe to retry
+// The first request with a key is processed and its response stored; retries with the same key
+// and the same method, path and body get the stored response back without running the handler again.
+// Reusing the key for a different request is rejected with 422 Unprocessable Entity, and a retry
+// arriving while the first request is still running with 409 Conflict. Server errors are not stored,
+// so the request can be retried with the same key. Keys are scoped to the token subject and must be
+// registered after the authentication middleware; keyed requests without a subject are rejected with
+// 400 Bad Request and bodies over 10 MB with 413. Requests without the header are left untouched.
+func Idempotency(idempotency service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		scope := jwtUtil.Subject(c)
+		if scope == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key requires a token with a subject"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body exceeds 10 MB"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := idempotency.Begin(scope, key, requestHash(c.Request, body))
+		if err != nil {
+			respondError(c, err, "failed to check idempotency key")
+			c.Abort()
+			return
+		}
+		if stored != nil {
+			for name, value := range stored.Header {
+				c.Header(name, value)
+			}
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(stored.StatusCode, stored.Header["Content-Type"], stored.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := idempotency.Release(scope, key); err != nil {
+				log.Printf("Failed to release idempotency key %q: %v", key, err)
+			}
+			return
+		}
+		response := domain.IdempotentResponse{StatusCode: status, Header: map[string]string{}, Body: recorder.body.Bytes()}
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				response.Header[name] = value
+			}
+		}
+		if err := idempotency.Complete(scope, key, response); err != nil {
+			log.Printf("Failed to store the response of idempotency key %q: %v", key, err)
+		}
+	}
+}
+
+// isSafeMethod reports whether the HTTP method does not change state, making the key pointless
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// requestHash identifies a request by its method, path with query and body
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copies the response body written by the handler so it can be stored
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer // Copy of the response body
+}
+
+// Write implements io.Writer, copying the data before writing it to the client
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements io.StringWriter, copying the data before writing it to the client
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,205 @@
+package transport
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newIdempotentRouter(mockIdempotency *service.MockIdempotencyService, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs", func(c *gin.Context) {
+		c.Set("principal", &jwtUtil.Principal{Subject: "user-1", Kind: jwtUtil.KindUser})
+	}, Idempotency(mockIdempotency), handler)
+	return router
+}
+
+// postJob sends a job creation with the given Idempotency-Key header, omitted when empty
+func postJob(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/jobs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_StoresResponse(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) {
+		c.Header("Location", "/jobs/9")
+		c.JSON(http.StatusCreated, gin.H{"message": "job created successfully"})
+	})
+
+	// Mock behavior
+	mockIdempotency.On("Begin", "user-1", "key-1", mock.AnythingOfType("string")).Return(nil, nil)
+	mockIdempotency.On("Complete", "user-1", "key-1", mock.MatchedBy(func(response domain.IdempotentResponse) bool {
+		return response.StatusCode == http.StatusCreated && response.Header["Location"] == "/jobs/9" &&
+			response.Header["Content-Type"] == "application/json; charset=utf-8" &&
+			string(response.Body) == `{"message":"job created successfully"}`
+	})).Return(nil)
+
+	// Execute
+	rec := postJob(router, "key-1", `{"title":"Designer"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+	mockIdempotency.AssertExpectations(t)
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	handlerCalled := false
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) { handlerCalled = true })
+
+	// Mock behavior
+	mockIdempotency.On("Begin", "user-1", "key-1", mock.AnythingOfType("string")).Return(&domain.IdempotentResponse{
+		StatusCode: http.StatusCreated,
+		Header:     map[string]string{"Content-Type": "application/json; charset=utf-8", "Location": "/jobs/9"},
+		Body:       []byte(`{"message":"job created successfully"}`),
+	}, nil)
+
+	// Execute
+	rec := postJob(router, "key-1", `{"title":"Designer"}`)
+
+	// Assertions
+	assert.False(t, handlerCalled)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, "/jobs/9", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{"message":"job created successfully"}`, rec.Body.String())
+}
+
+func TestIdempotency_SameRequestSameHash(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	var hashes []string
+
+	// Mock behavior
+	mockIdempotency.On("Begin", "user-1", mock.Anything, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { hashes = append(hashes, args.String(2)) }).Return(nil, nil)
+	mockIdempotency.On("Complete", "user-1", mock.Anything, mock.Anything).Return(nil)
+
+	// Execute
+	postJob(router, "key-1", `{"title":"Designer"}`)
+	postJob(router, "key-2", `{"title":"Designer"}`)
+	postJob(router, "key-3", `{"title":"Developer"}`)
+
+	// Assertions
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.NotEqual(t, hashes[0], hashes[2])
+}
+
+func TestIdempotency_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"key reused", domain.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity},
+		{"in progress", domain.ErrRequestInProgress, http.StatusConflict},
+		{"invalid key", &domain.ValidationError{Field: "Idempotency-Key", Message: "must be between 1 and 255 characters"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockIdempotency := new(service.MockIdempotencyService)
+			handlerCalled := false
+			router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) { handlerCalled = true })
+
+			// Mock behavior
+			mockIdempotency.On("Begin", "user-1", "key-1", mock.Anything).Return(nil, tt.err)
+
+			// Execute
+			rec := postJob(router, "key-1", `{"title":"Designer"}`)
+
+			// Assertions
+			assert.False(t, handlerCalled)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestIdempotency_ReleasesKeyOnServerError(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create job"})
+	})
+
+	// Mock behavior
+	mockIdempotency.On("Begin", "user-1", "key-1", mock.Anything).Return(nil, nil)
+	mockIdempotency.On("Release", "user-1", "key-1").Return(nil)
+
+	// Execute
+	rec := postJob(router, "key-1", `{"title":"Designer"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockIdempotency.AssertExpectations(t)
+	mockIdempotency.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	// Execute
+	rec := postJob(router, "", `{"title":"Designer"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockIdempotency.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_RejectsEmptySubject(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	handlerCalled := false
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs", func(c *gin.Context) {
+		c.Set("principal", &jwtUtil.Principal{Kind: jwtUtil.KindUser})
+	}, Idempotency(mockIdempotency), func(c *gin.Context) { handlerCalled = true })
+
+	// Execute
+	rec := postJob(router, "key-1", `{"title":"Designer"}`)
+
+	// Assertions
+	assert.False(t, handlerCalled)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockIdempotency.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_RejectsOversizedBody(t *testing.T) {
+	// Setup
+	mockIdempotency := new(service.MockIdempotencyService)
+	handlerCalled := false
+	router := newIdempotentRouter(mockIdempotency, func(c *gin.Context) { handlerCalled = true })
+
+	// Execute
+	rec := postJob(router, "key-1", strings.Repeat("a", maxIdempotentBodySize+1))
+
+	// Assertions
+	assert.False(t, handlerCalled)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	mockIdempotency.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportJobs_FormatFromContentType(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs/import", jobHandler.ImportJobs)
+	router.POST("/jobs/:id/restore", jobHandler.RestoreJob)
+
+	// Mock behavior
+	report := &domain.ImportReport{DryRun: true, Total: 1, Valid: 1, Errors: []domain.ImportRowError{}}
+	mockJobService.On("ImportJobs", mock.Anything, domain.ImportFormatNDJSON, mock.Anything, true).Return(report, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/jobs/import?dry_run=true", strings.NewReader(`{"title":"QA","description":"Tests"}`))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"dry_run":true`)
+	mockJobService.AssertExpectations(t)
+}
+
+func TestImportJobs_InvalidRows(t *testing.T) {
+	// Setup
+	mockJobService := new(service.MockJobService)
+	jobHandler := NewJobHandler(mockJobService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/jobs/import", jobHandler.ImportJobs)
+
+	// Mock behavior
+	report := &domain.ImportReport{Total: 2, Valid: 1, Invalid: 1,
+		Errors: []domain.ImportRowError{{Row: 3, Field: "title", Message: "is required"}}}
+	mockJobService.On("ImportJobs", mock.Anything, domain.ImportFormatCSV, mock.Anything, false).Return(report, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/jobs/import?format=csv", strings.NewReader("title,description\nA,a\n,b\n"))
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"row":3`)
+	mockJobService.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service/matching"
+)
+
+// MatchHandler handles HTTP requests related to job recommendations
+// This struct acts as the controller for ranking jobs against a candidate profile.
+type MatchHandler struct {
+	recommender matching.Recommender // Dependency on the Recommender for business logic
+}
+
+// NewMatchHandler creates a new MatchHandler instance
+// This is a constructor function to initialize the MatchHandler with a Recommender dependency.
+func NewMatchHandler(recommender matching.Recommender) *MatchHandler {
+	return &MatchHandler{recommender: recommender}
+}
+
+// GetRecommendedJobs handles the ranking of jobs for a candidate
+// @Summary Get recommended jobs
+// @Description Rank jobs by how well they fit a candidate. Each result carries its overall score
+// @Description and the breakdown of the factors (skills, term overlap, salary, seniority, location) that could be evaluated.
+// @Tags Jobs
+// @Produce json
+// @Param skills query []string false "Candidate skills (names or aliases)" collectionFormat(multi)
+// @Param keywords query []string false "Extra terms to look for in job titles and descriptions" collectionFormat(multi)
+// @Param experience_years query int false "Years of professional experience"
+// @Param location query string false "Preferred location"
+// @Param desired_salary query int false "Expected salary"
+// @Param limit query int false "Maximum number of jobs (default 20, max 100)"
+// @Success 200 {array} domain.JobMatch "Ranked jobs"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Failed to recommend jobs"
+// @Router /jobs/recommended [get]
+func (h *MatchHandler) GetRecommendedJobs(c *gin.Context) {
+	var profile domain.CandidateProfile
+	if err := c.ShouldBindQuery(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(profile.Skills) == 0 && len(profile.Keywords) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "skills or keywords are required"})
+		return
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	matches, err := h.recommender.Recommend(profile, limit)
+	if err != nil {
+		respondError(c, err, "failed to recommend jobs")
+		return
+	}
+	c.JSON(http.StatusOK, matches)
+}
@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service/matching"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecommendedJobs(t *testing.T) {
+	// Setup
+	mockRecommender := new(matching.MockRecommender)
+	matchHandler := NewMatchHandler(mockRecommender)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/recommended", matchHandler.GetRecommendedJobs)
+
+	// Test data
+	years := 3
+	profile := domain.CandidateProfile{Skills: []string{"go", "sql"}, ExperienceYears: &years, Location: "remote"}
+	matches := []domain.JobMatch{{
+		Job:     &domain.Job{ID: 1, Title: "Backend Engineer"},
+		Score:   0.8,
+		Factors: []domain.MatchFactor{{Name: "skills", Weight: 0.35, Score: 0.8, Detail: "has 80% of the weighted job skills"}},
+	}}
+
+	// Mock behavior
+	mockRecommender.On("Recommend", profile, 5).Return(matches, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/recommended?skills=go&skills=sql&experience_years=3&location=remote&limit=5", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	expectedResponse, _ := json.Marshal(matches)
+	assert.JSONEq(t, string(expectedResponse), rec.Body.String())
+	mockRecommender.AssertExpectations(t)
+}
+
+func TestGetRecommendedJobs_RequiresSkillsOrKeywords(t *testing.T) {
+	// Setup
+	mockRecommender := new(matching.MockRecommender)
+	matchHandler := NewMatchHandler(mockRecommender)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/jobs/recommended", matchHandler.GetRecommendedJobs)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs/recommended?location=remote", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockRecommender.AssertNotCalled(t, "Recommend", mock.Anything, mock.Anything)
+}
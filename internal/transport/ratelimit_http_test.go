@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+const rateLimitTestSecret = "test-secret"
+
+func newRateLimitedRouter(store ratelimit.Store, policy RateLimitPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(store, policy, ClientIdentity(newTestVerifier(rateLimitTestSecret), nil)))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/jobs", ok)
+	router.POST("/jobs", ok)
+	router.PUT("/jobs/:id", ok)
+	return router
+}
+
+// sendAs sends a request from the given address, with a bearer token when token is not empty
+func sendAs(router *gin.Engine, method, target, remoteAddr, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = remoteAddr
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_RejectsOnceExhausted(t *testing.T) {
+	// Setup
+	router := newRateLimitedRouter(ratelimit.NewMemoryStore(), RateLimitPolicy{
+		Routes: map[string]ratelimit.Limit{"POST /jobs": {Requests: 2, Period: time.Minute}},
+	})
+
+	// Execute
+	first := sendAs(router, http.MethodPost, "/jobs", "192.0.2.1:1234", "")
+	second := sendAs(router, http.MethodPost, "/jobs", "192.0.2.1:1234", "")
+	third := sendAs(router, http.MethodPost, "/jobs", "192.0.2.1:1234", "")
+	otherClient := sendAs(router, http.MethodPost, "/jobs", "192.0.2.2:1234", "")
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "0", second.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", second.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.Equal(t, "30", third.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, otherClient.Code)
+}
+
+func TestRateLimit_RoutesWithoutRuleShareDefault(t *testing.T) {
+	// Setup
+	router := newRateLimitedRouter(ratelimit.NewMemoryStore(), RateLimitPolicy{
+		Default: ratelimit.Limit{Requests: 1, Period: time.Minute},
+		Routes:  map[string]ratelimit.Limit{"POST /jobs": {Requests: 1, Period: time.Minute}},
+	})
+
+	// Execute
+	list := sendAs(router, http.MethodGet, "/jobs", "192.0.2.1:1234", "")
+	update := sendAs(router, http.MethodPut, "/jobs/5", "192.0.2.1:1234", "")
+	create := sendAs(router, http.MethodPost, "/jobs", "192.0.2.1:1234", "")
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, list.Code)
+	assert.Equal(t, http.StatusTooManyRequests, update.Code)
+	assert.Equal(t, http.StatusOK, create.Code, "a route with a rule has a bucket of its own")
+}
+
+func TestRateLimit_KeyedBySubject(t *testing.T) {
+	// Setup
+	router := newRateLimitedRouter(ratelimit.NewMemoryStore(), RateLimitPolicy{
+		Default: ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+	token, _ := jwtUtil.GenerateToken(rateLimitTestSecret, jwt.MapClaims{"sub": "acme", "exp": time.Now().Add(time.Hour).Unix()})
+	forged, _ := jwtUtil.GenerateToken("other-secret", jwt.MapClaims{"sub": "acme", "exp": time.Now().Add(time.Hour).Unix()})
+
+	// Execute
+	first := sendAs(router, http.MethodGet, "/jobs", "192.0.2.1:1234", token)
+	fromElsewhere := sendAs(router, http.MethodGet, "/jobs", "198.51.100.7:1234", token)
+	forgedToken := sendAs(router, http.MethodGet, "/jobs", "192.0.2.1:1234", forged)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, fromElsewhere.Code, "the subject is limited whatever its address")
+	assert.Equal(t, http.StatusOK, forgedToken.Code, "an invalid token is counted against the IP address")
+}
+
+// countingKeys accepts the API key "key-<id>" and counts the lookups
+type countingKeys struct{ lookups int }
+
+func (k *countingKeys) AuthenticateKey(key string) (*jwtUtil.Principal, error) {
+	k.lookups++
+	id, ok := strings.CutPrefix(key, "key-")
+	if !ok {
+		return nil, errors.New("unknown key")
+	}
+	return &jwtUtil.Principal{Subject: "apikey:" + id, Scopes: []string{}, Kind: jwtUtil.KindAPIKey}, nil
+}
+
+// countingRevocations revokes nothing and counts the lookups
+type countingRevocations struct{ lookups int }
+
+func (r *countingRevocations) IsRevoked(string, string, time.Time, time.Time) (bool, error) {
+	r.lookups++
+	return false, nil
+}
+
+func TestRateLimit_APIKeyCountedByKeyAndVerifiedOnce(t *testing.T) {
+	// Setup: the limiter runs before the auth middleware of the route, as in main
+	keys := &countingKeys{}
+	revocations := &countingRevocations{}
+	verifier, _ := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret(rateLimitTestSecret), jwtUtil.WithRevocationList(revocations))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(ratelimit.NewMemoryStore(), RateLimitPolicy{Default: ratelimit.Limit{Requests: 1, Period: time.Minute}},
+		ClientIdentity(verifier, keys)))
+	router.GET("/jobs", jwtUtil.Middleware(verifier, keys), func(c *gin.Context) { c.Status(http.StatusOK) })
+	token, _ := jwtUtil.GenerateToken(rateLimitTestSecret, jwt.MapClaims{"sub": "acme", "jti": "t-1", "exp": time.Now().Add(time.Hour).Unix()})
+	send := func(remoteAddr, header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(header, value)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Execute
+	first := send("192.0.2.1:1234", jwtUtil.APIKeyHeader, "key-7")
+	fromElsewhere := send("198.51.100.7:1234", jwtUtil.APIKeyHeader, "key-7")
+	otherKey := send("198.51.100.7:1234", jwtUtil.APIKeyHeader, "key-8")
+	withToken := send("192.0.2.9:1234", "Authorization", "Bearer "+token)
+
+	// Assertions: each request looked its credentials up once, for the limiter and the auth middleware together
+	assert.Equal(t, http.StatusOK, first)
+	assert.Equal(t, http.StatusTooManyRequests, fromElsewhere, "the key is limited whatever its address")
+	assert.Equal(t, http.StatusOK, otherKey)
+	assert.Equal(t, http.StatusOK, withToken)
+	assert.Equal(t, 3, keys.lookups)
+	assert.Equal(t, 1, revocations.lookups)
+}
+
+// failingStore is a rate limit store that cannot be reached
+type failingStore struct{}
+
+func (failingStore) Take(string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimit_StoreFailureLetsRequestsThrough(t *testing.T) {
+	// Setup
+	router := newRateLimitedRouter(failingStore{}, RateLimitPolicy{Default: ratelimit.Limit{Requests: 1, Period: time.Minute}})
+
+	// Execute
+	rec := sendAs(router, http.MethodGet, "/jobs", "192.0.2.1:1234", "")
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// RevocationHandler handles HTTP requests related to token revocation
+// This struct acts as the controller for the admin endpoints killing tokens before their expiry.
+type RevocationHandler struct {
+	service service.RevocationService // Dependency on RevocationService for business logic
+}
+
+// NewRevocationHandler creates a new RevocationHandler instance
+// This is a constructor function to initialize the RevocationHandler with a RevocationService dependency.
+func NewRevocationHandler(service service.RevocationService) *RevocationHandler {
+	return &RevocationHandler{service: service}
+}
+
+// RevokeToken handles the revocation of a single token
+// @Summary Revoke a token
+// @Description Reject the token with the given jti from now on, until it expires. Pass the exp of the
+// @Description token as expires_at when known; otherwise the revocation is kept for the longest token lifetime.
+// @Tags Revocations
+// @Accept json
+// @Produce json
+// @Param request body domain.TokenRevocationRequest true "The jti of the token"
+// @Success 201 {object} domain.TokenRevocation "Token revoked"
+// @Failure 400 {object} map[string]string "Invalid jti or expiry"
+// @Failure 500 {object} map[string]string "Failed to revoke token"
+// @Router /admin/revocations/tokens [post]
+func (h *RevocationHandler) RevokeToken(c *gin.Context) {
+	var req domain.TokenRevocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revoked, err := h.service.RevokeToken(actorContext(c), req)
+	if err != nil {
+		respondError(c, err, "failed to revoke token")
+		return
+	}
+	c.JSON(http.StatusCreated, revoked)
+}
+
+// RevokeSubject handles the revocation of every token of a subject
+// @Summary Revoke the tokens of a subject
+// @Description Reject the tokens of the subject issued at or before the cutoff time (now by default), e.g. after
+// @Description a password change or a compromised account. Tokens issued afterwards keep working.
+// @Tags Revocations
+// @Accept json
+// @Produce json
+// @Param request body domain.SubjectRevocationRequest true "The subject and the cutoff time"
+// @Success 201 {object} domain.SubjectRevocation "Tokens revoked"
+// @Failure 400 {object} map[string]string "Invalid subject or cutoff time"
+// @Failure 500 {object} map[string]string "Failed to revoke tokens"
+// @Router /admin/revocations/subjects [post]
+func (h *RevocationHandler) RevokeSubject(c *gin.Context) {
+	var req domain.SubjectRevocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revoked, err := h.service.RevokeSubject(actorContext(c), req)
+	if err != nil {
+		respondError(c, err, "failed to revoke tokens")
+		return
+	}
+	c.JSON(http.StatusCreated, revoked)
+}
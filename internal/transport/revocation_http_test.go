@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	jwtUtil "github.com/poolcamacho/jobs-service/pkg/jwt"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeToken(t *testing.T) {
+	// Setup
+	mockRevocationService := new(service.MockRevocationService)
+	revocationHandler := NewRevocationHandler(mockRevocationService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/revocations/tokens", withClaims(jwt.MapClaims{"sub": "admin-1", "role": "admin"}), revocationHandler.RevokeToken)
+
+	// Mock behavior: the revocation is made by the token subject
+	exp := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	mockRevocationService.On("RevokeToken", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.ActorFromContext(ctx).Subject == "admin-1"
+	}), domain.TokenRevocationRequest{JTI: "tok-1"}).Return(&domain.TokenRevocation{JTI: "tok-1", ExpiresAt: exp}, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/admin/revocations/tokens", bytes.NewBufferString(`{"jti":"tok-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"jti":"tok-1","expires_at":"2024-05-02T00:00:00Z"}`, rec.Body.String())
+	mockRevocationService.AssertExpectations(t)
+}
+
+func TestRevokeSubject_Invalid(t *testing.T) {
+	// Setup
+	mockRevocationService := new(service.MockRevocationService)
+	revocationHandler := NewRevocationHandler(mockRevocationService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/revocations/subjects", revocationHandler.RevokeSubject)
+
+	// Mock behavior
+	mockRevocationService.On("RevokeSubject", mock.Anything, mock.Anything).
+		Return(nil, &domain.ValidationError{Field: "before", Message: "must not be in the future"})
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/admin/revocations/subjects",
+		bytes.NewBufferString(`{"subject":"user-1","before":"2999-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "before")
+}
+
+func TestAuth_RevokedTokens(t *testing.T) {
+	// Setup: the jti and subject lookups themselves are covered in pkg/jwt
+	store := revocation.NewMemoryStore()
+	verifier, err := jwtUtil.NewVerifier(jwtUtil.WithHMACSecret("shared-secret"), jwtUtil.WithRevocationList(store))
+	require.NoError(t, err)
+	router := newVerifierTestRouter(verifier)
+	now := time.Now()
+	leaked := signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), "", jwt.MapClaims{
+		"sub": "recruiter-1", "jti": "tok-leaked", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()})
+	require.NoError(t, store.RevokeToken("tok-leaked", now.Add(time.Hour)))
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+leaked)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions: a revoked token is answered like any other invalid token
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Invalid token"}`, rec.Body.String())
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+}
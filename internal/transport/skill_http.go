@@ -0,0 +1,210 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// SkillHandler handles HTTP requests related to skills
+// This struct acts as the controller for the skills dictionary and the skills tagged on jobs.
+type SkillHandler struct {
+	service service.SkillService // Dependency on SkillService for business logic
+}
+
+// NewSkillHandler creates a new SkillHandler instance
+// This is a constructor function to initialize the SkillHandler with a SkillService dependency.
+func NewSkillHandler(service service.SkillService) *SkillHandler {
+	return &SkillHandler{service: service}
+}
+
+// aliasRequest is the payload for adding an alias to a skill
+type aliasRequest struct {
+	Alias string `json:"alias" binding:"required"` // Alternative spelling of the skill
+}
+
+// ListSkills handles the retrieval of the skills dictionary
+// @Summary List skills
+// @Description Retrieve every skill of the dictionary with its aliases
+// @Tags Skills
+// @Produce json
+// @Success 200 {array} domain.Skill "List of skills"
+// @Failure 500 {object} map[string]string "Failed to fetch skills"
+// @Router /skills [get]
+func (h *SkillHandler) ListSkills(c *gin.Context) {
+	skills, err := h.service.ListSkills()
+	if err != nil {
+		respondError(c, err, "failed to fetch skills")
+		return
+	}
+	c.JSON(http.StatusOK, skills)
+}
+
+// CreateSkill handles the creation of a skill
+// @Summary Create a skill
+// @Description Add a canonical skill and its aliases to the dictionary. Requires the admin role.
+// @Tags Skills
+// @Accept json
+// @Produce json
+// @Param request body domain.Skill true "Skill Creation Request"
+// @Success 201 {object} domain.Skill "Skill created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 409 {object} map[string]string "Skill or alias already exists"
+// @Failure 500 {object} map[string]string "Failed to create skill"
+// @Router /admin/skills [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	var skill domain.Skill
+	if err := c.ShouldBindJSON(&skill); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.AddSkill(&skill); err != nil {
+		respondError(c, err, "failed to create skill")
+		return
+	}
+	c.JSON(http.StatusCreated, skill)
+}
+
+// AddAlias handles the creation of a skill alias
+// @Summary Add a skill alias
+// @Description Add an alternative spelling that resolves to the skill (e.g., "golang" for "Go"). Requires the admin role.
+// @Tags Skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param request body aliasRequest true "Alias Creation Request"
+// @Success 201 {object} map[string]string "Alias created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Skill not found"
+// @Failure 409 {object} map[string]string "Alias already exists"
+// @Failure 500 {object} map[string]string "Failed to add alias"
+// @Router /admin/skills/{id}/aliases [post]
+func (h *SkillHandler) AddAlias(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid skill id"})
+		return
+	}
+	var request aliasRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.AddAlias(id, request.Alias); err != nil {
+		respondError(c, err, "failed to add alias")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "alias added successfully"})
+}
+
+// DeleteSkill handles the removal of a skill
+// @Summary Delete a skill
+// @Description Remove a skill from the dictionary and from every job tagged with it. Requires the admin role.
+// @Tags Skills
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Success 200 {object} map[string]string "Skill deleted"
+// @Failure 400 {object} map[string]string "Invalid skill ID"
+// @Failure 404 {object} map[string]string "Skill not found"
+// @Failure 500 {object} map[string]string "Failed to delete skill"
+// @Router /admin/skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid skill id"})
+		return
+	}
+
+	if err := h.service.RemoveSkill(id); err != nil {
+		respondError(c, err, "failed to delete skill")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "skill deleted successfully"})
+}
+
+// GetJobSkills handles the retrieval of the skills tagged on a job
+// @Summary List job skills
+// @Description Retrieve the required and nice-to-have skills of a job
+// @Tags Skills
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {array} domain.JobSkill "List of job skills"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 500 {object} map[string]string "Failed to fetch job skills"
+// @Router /jobs/{id}/skills [get]
+func (h *SkillHandler) GetJobSkills(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	skills, err := h.service.GetJobSkills(id)
+	if err != nil {
+		respondError(c, err, "failed to fetch job skills")
+		return
+	}
+	c.JSON(http.StatusOK, skills)
+}
+
+// SetJobSkills handles the replacement of the skills tagged on a job
+// @Summary Replace job skills
+// @Description Replace the skills of a job. Names and aliases are resolved to canonical skills; unknown skills are rejected.
+// @Tags Skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param request body []domain.JobSkill true "Job Skills"
+// @Success 200 {array} domain.JobSkill "Stored job skills"
+// @Failure 400 {object} map[string]string "Bad request or unknown skill"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 500 {object} map[string]string "Failed to update job skills"
+// @Router /jobs/{id}/skills [put]
+func (h *SkillHandler) SetJobSkills(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+	var skills []domain.JobSkill
+	if err := c.ShouldBindJSON(&skills); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tagged, err := h.service.SetJobSkills(actorContext(c), id, skills)
+	if err != nil {
+		respondError(c, err, "failed to update job skills")
+		return
+	}
+	c.JSON(http.StatusOK, tagged)
+}
+
+// DeleteJobSkill handles the removal of a skill from a job
+// @Summary Remove a job skill
+// @Description Untag a skill, given by name or alias, from a job
+// @Tags Skills
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param skill path string true "Skill name or alias"
+// @Success 200 {object} map[string]string "Skill removed"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 404 {object} map[string]string "Job not found or not tagged with the skill"
+// @Failure 500 {object} map[string]string "Failed to remove job skill"
+// @Router /jobs/{id}/skills/{skill} [delete]
+func (h *SkillHandler) DeleteJobSkill(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveJobSkill(actorContext(c), id, c.Param("skill")); err != nil {
+		respondError(c, err, "failed to remove job skill")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "skill removed successfully"})
+}
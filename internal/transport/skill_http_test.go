@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetJobSkills(t *testing.T) {
+	// Setup
+	mockSkillService := new(service.MockSkillService)
+	skillHandler := NewSkillHandler(mockSkillService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.PUT("/jobs/:id/skills", skillHandler.SetJobSkills)
+
+	// Test data
+	input := []domain.JobSkill{{Name: "golang"}, {Name: "docker", Requirement: domain.SkillNiceToHave}}
+	stored := []domain.JobSkill{
+		{SkillID: 1, Name: "Go", Requirement: domain.SkillRequired},
+		{SkillID: 4, Name: "Docker", Requirement: domain.SkillNiceToHave},
+	}
+
+	// Mock behavior
+	mockSkillService.On("SetJobSkills", mock.Anything, 5, input).Return(stored, nil)
+
+	// Prepare HTTP request
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPut, "/jobs/5/skills", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, rec.Code)
+	expectedResponse, _ := json.Marshal(stored)
+	assert.JSONEq(t, string(expectedResponse), rec.Body.String())
+	mockSkillService.AssertExpectations(t)
+}
+
+func TestCreateSkill_Duplicate(t *testing.T) {
+	// Setup
+	mockSkillService := new(service.MockSkillService)
+	skillHandler := NewSkillHandler(mockSkillService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/skills", skillHandler.CreateSkill)
+
+	// Mock behavior
+	mockSkillService.On("AddSkill", mock.AnythingOfType("*domain.Skill")).Return(domain.ErrSkillExists)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/admin/skills", bytes.NewBufferString(`{"name":"Go","aliases":["golang"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockSkillService.AssertExpectations(t)
+}
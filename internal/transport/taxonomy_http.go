@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// TaxonomyHandler handles HTTP requests related to the job classification vocabularies
+// This struct acts as the controller for listing and managing taxonomy terms.
+type TaxonomyHandler struct {
+	service service.TaxonomyService // Dependency on TaxonomyService for business logic
+}
+
+// NewTaxonomyHandler creates a new TaxonomyHandler instance
+// This is a constructor function to initialize the TaxonomyHandler with a TaxonomyService dependency.
+func NewTaxonomyHandler(service service.TaxonomyService) *TaxonomyHandler {
+	return &TaxonomyHandler{service: service}
+}
+
+// ListTerms handles the retrieval of the terms of a taxonomy kind
+// @Summary List taxonomy terms
+// @Description Retrieve the allowed values of a job classification (employment_type, seniority or department)
+// @Tags Taxonomies
+// @Produce json
+// @Param kind path string true "Taxonomy kind"
+// @Success 200 {array} domain.TaxonomyTerm "List of terms"
+// @Failure 400 {object} map[string]string "Unknown taxonomy kind"
+// @Failure 500 {object} map[string]string "Failed to fetch taxonomy terms"
+// @Router /taxonomies/{kind} [get]
+func (h *TaxonomyHandler) ListTerms(c *gin.Context) {
+	terms, err := h.service.ListTerms(c.Param("kind"))
+	if err != nil {
+		respondError(c, err, "failed to fetch taxonomy terms")
+		return
+	}
+	c.JSON(http.StatusOK, terms)
+}
+
+// CreateTerm handles the creation of a taxonomy term
+// @Summary Create a taxonomy term
+// @Description Add an allowed value to a job classification. Requires the admin role.
+// @Tags Taxonomies
+// @Accept json
+// @Produce json
+// @Param kind path string true "Taxonomy kind"
+// @Param request body domain.TaxonomyTerm true "Taxonomy Term Creation Request"
+// @Success 201 {object} domain.TaxonomyTerm "Term created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 409 {object} map[string]string "Term already exists"
+// @Failure 500 {object} map[string]string "Failed to create taxonomy term"
+// @Router /admin/taxonomies/{kind} [post]
+func (h *TaxonomyHandler) CreateTerm(c *gin.Context) {
+	var term domain.TaxonomyTerm
+	if err := c.ShouldBindJSON(&term); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	term.Kind = c.Param("kind")
+
+	if err := h.service.AddTerm(&term); err != nil {
+		respondError(c, err, "failed to create taxonomy term")
+		return
+	}
+	c.JSON(http.StatusCreated, term)
+}
+
+// DeleteTerm handles the removal of a taxonomy term
+// @Summary Delete a taxonomy term
+// @Description Remove an allowed value from a job classification. Jobs already using it are left untouched. Requires the admin role.
+// @Tags Taxonomies
+// @Produce json
+// @Param kind path string true "Taxonomy kind"
+// @Param value path string true "Term value"
+// @Success 200 {object} map[string]string "Term deleted"
+// @Failure 400 {object} map[string]string "Unknown taxonomy kind"
+// @Failure 404 {object} map[string]string "Term not found"
+// @Failure 500 {object} map[string]string "Failed to delete taxonomy term"
+// @Router /admin/taxonomies/{kind}/{value} [delete]
+func (h *TaxonomyHandler) DeleteTerm(c *gin.Context) {
+	if err := h.service.RemoveTerm(c.Param("kind"), c.Param("value")); err != nil {
+		respondError(c, err, "failed to delete taxonomy term")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "taxonomy term deleted successfully"})
+}
@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTerm(t *testing.T) {
+	// Setup
+	mockTaxonomyService := new(service.MockTaxonomyService)
+	taxonomyHandler := NewTaxonomyHandler(mockTaxonomyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/taxonomies/:kind", taxonomyHandler.CreateTerm)
+
+	// Mock behavior
+	mockTaxonomyService.On("AddTerm", mock.MatchedBy(func(term *domain.TaxonomyTerm) bool {
+		return term.Kind == domain.TaxonomyDepartment && term.Value == "engineering"
+	})).Return(nil)
+
+	// Prepare HTTP request
+	body := `{"value":"engineering","label":"Engineering"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/taxonomies/department", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockTaxonomyService.AssertExpectations(t)
+}
+
+func TestCreateTerm_Duplicate(t *testing.T) {
+	// Setup
+	mockTaxonomyService := new(service.MockTaxonomyService)
+	taxonomyHandler := NewTaxonomyHandler(mockTaxonomyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/admin/taxonomies/:kind", taxonomyHandler.CreateTerm)
+
+	// Mock behavior
+	mockTaxonomyService.On("AddTerm", mock.AnythingOfType("*domain.TaxonomyTerm")).Return(domain.ErrTaxonomyTermExists)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/admin/taxonomies/seniority", bytes.NewBufferString(`{"value":"senior"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockTaxonomyService.AssertExpectations(t)
+}
+
+func TestListTerms_UnknownKind(t *testing.T) {
+	// Setup
+	mockTaxonomyService := new(service.MockTaxonomyService)
+	taxonomyHandler := NewTaxonomyHandler(mockTaxonomyService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/taxonomies/:kind", taxonomyHandler.ListTerms)
+
+	// Mock behavior
+	mockTaxonomyService.On("ListTerms", "location").Return(nil, &domain.ValidationError{Field: "kind", Message: "unknown"})
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/taxonomies/location", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockTaxonomyService.AssertExpectations(t)
+}
@@ -0,0 +1,170 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// AuthHandler handles HTTP requests related to user accounts and their tokens
+// This struct acts as the controller for registration, login and token refresh.
+type AuthHandler struct {
+	service service.AuthService // Dependency on AuthService for business logic
+}
+
+// NewAuthHandler creates a new AuthHandler instance
+// This is a constructor function to initialize the AuthHandler with an AuthService dependency.
+func NewAuthHandler(service service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Register handles the creation of a user account
+// @Summary Register a user
+// @Description Create a user account with the "user" role, which can read jobs but not change them until an admin
+// @Description grants another role. Log in afterwards to get tokens. An email that is already registered gets the
+// @Description same answer and creates nothing, so the answer does not reveal which emails exist.
+// @Description Only served when AUTH_REGISTRATION_ENABLED is set.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body domain.RegisterRequest true "Username, email and password"
+// @Success 202 {object} map[string]string "Registration received"
+// @Failure 400 {object} map[string]string "Invalid username, email or password"
+// @Failure 409 {object} map[string]string "Username already taken"
+// @Failure 500 {object} map[string]string "Failed to register user"
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req domain.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Register(req); err != nil {
+		respondError(c, err, "failed to register user")
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "registration received, log in to continue"})
+}
+
+// Login handles the exchange of credentials for tokens
+// @Summary Log in
+// @Description Exchange an email and password for an access token and a refresh token. After failed attempts,
+// @Description further ones on the same account are delayed progressively and then locked out for a while;
+// @Description an IP failing on many accounts is locked out as well.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body domain.LoginRequest true "Email and password"
+// @Success 200 {object} domain.AuthTokens "Access and refresh tokens"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Invalid email or password"
+// @Failure 429 {object} map[string]string "Too many failed attempts, retry after the Retry-After seconds"
+// @Failure 500 {object} map[string]string "Failed to log in"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req domain.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.service.Login(actorContext(c), req)
+	if err != nil {
+		respondError(c, err, "failed to log in")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh handles the exchange of a refresh token for new tokens
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. Each refresh token
+// @Description works once; reusing one revokes every token descending from the same login.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body domain.RefreshRequest true "The refresh token"
+// @Success 200 {object} domain.AuthTokens "New access and refresh tokens"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Invalid, expired, revoked or reused refresh token"
+// @Failure 500 {object} map[string]string "Failed to refresh tokens"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req domain.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.service.Refresh(req.RefreshToken)
+	if err != nil {
+		respondError(c, err, "failed to refresh tokens")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, tokens)
+}
+
+// UnlockUser handles lifting the login lockout of a user
+// @Summary Unlock a user
+// @Description Clear the failed logins of a user, lifting their lockout and delays. Failures counted for IPs are kept.
+// @Tags Auth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string "User unlocked successfully"
+// @Failure 400 {object} map[string]string "Invalid user ID"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Failed to unlock user"
+// @Router /admin/users/{id}/unlock [post]
+func (h *AuthHandler) UnlockUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.service.Unlock(actorContext(c), id); err != nil {
+		respondError(c, err, "failed to unlock user")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user unlocked successfully"})
+}
+
+// SetUserRole handles changing the role of a user
+// @Summary Change the role of a user
+// @Description Set the role of a user: "user" (read only), "recruiter" (reads and changes jobs) or "admin".
+// @Description The access tokens already issued keep their scopes; the new role applies from the next login or refresh.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body domain.UserRoleRequest true "New role"
+// @Success 200 {object} domain.User "User with the new role"
+// @Failure 400 {object} map[string]string "Invalid user ID or role"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Failed to change the role"
+// @Router /admin/users/{id}/role [put]
+func (h *AuthHandler) SetUserRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	var req domain.UserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.SetRole(actorContext(c), id, req.Role)
+	if err != nil {
+		respondError(c, err, "failed to change the role")
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
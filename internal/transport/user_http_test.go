@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newAuthHandlerRouter serves the auth routes with the given service
+func newAuthHandlerRouter(authService service.AuthService) *gin.Engine {
+	authHandler := NewAuthHandler(authService)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/auth/register", authHandler.Register)
+	router.POST("/auth/login", authHandler.Login)
+	router.POST("/auth/refresh", authHandler.Refresh)
+	router.POST("/admin/users/:id/unlock", authHandler.UnlockUser)
+	router.PUT("/admin/users/:id/role", authHandler.SetUserRole)
+	return router
+}
+
+// postJSON sends a JSON POST request to the router
+func postJSON(router *gin.Engine, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRegisterUser(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior
+	req := domain.RegisterRequest{Username: "ana", Email: "ana@example.com", Password: "s3cret-pass"}
+	mockAuthService.On("Register", req).Return(nil)
+
+	// Execute
+	rec := postJSON(router, "/auth/register", `{"username":"ana","email":"ana@example.com","password":"s3cret-pass"}`)
+
+	// Assertions: the answer carries nothing about the account
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"message":"registration received, log in to continue"}`, rec.Body.String())
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestRegisterUser_Invalid(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior
+	mockAuthService.On("Register", domain.RegisterRequest{Username: "ana", Email: "ana@example.com", Password: "s3cret-pass"}).
+		Return(domain.ErrUsernameTaken)
+
+	// Execute
+	short := postJSON(router, "/auth/register", `{"username":"ana","email":"ana@example.com","password":"short"}`)
+	taken := postJSON(router, "/auth/register", `{"username":"ana","email":"ana@example.com","password":"s3cret-pass"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, short.Code)
+	assert.Equal(t, http.StatusConflict, taken.Code)
+}
+
+func TestLoginUser(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior
+	tokens := &domain.AuthTokens{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 900, RefreshToken: "refresh", RefreshExpiresIn: 3600}
+	mockAuthService.On("Login", mock.Anything, domain.LoginRequest{Email: "ana@example.com", Password: "s3cret-pass"}).Return(tokens, nil)
+	mockAuthService.On("Login", mock.Anything, domain.LoginRequest{Email: "ana@example.com", Password: "wrong"}).Return(nil, domain.ErrInvalidCredentials)
+
+	// Execute
+	ok := postJSON(router, "/auth/login", `{"email":"ana@example.com","password":"s3cret-pass"}`)
+	wrong := postJSON(router, "/auth/login", `{"email":"ana@example.com","password":"wrong"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.JSONEq(t, `{"access_token":"access","token_type":"Bearer","expires_in":900,"refresh_token":"refresh","refresh_expires_in":3600}`, ok.Body.String())
+	assert.Equal(t, "no-store", ok.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusUnauthorized, wrong.Code)
+}
+
+func TestLoginUser_Throttled(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior: the client IP reaches the service through the context
+	mockAuthService.On("Login", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.ActorFromContext(ctx).IP == "192.0.2.1"
+	}), mock.Anything).Return(nil, &domain.LoginThrottledError{RetryAfter: 1500 * time.Millisecond, Locked: true})
+
+	// Execute
+	rec := postJSON(router, "/auth/login", `{"email":"ana@example.com","password":"s3cret-pass"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestUnlockUser(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior
+	mockAuthService.On("Unlock", mock.Anything, 7).Return(nil)
+	mockAuthService.On("Unlock", mock.Anything, 8).Return(domain.ErrUserNotFound)
+
+	// Execute
+	ok := postJSON(router, "/admin/users/7/unlock", "")
+	missing := postJSON(router, "/admin/users/8/unlock", "")
+	invalid := postJSON(router, "/admin/users/abc/unlock", "")
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestSetUserRole(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+	putRole := func(target, body string) int {
+		req := httptest.NewRequest(http.MethodPut, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Mock behavior
+	mockAuthService.On("SetRole", mock.Anything, 7, domain.RoleRecruiter).Return(&domain.User{ID: 7, Role: domain.RoleRecruiter}, nil)
+	mockAuthService.On("SetRole", mock.Anything, 7, "owner").Return(nil, &domain.ValidationError{Field: "role", Message: "must be one of user, recruiter, admin"})
+	mockAuthService.On("SetRole", mock.Anything, 8, domain.RoleRecruiter).Return(nil, domain.ErrUserNotFound)
+
+	// Execute and assert
+	assert.Equal(t, http.StatusOK, putRole("/admin/users/7/role", `{"role":"recruiter"}`))
+	assert.Equal(t, http.StatusBadRequest, putRole("/admin/users/7/role", `{"role":"owner"}`))
+	assert.Equal(t, http.StatusNotFound, putRole("/admin/users/8/role", `{"role":"recruiter"}`))
+	assert.Equal(t, http.StatusBadRequest, putRole("/admin/users/7/role", `{}`))
+	assert.Equal(t, http.StatusBadRequest, putRole("/admin/users/abc/role", `{"role":"recruiter"}`))
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestRefreshTokens_Reused(t *testing.T) {
+	// Setup
+	mockAuthService := new(service.MockAuthService)
+	router := newAuthHandlerRouter(mockAuthService)
+
+	// Mock behavior
+	mockAuthService.On("Refresh", "used-token").Return(nil, domain.ErrInvalidRefreshToken)
+
+	// Execute
+	rec := postJSON(router, "/auth/refresh", `{"refresh_token":"used-token"}`)
+
+	// Assertions
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockAuthService.AssertExpectations(t)
+}
@@ -0,0 +1,217 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+)
+
+// WebhookHandler handles HTTP requests related to webhook subscriptions
+// This struct acts as the controller for the subscriptions of the authenticated user and their delivery logs.
+type WebhookHandler struct {
+	service service.WebhookService // Dependency on WebhookService for business logic
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+// This is a constructor function to initialize the WebhookHandler with a WebhookService dependency.
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// CreateSubscription handles the registration of a webhook endpoint
+// @Summary Create a webhook subscription
+// @Description Register an endpoint notified of job events. Requests are signed with HMAC-SHA256 using the
+// @Description returned secret, which is only shown once: X-Webhook-Signature is "sha256=" followed by the hex
+// @Description HMAC of the X-Webhook-Timestamp value, a dot and the raw body.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body domain.WebhookSubscriptionRequest true "Endpoint and event types"
+// @Success 201 {object} domain.WebhookSubscription "Subscription created, with its secret"
+// @Failure 400 {object} map[string]string "Invalid URL or event type"
+// @Failure 500 {object} map[string]string "Failed to create subscription"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req domain.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := h.service.CreateSubscription(actorContext(c), req)
+	if err != nil {
+		respondError(c, err, "failed to create subscription")
+		return
+	}
+	c.JSON(http.StatusCreated, sub)
+}
+
+// ListSubscriptions handles the retrieval of the webhook subscriptions of the user
+// @Summary List webhook subscriptions
+// @Description Retrieve the webhook subscriptions created by the authenticated user
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} domain.WebhookSubscription "List of subscriptions"
+// @Failure 500 {object} map[string]string "Failed to fetch subscriptions"
+// @Router /webhooks [get]
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	subs, err := h.service.ListSubscriptions(actorContext(c))
+	if err != nil {
+		respondError(c, err, "failed to fetch subscriptions")
+		return
+	}
+	c.JSON(http.StatusOK, subs)
+}
+
+// GetSubscription handles the retrieval of a webhook subscription
+// @Summary Get a webhook subscription
+// @Description Retrieve a webhook subscription of the authenticated user
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} domain.WebhookSubscription "The subscription"
+// @Failure 400 {object} map[string]string "Invalid subscription ID"
+// @Failure 404 {object} map[string]string "Subscription not found"
+// @Failure 500 {object} map[string]string "Failed to fetch subscription"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	id, ok := parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.service.GetSubscription(actorContext(c), id)
+	if err != nil {
+		respondError(c, err, "failed to fetch subscription")
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateSubscription handles the modification of a webhook subscription
+// @Summary Update a webhook subscription
+// @Description Change the endpoint, event types or active flag of a subscription. The secret is kept.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param request body domain.WebhookSubscriptionRequest true "Endpoint and event types"
+// @Success 200 {object} domain.WebhookSubscription "The updated subscription"
+// @Failure 400 {object} map[string]string "Invalid subscription ID, URL or event type"
+// @Failure 404 {object} map[string]string "Subscription not found"
+// @Failure 500 {object} map[string]string "Failed to update subscription"
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	id, ok := parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+	var req domain.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := h.service.UpdateSubscription(actorContext(c), id, req)
+	if err != nil {
+		respondError(c, err, "failed to update subscription")
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// DeleteSubscription handles the removal of a webhook subscription
+// @Summary Delete a webhook subscription
+// @Description Remove a webhook subscription and its delivery log
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} map[string]string "Subscription deleted successfully"
+// @Failure 400 {object} map[string]string "Invalid subscription ID"
+// @Failure 404 {object} map[string]string "Subscription not found"
+// @Failure 500 {object} map[string]string "Failed to delete subscription"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSubscription(actorContext(c), id); err != nil {
+		respondError(c, err, "failed to delete subscription")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "subscription deleted successfully"})
+}
+
+// ListDeliveries handles the retrieval of the delivery log of a subscription
+// @Summary List webhook deliveries
+// @Description Retrieve the deliveries of a subscription, newest first, with their status, attempts and last error
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param limit query int false "Maximum number of deliveries (default 50, max 200)"
+// @Param offset query int false "Number of deliveries to skip"
+// @Success 200 {array} domain.WebhookDelivery "Delivery log"
+// @Failure 400 {object} map[string]string "Invalid subscription ID"
+// @Failure 404 {object} map[string]string "Subscription not found"
+// @Failure 500 {object} map[string]string "Failed to fetch deliveries"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	deliveries, err := h.service.ListDeliveries(actorContext(c), id, limit, offset)
+	if err != nil {
+		respondError(c, err, "failed to fetch deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// Redeliver handles sending a delivery again
+// @Summary Redeliver a webhook
+// @Description Queue a delivery again, including dead-lettered ones, with a fresh retry schedule
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 202 {object} map[string]string "Delivery queued"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Subscription or delivery not found"
+// @Failure 500 {object} map[string]string "Failed to queue delivery"
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, ok := parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil || deliveryID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+
+	if err := h.service.Redeliver(actorContext(c), id, deliveryID); err != nil {
+		respondError(c, err, "failed to queue delivery")
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "delivery queued"})
+}
+
+// parseSubscriptionID reads the :id path parameter, answering 400 when it is not a positive integer
+func parseSubscriptionID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
+		return 0, false
+	}
+	return id, true
+}
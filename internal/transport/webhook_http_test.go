@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/internal/domain"
+	"github.com/poolcamacho/jobs-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	// Setup
+	mockWebhookService := new(service.MockWebhookService)
+	webhookHandler := NewWebhookHandler(mockWebhookService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/webhooks", withClaims(jwt.MapClaims{"sub": "acme"}), webhookHandler.CreateSubscription)
+
+	// Mock behavior: the subscription is owned by the token subject
+	sub := &domain.WebhookSubscription{ID: 1, URL: "https://acme.test/hook", Secret: "whsec_x", Active: true}
+	mockWebhookService.On("CreateSubscription", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.ActorFromContext(ctx).Subject == "acme"
+	}), domain.WebhookSubscriptionRequest{URL: "https://acme.test/hook"}).Return(sub, nil)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url":"https://acme.test/hook"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"secret":"whsec_x"`)
+	mockWebhookService.AssertExpectations(t)
+}
+
+func TestRedeliver_DeliveryNotFound(t *testing.T) {
+	// Setup
+	mockWebhookService := new(service.MockWebhookService)
+	webhookHandler := NewWebhookHandler(mockWebhookService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+
+	// Mock behavior
+	mockWebhookService.On("Redeliver", mock.Anything, 2, 99).Return(domain.ErrDeliveryNotFound)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/2/deliveries/99/redeliver", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockWebhookService.AssertExpectations(t)
+}
+
+func TestListDeliveries_InvalidID(t *testing.T) {
+	// Setup
+	mockWebhookService := new(service.MockWebhookService)
+	webhookHandler := NewWebhookHandler(mockWebhookService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+
+	// Prepare HTTP request
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/abc/deliveries", nil)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(rec, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockWebhookService.AssertNotCalled(t, "ListDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,28 @@
+-- Job classification: employment type, seniority and department
+ALTER TABLE jobs
+    ADD COLUMN employment_type VARCHAR(50)  NOT NULL DEFAULT '' AFTER salary_range,
+    ADD COLUMN seniority       VARCHAR(50)  NOT NULL DEFAULT '' AFTER employment_type,
+    ADD COLUMN department      VARCHAR(100) NOT NULL DEFAULT '' AFTER seniority,
+    ADD INDEX idx_jobs_employment_type (employment_type),
+    ADD INDEX idx_jobs_seniority (seniority),
+    ADD INDEX idx_jobs_department (department);
+
+-- Controlled vocabularies managed through the /admin/taxonomies endpoints
+CREATE TABLE job_taxonomy_terms (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    kind       VARCHAR(30)  NOT NULL,
+    value      VARCHAR(100) NOT NULL,
+    label      VARCHAR(150) NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_job_taxonomy_terms_kind_value (kind, value)
+);
+
+INSERT INTO job_taxonomy_terms (kind, value, label) VALUES
+    ('employment_type', 'full-time', 'Full-time'),
+    ('employment_type', 'part-time', 'Part-time'),
+    ('employment_type', 'contract', 'Contract'),
+    ('employment_type', 'internship', 'Internship'),
+    ('seniority', 'junior', 'Junior'),
+    ('seniority', 'mid', 'Mid-level'),
+    ('seniority', 'senior', 'Senior'),
+    ('seniority', 'lead', 'Lead');
@@ -0,0 +1,24 @@
+-- Skills dictionary with case-insensitive aliases (e.g., "golang" resolves to "Go")
+CREATE TABLE skills (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_skills_name (name)
+);
+
+CREATE TABLE skill_aliases (
+    alias    VARCHAR(100) NOT NULL PRIMARY KEY, -- Stored lower-cased and trimmed
+    skill_id INT          NOT NULL,
+    CONSTRAINT fk_skill_aliases_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+);
+
+-- Skills tagged on jobs
+CREATE TABLE job_skills (
+    job_id      INT                               NOT NULL,
+    skill_id    INT                               NOT NULL,
+    requirement ENUM ('required', 'nice_to_have') NOT NULL DEFAULT 'required',
+    PRIMARY KEY (job_id, skill_id),
+    INDEX idx_job_skills_skill (skill_id),
+    CONSTRAINT fk_job_skills_job FOREIGN KEY (job_id) REFERENCES jobs (id) ON DELETE CASCADE,
+    CONSTRAINT fk_job_skills_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Soft deletion: deleted jobs keep their row until purged after the retention period
+ALTER TABLE jobs
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at,
+    ADD INDEX idx_jobs_deleted_at (deleted_at);
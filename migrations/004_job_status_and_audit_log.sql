@@ -0,0 +1,29 @@
+-- Publication status: existing jobs stay visible as published
+ALTER TABLE jobs
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published' AFTER department,
+    ADD INDEX idx_jobs_status (status);
+
+-- Audit log: one row per job mutation, written in the same transaction as the change
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   INT          NOT NULL,
+    action      VARCHAR(50)  NOT NULL,
+    actor       VARCHAR(255) NOT NULL DEFAULT '',
+    request_id  VARCHAR(128) NOT NULL DEFAULT '',
+    before_data JSON NULL,
+    after_data  JSON NULL,
+    changes     JSON         NOT NULL,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_log_entity (entity_type, entity_id),
+    INDEX idx_audit_log_actor (actor),
+    INDEX idx_audit_log_request (request_id),
+    INDEX idx_audit_log_created_at (created_at)
+);
+
+-- The log is append-only: rows can never be changed or removed
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';
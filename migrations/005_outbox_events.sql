@@ -0,0 +1,19 @@
+-- Transactional outbox: job events are written with the job change and delivered by the relay
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id        CHAR(36)     NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    INT          NOT NULL,
+    payload         JSON         NOT NULL,
+    occurred_at     DATETIME     NOT NULL,
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME     NOT NULL,
+    last_error      TEXT         NOT NULL DEFAULT (''),
+    claim_token     CHAR(32)     NULL,
+    locked_until    DATETIME     NULL,
+    delivered_at    DATETIME     NULL,
+    UNIQUE KEY uq_outbox_events_event_id (event_id),
+    INDEX idx_outbox_events_pending (delivered_at, next_attempt_at),
+    INDEX idx_outbox_events_claim (claim_token)
+);
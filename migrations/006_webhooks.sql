@@ -0,0 +1,35 @@
+-- Webhook subscriptions of client companies
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    owner      VARCHAR(255)  NOT NULL,
+    url        VARCHAR(2048) NOT NULL,
+    events     JSON          NOT NULL,
+    secret     VARCHAR(100)  NOT NULL,
+    active     BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_webhook_subscriptions_owner (owner)
+);
+
+-- Delivery queue and log: one row per event and subscription
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               INT AUTO_INCREMENT PRIMARY KEY,
+    subscription_id  INT          NOT NULL,
+    event_id         CHAR(36)     NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    payload          JSON         NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts         INT          NOT NULL DEFAULT 0,
+    next_attempt_at  DATETIME     NULL,
+    last_status_code INT          NOT NULL DEFAULT 0,
+    last_error       TEXT         NOT NULL DEFAULT (''),
+    claim_token      CHAR(32)     NULL,
+    locked_until     DATETIME     NULL,
+    delivered_at     DATETIME     NULL,
+    created_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_webhook_deliveries_event (subscription_id, event_id),
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_claim (claim_token),
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id)
+        REFERENCES webhook_subscriptions (id) ON DELETE CASCADE
+);
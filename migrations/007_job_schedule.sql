@@ -0,0 +1,13 @@
+-- Scheduled publishing and expiry of jobs, applied by the job scheduler
+ALTER TABLE jobs
+    ADD COLUMN publish_at DATETIME NULL AFTER status,
+    ADD COLUMN expires_at DATETIME NULL AFTER publish_at,
+    ADD INDEX idx_jobs_status_publish_at (status, publish_at),
+    ADD INDEX idx_jobs_status_expires_at (status, expires_at);
+
+-- Leases electing the single replica that runs a background task
+CREATE TABLE IF NOT EXISTS leases (
+    name       VARCHAR(100) PRIMARY KEY,
+    holder     VARCHAR(100) NOT NULL,
+    expires_at DATETIME     NOT NULL
+);
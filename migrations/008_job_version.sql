@@ -0,0 +1,3 @@
+-- Optimistic concurrency: every change increments the version, sent to clients as the ETag
+ALTER TABLE jobs
+    ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER expires_at;
@@ -0,0 +1,15 @@
+-- Idempotency keys: responses of mutating requests, replayed when the client retries with the same key
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id               BIGINT AUTO_INCREMENT PRIMARY KEY,
+    scope            VARCHAR(255) NOT NULL,
+    idempotency_key  VARCHAR(255) NOT NULL,
+    request_hash     CHAR(64)     NOT NULL,
+    status_code      INT          NULL,
+    response_headers JSON         NULL,
+    response_body    MEDIUMBLOB   NULL,
+    locked_until     DATETIME     NOT NULL,
+    created_at       DATETIME     NOT NULL,
+    expires_at       DATETIME     NOT NULL,
+    UNIQUE KEY uq_idempotency_keys_scope_key (scope, idempotency_key),
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+);
@@ -0,0 +1,7 @@
+-- Token buckets of the rate limiter, shared by the replicas
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key VARCHAR(255) NOT NULL PRIMARY KEY,
+    tokens     DOUBLE       NOT NULL,
+    updated_at BIGINT       NOT NULL, -- Unix time in microseconds
+    INDEX idx_rate_limit_buckets_updated_at (updated_at)
+);
@@ -0,0 +1,19 @@
+-- API keys of the machine clients (e.g., ATS integrations); only a SHA-256 hash of each key is stored
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                  INT AUTO_INCREMENT PRIMARY KEY,
+    name                VARCHAR(100) NOT NULL,
+    prefix              CHAR(16)     NOT NULL,
+    key_hash            CHAR(64)     NOT NULL,
+    scopes              JSON         NOT NULL,
+    created_by          VARCHAR(255) NOT NULL,
+    previous_prefix     CHAR(16)     NULL,     -- Key replaced by the last rotation
+    previous_key_hash   CHAR(64)     NULL,
+    previous_expires_at DATETIME     NULL,     -- End of the grace period of the replaced key
+    expires_at          DATETIME     NULL,
+    last_used_at        DATETIME     NULL,
+    revoked_at          DATETIME     NULL,
+    created_at          TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at          DATETIME     NULL,
+    UNIQUE KEY uq_api_keys_prefix (prefix),
+    INDEX idx_api_keys_previous_prefix (previous_prefix)
+);
@@ -0,0 +1,14 @@
+-- Tokens revoked before their expiry, by jti
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti        VARCHAR(255) NOT NULL PRIMARY KEY,
+    expires_at DATETIME     NOT NULL, -- The row can be removed once the token has expired
+    INDEX idx_revoked_tokens_expires_at (expires_at)
+);
+
+-- Subjects whose tokens issued at or before revoked_before are revoked
+CREATE TABLE IF NOT EXISTS revoked_subjects (
+    subject        VARCHAR(255) NOT NULL PRIMARY KEY,
+    revoked_before DATETIME     NOT NULL,
+    expires_at     DATETIME     NOT NULL, -- The row can be removed once the tokens it covers have expired
+    INDEX idx_revoked_subjects_expires_at (expires_at)
+);
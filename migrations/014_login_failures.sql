@@ -0,0 +1,8 @@
+-- Failed logins per account and per client IP, shared by the replicas
+CREATE TABLE IF NOT EXISTS login_failures (
+    failure_key  VARCHAR(320) NOT NULL PRIMARY KEY, -- "account:<email>" or "ip:<address>"
+    failures     INT          NOT NULL,
+    last_failure BIGINT       NOT NULL, -- Unix time in microseconds
+    expires_at   BIGINT       NOT NULL, -- Unix time in microseconds; the row can be removed afterwards
+    INDEX idx_login_failures_expires_at (expires_at)
+);
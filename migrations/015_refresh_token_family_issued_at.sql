@@ -0,0 +1,10 @@
+-- When the login starting each refresh token family happened, so revoking a user's tokens also stops their refreshes
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_issued_at DATETIME NULL AFTER family_id,
+    ADD INDEX idx_refresh_tokens_user_id_family_issued_at (user_id, family_issued_at);
+
+-- Families started before this migration: their oldest remaining token
+UPDATE refresh_tokens t
+JOIN (SELECT family_id, MIN(created_at) AS issued_at FROM refresh_tokens GROUP BY family_id) f ON f.family_id = t.family_id
+SET t.family_issued_at = f.issued_at
+WHERE t.family_issued_at IS NULL;
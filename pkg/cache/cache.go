@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is a byte-oriented key-value store with per-entry expiry
+// Implement it to back a cache with an external store shared by several replicas (e.g., Redis or memcached).
+type Cache interface {
+	// Get returns the value stored under the key
+	// @param key string - The key
+	// @return []byte - The value
+	// @return bool - False if the key is missing or expired
+	// @return error - An error if the store cannot be reached
+	Get(key string) ([]byte, bool, error)
+
+	// Set stores a value under the key
+	// @param key string - The key
+	// @param value []byte - The value; callers must not modify it afterwards
+	// @param ttl time.Duration - How long the value is kept; zero keeps it until evicted
+	// @return error - An error if the store cannot be reached
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// LRU is an in-memory Cache holding a bounded number of entries
+// Once full, storing a new key evicts the least recently used entry. It is safe for concurrent use.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int                      // Maximum number of entries
+	entries  map[string]*list.Element // Entries by key
+	order    *list.List               // Entries from most to least recently used
+	now      func() time.Time         // Clock, replaced in tests
+}
+
+// lruEntry is an element of the LRU recency list
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // Zero when the entry never expires
+}
+
+// NewLRU creates an in-memory cache holding up to capacity entries
+// @param capacity int - The maximum number of entries; at least 1
+// @return *LRU - The cache
+func NewLRU(capacity int) *LRU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU{capacity: capacity, entries: make(map[string]*list.Element), order: list.New(), now: time.Now}
+}
+
+// Get returns the value stored under the key, marking it as recently used
+// @param key string - The key
+// @return []byte - The value
+// @return bool - False if the key is missing or expired
+// @return error - Always nil
+func (c *LRU) Get(key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores a value under the key, evicting the least recently used entry when full
+// @param key string - The key
+// @param value []byte - The value
+// @param ttl time.Duration - How long the value is kept; zero keeps it until evicted
+// @return error - Always nil
+func (c *LRU) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, expired ones included until they are looked up or evicted
+// @return int - The number of entries
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
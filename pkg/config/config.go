@@ -1,7 +1,10 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config holds application configuration values
@@ -11,6 +14,70 @@ type Config struct {
 	DatabaseURL  string // URL for the database connection
 	JWTSecretKey string // Secret key used for JWT token generation
 	Port         string // Port on which the server will run
+
+	JWTAlgorithms       string        // Comma separated accepted token algorithms (e.g., "RS256,ES256"); empty accepts all configured
+	JWKSURL             string        // File path or URL of the JWKS verifying asymmetric tokens; empty disables them
+	JWKSRefreshInterval time.Duration // How often the JWKS is fetched again
+	JWKSKeyRetention    time.Duration // How long a key dropped from the JWKS is still accepted
+	JWTIssuers          string        // Comma separated accepted "iss" values; empty accepts any
+	JWTAudiences        string        // Comma separated accepted "aud" values, one of which tokens must name; empty accepts any
+	JWTLeeway           time.Duration // Clock skew tolerated on the exp, nbf and iat claims
+	JWTRequiredClaims   string        // Comma separated claims every token must carry (e.g., "role")
+
+	TokenRevocationStore string        // Where revoked tokens are kept: "mysql" (shared by the replicas), "memory" or "none"
+	JWTMaxTokenLifetime  time.Duration // Longest lifetime of the accepted tokens; revocations are kept that long
+
+	AuthRegistrationEnabled   bool          // Serves POST /auth/register, letting anyone create a read-only account
+	AccessTokenTTL            time.Duration // Lifetime of the access tokens issued by /auth/login and /auth/refresh
+	RefreshTokenTTL           time.Duration // Lifetime of the refresh tokens
+	RefreshTokenPurgeInterval time.Duration // How often expired refresh tokens are removed
+
+	PasswordHasher        string // Algorithm hashing new passwords: "argon2id" or "bcrypt"; existing hashes are upgraded at login
+	BcryptCost            int    // bcrypt cost factor (4 to 31)
+	Argon2Memory          int    // Argon2id memory in KiB
+	Argon2Iterations      int    // Argon2id number of passes
+	Argon2Parallelism     int    // Argon2id number of lanes
+	PasswordMinLength     int    // Shortest password accepted on registration
+	BreachedPasswordsFile string // File listing breached passwords (plain or SHA-1) rejected on registration; empty disables the check
+
+	LoginGuardStore         string        // Where failed logins are counted: "mysql" (shared by the replicas), "memory" or "none"
+	LoginMaxAccountFailures int           // Failed logins of an account before it is locked; 0 disables the account lockout
+	LoginMaxIPFailures      int           // Failed logins from an IP before it is locked; 0 disables the IP lockout
+	LoginLockoutDuration    time.Duration // How long a lockout lasts after the failure that caused it
+	LoginFailureWindow      time.Duration // How long failed logins are remembered after the last one
+	LoginBaseDelay          time.Duration // Wait imposed on an account after its first failed login, doubled by each further one
+	LoginMaxDelay           time.Duration // Longest wait imposed between two failed logins of an account
+
+	JobRetention     time.Duration // How long soft-deleted jobs are kept before being purged
+	JobPurgeInterval time.Duration // How often soft-deleted jobs past the retention period are purged
+
+	EventPublisher     string        // Destination of job events: "stdout", "file" or "none"
+	EventFile          string        // File the events are appended to when EventPublisher is "file"
+	OutboxPollInterval time.Duration // How often the outbox is checked for events to deliver
+	SchedulerInterval  time.Duration // How often jobs due for publication or expiry are checked
+
+	IdempotencyTTL           time.Duration // How long Idempotency-Key responses are kept for replay
+	IdempotencyPurgeInterval time.Duration // How often expired idempotency keys are removed
+
+	JobCacheSize int           // Maximum number of job listings cached in memory; 0 disables the cache
+	JobCacheTTL  time.Duration // How long a cached job listing is served
+
+	PublicCacheControl        string // Cache-Control of the job responses and feeds served without credentials
+	AuthenticatedCacheControl string // Cache-Control of the job responses served to authenticated requests
+
+	RateLimitStore   string // Where the rate limit buckets are kept: "memory", "mysql" (shared by the replicas) or "none"
+	RateLimitDefault string // Requests per period allowed to each client on the routes without a rule (e.g., "600/m")
+	RateLimitRoutes  string // Comma separated route limits (e.g., "POST /jobs=30/m,POST /jobs/import=5/m")
+
+	HiringOrganization     string // Name of the employer shown in the JSON-LD job postings
+	HiringOrganizationURL  string // Careers or company website of the employer
+	HiringOrganizationLogo string // URL of the employer logo
+	CareersURL             string // Base URL of the careers site linked from the job board feeds
+	PublicBaseURL          string // Public URL of the API linked from the Atom and RSS feeds; without it they answer 503
+	JobLocality            string // City of the workplace
+	JobRegion              string // State or region of the workplace
+	JobCountry             string // ISO 3166-1 alpha-2 country code of the workplace
+	SalaryCurrency         string // ISO 4217 currency assumed when a salary range names none
 }
 
 // Load reads configuration from environment variables
@@ -22,6 +89,70 @@ func Load() *Config {
 		DatabaseURL:  getEnv("DATABASE_URL", "admin_db:dadgic-qafkuh-Hipto0@tcp(talent-management-db.cne4yyyawn11.us-east-1.rds.amazonaws.com:3306)/talent_management_db"),
 		JWTSecretKey: getEnv("JWT_SECRET_KEY", "d18aa05bbce170dc073b548f721170fee6e8085e8f10b10548854a489b93afb8"),
 		Port:         getEnv("PORT", "3000"),
+
+		JWTAlgorithms:       getEnv("JWT_ALGORITHMS", ""),
+		JWKSURL:             getEnv("JWKS_URL", ""),
+		JWKSRefreshInterval: getEnvDuration("JWKS_REFRESH_INTERVAL", 15*time.Minute),
+		JWKSKeyRetention:    getEnvDuration("JWKS_KEY_RETENTION", time.Hour),
+		JWTIssuers:          getEnv("JWT_ISSUER", ""),
+		JWTAudiences:        getEnv("JWT_AUDIENCE", ""),
+		JWTLeeway:           getEnvDuration("JWT_LEEWAY", 30*time.Second),
+		JWTRequiredClaims:   getEnv("JWT_REQUIRED_CLAIMS", ""),
+
+		TokenRevocationStore: getEnv("TOKEN_REVOCATION_STORE", "mysql"),
+		JWTMaxTokenLifetime:  getEnvDuration("JWT_MAX_TOKEN_LIFETIME", 7*24*time.Hour),
+
+		AuthRegistrationEnabled:   getEnvBool("AUTH_REGISTRATION_ENABLED", false),
+		AccessTokenTTL:            getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:           getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		RefreshTokenPurgeInterval: getEnvDuration("REFRESH_TOKEN_PURGE_INTERVAL", time.Hour),
+
+		PasswordHasher:        getEnv("PASSWORD_HASHER", "argon2id"),
+		BcryptCost:            getEnvInt("BCRYPT_COST", 10),
+		Argon2Memory:          getEnvInt("ARGON2_MEMORY", 64*1024),
+		Argon2Iterations:      getEnvInt("ARGON2_ITERATIONS", 3),
+		Argon2Parallelism:     getEnvInt("ARGON2_PARALLELISM", 2),
+		PasswordMinLength:     getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		BreachedPasswordsFile: getEnv("BREACHED_PASSWORDS_FILE", ""),
+
+		LoginGuardStore:         getEnv("LOGIN_GUARD_STORE", "mysql"),
+		LoginMaxAccountFailures: getEnvInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		LoginMaxIPFailures:      getEnvInt("LOGIN_MAX_IP_FAILURES", 100),
+		LoginLockoutDuration:    getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		LoginFailureWindow:      getEnvDuration("LOGIN_FAILURE_WINDOW", time.Hour),
+		LoginBaseDelay:          getEnvDuration("LOGIN_BASE_DELAY", time.Second),
+		LoginMaxDelay:           getEnvDuration("LOGIN_MAX_DELAY", 30*time.Second),
+
+		JobRetention:     getEnvDuration("JOB_RETENTION", 365*24*time.Hour),
+		JobPurgeInterval: getEnvDuration("JOB_PURGE_INTERVAL", 24*time.Hour),
+
+		EventPublisher:     getEnv("EVENT_PUBLISHER", "stdout"),
+		EventFile:          getEnv("EVENT_FILE", "job-events.ndjson"),
+		OutboxPollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		SchedulerInterval:  getEnvDuration("SCHEDULER_INTERVAL", 15*time.Second),
+
+		IdempotencyTTL:           getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyPurgeInterval: getEnvDuration("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+
+		JobCacheSize: getEnvInt("JOB_CACHE_SIZE", 1000),
+		JobCacheTTL:  getEnvDuration("JOB_CACHE_TTL", 30*time.Second),
+
+		PublicCacheControl:        getEnv("PUBLIC_CACHE_CONTROL", "public, max-age=300"),
+		AuthenticatedCacheControl: getEnv("AUTHENTICATED_CACHE_CONTROL", "private, no-cache"),
+
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "600/m"),
+		RateLimitRoutes:  getEnv("RATE_LIMIT_ROUTES", "POST /jobs=30/m,POST /jobs/import=5/m"),
+
+		HiringOrganization:     getEnv("HIRING_ORGANIZATION", ""),
+		HiringOrganizationURL:  getEnv("HIRING_ORGANIZATION_URL", ""),
+		HiringOrganizationLogo: getEnv("HIRING_ORGANIZATION_LOGO", ""),
+		CareersURL:             getEnv("CAREERS_URL", ""),
+		PublicBaseURL:          getEnv("PUBLIC_BASE_URL", ""),
+		JobLocality:            getEnv("JOB_LOCALITY", ""),
+		JobRegion:              getEnv("JOB_REGION", ""),
+		JobCountry:             getEnv("JOB_COUNTRY", ""),
+		SalaryCurrency:         getEnv("SALARY_CURRENCY", "USD"),
 	}
 }
 
@@ -37,3 +168,60 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvDuration retrieves a duration from the environment variable named by the key
+// @Description Parses the variable with time.ParseDuration (e.g., "24h", "90m"). If the
+// variable is not set or cannot be parsed, it logs a warning and returns the fallback value.
+// @Param key string The name of the environment variable to retrieve.
+// @Param fallback time.Duration The default value to return if the variable is not set or invalid.
+// @Return time.Duration The parsed duration or the fallback value.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration %q for %s, using %s", value, key, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// getEnvInt retrieves a non-negative integer from the environment variable named by the key
+// @Description If the variable is not set or is not a non-negative integer, it logs a warning
+// (when set) and returns the fallback value.
+// @Param key string The name of the environment variable to retrieve.
+// @Param fallback int The default value to return if the variable is not set or invalid.
+// @Return int The parsed integer or the fallback value.
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		log.Printf("Invalid number %q for %s, using %d", value, key, fallback)
+		return fallback
+	}
+	return number
+}
+
+// getEnvBool retrieves a boolean from the environment variable named by the key
+// @Description Accepts the values understood by strconv.ParseBool (e.g., "true", "false", "1", "0"). If the
+// variable is not set or cannot be parsed, it logs a warning (when set) and returns the fallback value.
+// @Param key string The name of the environment variable to retrieve.
+// @Param fallback bool The default value to return if the variable is not set or invalid.
+// @Return bool The parsed boolean or the fallback value.
+func getEnvBool(key string, fallback bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean %q for %s, using %t", value, key, fallback)
+		return fallback
+	}
+	return enabled
+}
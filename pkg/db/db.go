@@ -4,18 +4,31 @@ import (
 	"database/sql"
 	"log"
 
-	_ "github.com/go-sql-driver/mysql" // MySQL driver
+	"github.com/go-sql-driver/mysql"
 )
 
 // Connect establishes a connection to the MySQL database
 // @Description Establishes a connection to the MySQL database using the provided DSN (Data Source Name).
+// Every session runs in UTC unless the DSN sets time_zone itself: the repositories write times as UTC
+// strings, and TIMESTAMP columns (including those set by CURRENT_TIMESTAMP) are read and compared in
+// the session time zone, so a server in another zone would shift every comparison by its offset.
 // Logs a fatal error and stops the application if the connection fails.
 // @Param dsn string The Data Source Name containing the database connection details (e.g., username, password, host, port, database name).
 // @Return *sql.DB A pointer to the SQL database connection.
 func Connect(dsn string) *sql.DB {
-	db, err := sql.Open("mysql", dsn)
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		log.Fatalf("Failed to parse database DSN: %v", err)
+	}
+	if _, ok := cfg.Params["time_zone"]; !ok {
+		if cfg.Params == nil {
+			cfg.Params = map[string]string{}
+		}
+		cfg.Params["time_zone"] = "'+00:00'"
+	}
+	connector, err := mysql.NewConnector(cfg)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	return db
+	return sql.OpenDB(connector)
 }
@@ -0,0 +1,109 @@
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Claim validation errors, logged when a token is rejected
+var (
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+	ErrTokenUsedEarly   = errors.New("token was issued in the future")
+	ErrTokenTooLong     = errors.New("token lifetime exceeds the accepted maximum")
+	ErrInvalidIssuer    = errors.New("token issuer is not accepted")
+	ErrInvalidAudience  = errors.New("token audience is not accepted")
+	ErrMissingClaim     = errors.New("token lacks a required claim")
+	ErrTokenRevoked     = errors.New("token is revoked")
+)
+
+// Claims holds the claims of the tokens accepted by the service
+// The registered claims (iss, sub, aud, exp, nbf, iat, jti) are typed; every claim, custom ones
+// included, is also available in Custom.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role   string `json:"role,omitempty"`    // Role of the user (e.g., "admin")
+	UserID string `json:"user_id,omitempty"` // ID of the user, for issuers not setting sub; numbers are read as strings
+	Email  string `json:"email,omitempty"`   // Email of the user
+	Scope  string `json:"scope,omitempty"`   // Space-separated scopes the token is restricted to; empty for unrestricted
+
+	Custom map[string]any `json:"-"` // Every claim of the token, by name
+}
+
+// UnmarshalJSON decodes the claims of a token, accepting a numeric user_id
+// @Param data []byte The JSON payload of the token.
+// @Return error An error if the payload is not a JSON object or a registered claim has the wrong type.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type plain Claims // Without this method, to avoid recursing
+	aux := struct {
+		*plain
+		UserID any `json:"user_id"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	switch id := aux.UserID.(type) {
+	case string:
+		c.UserID = id
+	case float64:
+		c.UserID = strconv.FormatFloat(id, 'f', -1, 64)
+	}
+	return json.Unmarshal(data, &c.Custom)
+}
+
+// subject reads the "sub" claim, falling back to "user_id" and "email"
+func (c *Claims) subject() string {
+	for _, value := range []string{c.Subject, c.UserID, c.Email} {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// validateClaims checks the time, issuer, audience and required claims of a token
+// Every token must expire. With a maximum lifetime, it must also carry iat, and exp may not be
+// further from it than that lifetime.
+func (v *Verifier) validateClaims(claims *Claims, now time.Time) error {
+	if claims.ExpiresAt == nil {
+		return fmt.Errorf("%w: exp", ErrMissingClaim)
+	}
+	if now.After(claims.ExpiresAt.Add(v.leeway)) {
+		return ErrTokenExpired
+	}
+	if v.maxLifetime > 0 {
+		if claims.IssuedAt == nil {
+			return fmt.Errorf("%w: iat", ErrMissingClaim)
+		}
+		if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime > v.maxLifetime {
+			return fmt.Errorf("%w: %s", ErrTokenTooLong, lifetime)
+		}
+	}
+	if claims.NotBefore != nil && now.Before(claims.NotBefore.Add(-v.leeway)) {
+		return ErrTokenNotValidYet
+	}
+	if claims.IssuedAt != nil && now.Before(claims.IssuedAt.Add(-v.leeway)) {
+		return ErrTokenUsedEarly
+	}
+	if len(v.issuers) > 0 && !slices.Contains(v.issuers, claims.Issuer) {
+		return fmt.Errorf("%w: %q", ErrInvalidIssuer, claims.Issuer)
+	}
+	if len(v.audiences) > 0 && !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(v.audiences, aud)
+	}) {
+		return fmt.Errorf("%w: %q", ErrInvalidAudience, claims.Audience)
+	}
+	for _, name := range v.requiredClaims {
+		if value, ok := claims.Custom[name]; !ok || value == nil || value == "" {
+			return fmt.Errorf("%w: %s", ErrMissingClaim, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateClaims(t *testing.T) {
+	// Setup
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"),
+		WithIssuer("https://auth.example.com"), WithAudience("jobs-service"),
+		WithLeeway(30*time.Second), WithMaxLifetime(time.Hour), WithRequiredClaims("role"))
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *jwt.NumericDate { return jwt.NewNumericDate(now.Add(offset)) }
+	valid := func(edit func(*Claims)) *Claims {
+		claims := &Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "recruiter-1", Issuer: "https://auth.example.com",
+				Audience: jwt.ClaimStrings{"jobs-service", "auth-service"}, IssuedAt: at(-time.Minute), ExpiresAt: at(time.Minute)},
+			Role:   "recruiter",
+			Custom: map[string]any{"role": "recruiter"},
+		}
+		if edit != nil {
+			edit(claims)
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		want   error
+	}{
+		{"valid", valid(nil), nil},
+		{"expired within the leeway", valid(func(c *Claims) { c.ExpiresAt = at(-30 * time.Second) }), nil},
+		{"expired beyond the leeway", valid(func(c *Claims) { c.ExpiresAt = at(-31 * time.Second) }), ErrTokenExpired},
+		{"no expiry", valid(func(c *Claims) { c.ExpiresAt = nil }), ErrMissingClaim},
+		{"lifetime at the maximum", valid(func(c *Claims) { c.ExpiresAt = at(59 * time.Minute) }), nil},
+		{"lifetime beyond the maximum", valid(func(c *Claims) { c.ExpiresAt = at(time.Hour) }), ErrTokenTooLong},
+		{"far-future expiry", valid(func(c *Claims) { c.ExpiresAt = at(365 * 24 * time.Hour) }), ErrTokenTooLong},
+		{"no issue time with a maximum lifetime", valid(func(c *Claims) { c.IssuedAt = nil }), ErrMissingClaim},
+		{"not valid yet within the leeway", valid(func(c *Claims) { c.NotBefore = at(30 * time.Second) }), nil},
+		{"not valid yet beyond the leeway", valid(func(c *Claims) { c.NotBefore = at(31 * time.Second) }), ErrTokenNotValidYet},
+		{"issued in the future within the leeway", valid(func(c *Claims) { c.IssuedAt = at(30 * time.Second) }), nil},
+		{"issued in the future beyond the leeway", valid(func(c *Claims) { c.IssuedAt = at(time.Minute) }), ErrTokenUsedEarly},
+		{"other issuer", valid(func(c *Claims) { c.Issuer = "https://evil.example.com" }), ErrInvalidIssuer},
+		{"no issuer", valid(func(c *Claims) { c.Issuer = "" }), ErrInvalidIssuer},
+		{"single matching audience", valid(func(c *Claims) { c.Audience = jwt.ClaimStrings{"jobs-service"} }), nil},
+		{"other audience", valid(func(c *Claims) { c.Audience = jwt.ClaimStrings{"billing-service"} }), ErrInvalidAudience},
+		{"missing required claim", valid(func(c *Claims) { delete(c.Custom, "role") }), ErrMissingClaim},
+		{"empty required claim", valid(func(c *Claims) { c.Custom["role"] = "" }), ErrMissingClaim},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			err := verifier.validateClaims(tt.claims, now)
+
+			// Assertions
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_AudienceAsString(t *testing.T) {
+	// Setup
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"), WithAudience("jobs-service"))
+	require.NoError(t, err)
+	token := signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), "", jwt.MapClaims{"sub": "recruiter-1", "aud": "jobs-service", "exp": time.Now().Add(time.Hour).Unix()})
+
+	// Execute
+	_, err = verifier.Validate(token)
+
+	// Assertions
+	assert.NoError(t, err)
+}
+
+func TestClaims_NumericUserID(t *testing.T) {
+	// Setup
+	verifier := hmacVerifier("shared-secret")
+	token, _ := GenerateToken("shared-secret", jwt.MapClaims{"user_id": 42, "role": "admin", "team": "platform", "exp": time.Now().Add(time.Hour).Unix()})
+
+	// Execute
+	claims, err := verifier.Validate(token)
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, "42", claims.UserID)
+	assert.Equal(t, "42", claims.subject())
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, "platform", claims.Custom["team"])
+}
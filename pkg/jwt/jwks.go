@@ -0,0 +1,333 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often a token with an unknown kid can trigger a refresh of the key set
+const minRefreshInterval = time.Minute
+
+// maxJWKSSize bounds the size of a key set document
+const maxJWKSSize = 1 << 20
+
+// ErrUnknownKey is returned when no key of the set matches the kid of a token
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// KeySet holds the public keys of a JSON Web Key Set (RFC 7517) used to verify asymmetric tokens
+// Keys are selected by the kid header of the token. When the issuer rotates its keys, a key dropped from
+// the set keeps verifying tokens for the retention period, so tokens signed just before the rotation stay
+// valid. It is safe for concurrent use.
+type KeySet struct {
+	source    string        // File path or http(s) URL of the JWKS document
+	client    *http.Client  // Client used to fetch URLs
+	retention time.Duration // How long a key dropped from the set is still accepted
+
+	refreshMu   sync.Mutex // Serialises refreshes
+	mu          sync.RWMutex
+	keys        map[string]verificationKey // Keys of the last fetched set, by kid
+	retired     map[string]retiredKey      // Keys dropped from the set still within their retention, by kid
+	lastRefresh time.Time
+}
+
+// verificationKey is a public key and the algorithm the set restricts it to, if any
+type verificationKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// retiredKey is a key dropped from the set and when it stops being accepted
+type retiredKey struct {
+	verificationKey
+	until time.Time
+}
+
+// KeySetOption configures a KeySet
+type KeySetOption func(*KeySet)
+
+// WithHTTPClient sets the client used to fetch a JWKS URL
+// @Param client *http.Client The client (default: a client with a 10s timeout).
+// @Return KeySetOption The option.
+func WithHTTPClient(client *http.Client) KeySetOption {
+	return func(s *KeySet) {
+		s.client = client
+	}
+}
+
+// WithRetention sets how long a key dropped from the set is still accepted
+// @Param retention time.Duration The retention period (default: 1h).
+// @Return KeySetOption The option.
+func WithRetention(retention time.Duration) KeySetOption {
+	return func(s *KeySet) {
+		s.retention = retention
+	}
+}
+
+// NewKeySet loads the key set published at source
+// @Description Reads a JWKS document from a local file or an http(s) URL. Only signature keys of type
+// RSA, EC (P-256, P-384, P-521) and OKP (Ed25519) are kept; other keys are skipped.
+// @Param ctx context.Context Bounds the initial fetch.
+// @Param source string The file path or URL of the JWKS document.
+// @Param opts ...KeySetOption Optional settings.
+// @Return *KeySet The loaded key set.
+// @Return error An error if the document cannot be read or holds no usable key.
+func NewKeySet(ctx context.Context, source string, opts ...KeySetOption) (*KeySet, error) {
+	s := &KeySet{
+		source:    source,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		retention: time.Hour,
+		keys:      make(map[string]verificationKey),
+		retired:   make(map[string]retiredKey),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh fetches the key set again
+// @Description Keys dropped from the set are retired and still accepted for the retention period.
+// On error the current keys are kept.
+// @Param ctx context.Context Bounds the fetch.
+// @Return error An error if the document cannot be read or holds no usable key.
+func (s *KeySet) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	return s.refresh(ctx)
+}
+
+// Run refreshes the key set every interval until ctx is cancelled
+// @Description Failed refreshes are logged and retried at the next tick, keeping the current keys.
+// @Param ctx context.Context Stops the loop when cancelled.
+// @Param interval time.Duration The time between refreshes.
+func (s *KeySet) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Failed to refresh JWKS from %s: %v", s.source, err)
+			}
+		}
+	}
+}
+
+// lookup returns the key a token with the given kid must be verified with
+// A kid missing from the set triggers a refresh, at most once per minRefreshInterval, in case the
+// issuer has just published a new key. A token without kid is accepted when the set holds a single key.
+func (s *KeySet) lookup(ctx context.Context, kid string) (verificationKey, error) {
+	if key, ok := s.find(kid); ok {
+		return key, nil
+	}
+
+	s.refreshMu.Lock()
+	if key, ok := s.find(kid); ok {
+		s.refreshMu.Unlock()
+		return key, nil
+	}
+	s.mu.RLock()
+	stale := time.Since(s.lastRefresh) >= minRefreshInterval
+	s.mu.RUnlock()
+	if stale {
+		if err := s.refresh(ctx); err != nil {
+			log.Printf("Failed to refresh JWKS from %s: %v", s.source, err)
+		}
+	}
+	s.refreshMu.Unlock()
+
+	if key, ok := s.find(kid); ok {
+		return key, nil
+	}
+	return verificationKey{}, ErrUnknownKey
+}
+
+// find looks a kid up among the current and the retired keys
+func (s *KeySet) find(kid string) (verificationKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return verificationKey{}, false
+		}
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	if key, ok := s.keys[kid]; ok {
+		return key, true
+	}
+	if key, ok := s.retired[kid]; ok && time.Now().Before(key.until) {
+		return key.verificationKey, true
+	}
+	return verificationKey{}, false
+}
+
+// refresh fetches and swaps the key set; the caller holds refreshMu
+func (s *KeySet) refresh(ctx context.Context) error {
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for kid, key := range s.keys {
+		if _, ok := keys[kid]; !ok {
+			s.retired[kid] = retiredKey{verificationKey: key, until: now.Add(s.retention)}
+		}
+	}
+	for kid, key := range s.retired {
+		if _, ok := keys[kid]; ok || !now.Before(key.until) {
+			delete(s.retired, kid)
+		}
+	}
+	s.keys = keys
+	s.lastRefresh = now
+	return nil
+}
+
+// fetch reads the JWKS document from its URL or file
+func (s *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.source, "https://") && !strings.HasPrefix(s.source, "http://") {
+		return os.ReadFile(s.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+// jsonWebKey is a key of a JWKS document; only the members of the supported key types are read
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS decodes the signature keys of a JWKS document, by kid
+func parseJWKS(data []byte) (map[string]verificationKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Printf("Skipping JWKS key %q: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = verificationKey{key: key, alg: jwk.Alg}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS holds no usable signature key")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the public key of a JWK
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.BitLen() < 2048 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, ok := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(raw) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
@@ -0,0 +1,155 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Rotation(t *testing.T) {
+	// Setup
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"2024-01": &oldKey.PublicKey})
+
+	retaining, err := NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	dropping, err := NewKeySet(context.Background(), path, WithRetention(0))
+	require.NoError(t, err)
+	oldToken := signToken(t, jwt.SigningMethodRS256, oldKey, "2024-01")
+	newToken := signToken(t, jwt.SigningMethodRS256, newKey, "2024-02")
+
+	// Execute: the issuer replaces its key
+	writeJWKS(t, path, map[string]any{"2024-02": &newKey.PublicKey})
+	require.NoError(t, retaining.Refresh(context.Background()))
+	require.NoError(t, dropping.Refresh(context.Background()))
+
+	// Assertions: the old key keeps working during the retention period only
+	tests := []struct {
+		name  string
+		keys  *KeySet
+		token string
+		valid bool
+	}{
+		{"retired key within retention", retaining, oldToken, true},
+		{"new key with retention", retaining, newToken, true},
+		{"retired key without retention", dropping, oldToken, false},
+		{"new key without retention", dropping, newToken, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(WithKeySet(tt.keys))
+			require.NoError(t, err)
+			_, err = verifier.Validate(tt.token)
+			assert.Equal(t, tt.valid, err == nil, err)
+		})
+	}
+}
+
+func TestKeySet_RetiredKeyExpires(t *testing.T) {
+	// Setup: a key retired an hour ago with a one hour retention
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"2024-01": &oldKey.PublicKey})
+	keys, err := NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	writeJWKS(t, path, map[string]any{"2024-02": &newKey.PublicKey})
+	require.NoError(t, keys.Refresh(context.Background()))
+	_, ok := keys.find("2024-01")
+	require.True(t, ok)
+
+	// Execute
+	keys.mu.Lock()
+	retired := keys.retired["2024-01"]
+	retired.until = time.Now().Add(-time.Second)
+	keys.retired["2024-01"] = retired
+	keys.mu.Unlock()
+	_, found := keys.find("2024-01")
+	require.NoError(t, keys.Refresh(context.Background()))
+
+	// Assertions: it is no longer accepted, and the next refresh forgets it
+	assert.False(t, found)
+	assert.NotContains(t, keys.retired, "2024-01")
+}
+
+func TestKeySet_UnknownKidRefreshes(t *testing.T) {
+	// Setup
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"2024-01": &oldKey.PublicKey})
+	keys, err := NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	writeJWKS(t, path, map[string]any{"2024-01": &oldKey.PublicKey, "2024-02": &newKey.PublicKey})
+
+	// Execute: the set was refreshed moments ago, then long enough ago
+	_, recentErr := keys.lookup(context.Background(), "2024-02")
+	keys.mu.Lock()
+	keys.lastRefresh = time.Now().Add(-minRefreshInterval)
+	keys.mu.Unlock()
+	_, staleErr := keys.lookup(context.Background(), "2024-02")
+
+	// Assertions: a new kid is fetched at most once per minRefreshInterval
+	assert.ErrorIs(t, recentErr, ErrUnknownKey)
+	assert.NoError(t, staleErr)
+}
+
+func TestParseJWKS(t *testing.T) {
+	// Setup
+	tests := []struct {
+		name     string
+		document string
+		kids     []string
+	}{
+		{"encryption key skipped", `{"keys":[{"kty":"OKP","kid":"enc","use":"enc","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+			{"kty":"OKP","kid":"sig","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, []string{"sig"}},
+		{"weak RSA key skipped", `{"keys":[{"kty":"RSA","kid":"weak","n":"AQAB","e":"AQAB"},
+			{"kty":"OKP","kid":"sig","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, []string{"sig"}},
+		{"unsupported curve skipped", `{"keys":[{"kty":"OKP","kid":"x448","crv":"X448","x":"AQAB"},
+			{"kty":"OKP","kid":"sig","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, []string{"sig"}},
+		{"no usable key", `{"keys":[{"kty":"oct","kid":"secret","k":"c2VjcmV0"}]}`, nil},
+		{"not JSON", `keys`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			keys, err := parseJWKS([]byte(tt.document))
+
+			// Assertions
+			if tt.kids == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var kids []string
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			assert.ElementsMatch(t, tt.kids, kids)
+		})
+	}
+}
+
+func TestNewKeySet_MissingFile(t *testing.T) {
+	// Execute
+	_, err := NewKeySet(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+
+	// Assertions
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
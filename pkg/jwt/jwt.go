@@ -2,7 +2,6 @@ package jwt
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
@@ -11,39 +10,23 @@ import (
 // GenerateToken generates a JWT for a given user
 // @Description Generates a JSON Web Token (JWT) using the provided secret key and claims.
 // @Param secretKey string The secret key used to sign the token.
-// @Param claims jwt.MapClaims The claims to be embedded in the token.
+// @Param claims jwt.Claims The claims to be embedded in the token (e.g., jwt.MapClaims or *Claims).
 // @Return string The signed JWT as a string.
 // @Return error An error if the token generation fails.
-func GenerateToken(secretKey string, claims jwt.MapClaims) (string, error) {
+func GenerateToken(secretKey string, claims jwt.Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secretKey))
 }
 
 // ValidateToken validates a JWT and returns the claims if valid
-// @Description Validates a JSON Web Token (JWT) using the provided secret key.
+// @Description Validates a JSON Web Token (JWT) signed with HS256, HS384 or HS512 using the provided secret key.
 // Returns the claims if the token is valid, or an error if validation fails.
 // @Param secretKey string The secret key used to validate the token's signature.
 // @Param tokenString string The JWT string to be validated.
-// @Return jwt.MapClaims The claims extracted from the valid token.
+// @Return *Claims The claims extracted from the valid token.
 // @Return error An error if the token is invalid or validation fails.
-func ValidateToken(secretKey string, tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return []byte(secretKey), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, jwt.ErrSignatureInvalid
-	}
-
-	return claims, nil
+func ValidateToken(secretKey string, tokenString string) (*Claims, error) {
+	return hmacVerifier(secretKey).Validate(tokenString)
 }
 
 // AuthMiddleware is a middleware that validates JWT tokens in HTTP requests
@@ -51,36 +34,52 @@ func ValidateToken(secretKey string, tokenString string) (jwt.MapClaims, error)
 // @Param secretKey string The secret key used to validate the token.
 // @Return gin.HandlerFunc The middleware function for Gin.
 func AuthMiddleware(secretKey string) gin.HandlerFunc {
+	return Middleware(hmacVerifier(secretKey), nil)
+}
+
+// RequireRole is a middleware that only lets through tokens carrying the given role
+// @Description Middleware that checks the "role" claim stored by AuthMiddleware.
+// Must be registered after AuthMiddleware.
+// @Param role string The role required to access the route (e.g., "admin").
+// @Return gin.HandlerFunc The middleware function for Gin.
+func RequireRole(role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Extract the Authorization header
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
+		if PrincipalFrom(c) == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authentication"})
 			c.Abort()
 			return
 		}
 
-		// Ensure the token is prefixed with "Bearer "
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+		if !HasRole(c, role) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
 			c.Abort()
 			return
 		}
 
-		// Validate the token
-		tokenString := parts[1]
-		claims, err := ValidateToken(secretKey, tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token", "details": err.Error()})
-			c.Abort()
-			return
-		}
+		c.Next()
+	}
+}
 
-		// Store claims in the context for further use
-		c.Set("claims", claims)
+// HasRole reports whether the authenticated request carries the given role
+// @Description Checks the role of the principal stored by AuthMiddleware; false when the request is not authenticated
+// or authenticated with an API key.
+// @Param c *gin.Context The request context.
+// @Param role string The role to check (e.g., "admin").
+// @Return bool True if the token carries the role.
+func HasRole(c *gin.Context, role string) bool {
+	principal := PrincipalFrom(c)
+	return principal != nil && principal.Role != "" && principal.Role == role
+}
 
-		// Continue to the next middleware/handler
-		c.Next()
+// Subject returns the subject of the authenticated request
+// @Description Reads the subject of the principal stored by AuthMiddleware: the "sub" claim of a JWT
+// (falling back to "user_id" and "email"), or "apikey:<id>" for an API key.
+// @Param c *gin.Context The request context.
+// @Return string The subject, empty when the request is not authenticated.
+func Subject(c *gin.Context) string {
+	principal := PrincipalFrom(c)
+	if principal == nil {
+		return ""
 	}
+	return principal.Subject
 }
@@ -0,0 +1,191 @@
+package jwt
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Principal kinds
+const (
+	KindUser   = "user"    // Authenticated with a bearer JWT
+	KindAPIKey = "api_key" // Authenticated with an X-API-Key
+)
+
+// APIKeyHeader is the header machine clients send their API key in
+const APIKeyHeader = "X-API-Key"
+
+// Principal is the caller of an authenticated request, whichever credential it used
+type Principal struct {
+	Subject string   // e.g., the "sub" claim of a JWT or "apikey:<id>"
+	Role    string   // The "role" claim of a JWT; empty for API keys
+	Scopes  []string // Scopes granted to an API key or listed in the "scope" claim of a JWT; nil when unrestricted
+	Kind    string   // KindUser or KindAPIKey
+}
+
+// HasScope reports whether the principal may use the given scope
+// @Description API keys only hold the scopes they were issued with, and JWTs the ones listed in their "scope"
+// claim. JWTs without a "scope" claim are not restricted.
+// @Param scope string The scope to check (e.g., "jobs:write").
+// @Return bool True if the scope is granted.
+func (p *Principal) HasScope(scope string) bool {
+	return p.Scopes == nil || slices.Contains(p.Scopes, scope)
+}
+
+// KeyAuthenticator resolves the API key sent by a client to its principal
+type KeyAuthenticator interface {
+	AuthenticateKey(key string) (*Principal, error)
+}
+
+// Middleware authenticates requests carrying either a bearer JWT or an X-API-Key
+// @Description Like AuthMiddleware, but also accepts the X-API-Key header when keys is not nil.
+// Stores the caller under "principal" (and the JWT claims under "claims") in the context. Credentials
+// already resolved by Resolve earlier in the chain (e.g., by a rate limiter) are not verified again.
+// @Param verifier *Verifier The verifier used to validate tokens.
+// @Param keys KeyAuthenticator The API key lookup, or nil to only accept JWTs.
+// @Return gin.HandlerFunc The middleware function for Gin.
+func Middleware(verifier *Verifier, keys KeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_, err := Resolve(verifier, keys, c)
+		switch {
+		case err == nil:
+			c.Next()
+			return
+		case errors.Is(err, errMissingAuthorization):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
+		case errors.Is(err, errMalformedAuthorization):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+		case errors.Is(err, errInvalidAPIKey):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		default:
+			// Why a token was rejected is only logged: telling clients would help forging tokens
+			log.Printf("Rejected bearer token for %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		}
+		c.Abort()
+	}
+}
+
+// Resolve authenticates the bearer JWT or X-API-Key of a request, once per request
+// @Description The outcome is kept in the context, so middleware running before Middleware (e.g., a rate
+// limiter keying clients by principal) and Middleware itself verify the credentials a single time. Every
+// caller of a request must therefore pass the same verifier and keys. On success the principal is stored
+// under "principal" (and the JWT claims under "claims").
+// @Param verifier *Verifier The verifier used to validate tokens.
+// @Param keys KeyAuthenticator The API key lookup, or nil to only accept JWTs.
+// @Param c *gin.Context The request context.
+// @Return *Principal The caller.
+// @Return error An error if the request carries no credentials or invalid ones.
+func Resolve(verifier *Verifier, keys KeyAuthenticator, c *gin.Context) (*Principal, error) {
+	if value, ok := c.Get(resolvedKey); ok {
+		result := value.(resolution)
+		return result.principal, result.err
+	}
+
+	principal, claims, err := resolve(verifier, keys, c)
+	c.Set(resolvedKey, resolution{principal: principal, err: err})
+	if err == nil {
+		if claims != nil {
+			c.Set("claims", claims)
+		}
+		c.Set("principal", principal)
+	}
+	return principal, err
+}
+
+// resolvedKey is the context key holding the outcome of Resolve
+const resolvedKey = "jwt.resolved"
+
+// resolution is the outcome of Resolve kept in the context
+type resolution struct {
+	principal *Principal
+	err       error
+}
+
+// Reasons for rejecting the credentials of a request, besides the token validation errors
+var (
+	errMissingAuthorization   = errors.New("authorization header is missing")
+	errMalformedAuthorization = errors.New("invalid authorization header format")
+	errInvalidAPIKey          = errors.New("invalid api key")
+)
+
+// resolve authenticates the credentials of a request; claims is nil for API keys
+func resolve(verifier *Verifier, keys KeyAuthenticator, c *gin.Context) (*Principal, *Claims, error) {
+	if key := c.GetHeader(APIKeyHeader); key != "" && keys != nil {
+		principal, err := keys.AuthenticateKey(key)
+		if err != nil {
+			return nil, nil, errInvalidAPIKey
+		}
+		return principal, nil, nil
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return nil, nil, errMissingAuthorization
+	}
+	// Ensure the token is prefixed with "Bearer "
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, nil, errMalformedAuthorization
+	}
+	claims, err := verifier.Validate(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return principalFromClaims(claims), claims, nil
+}
+
+// RequireScope is a middleware that only lets through principals holding the given scope
+// @Description Must be registered after Middleware. Users whose JWT has no "scope" claim always pass.
+// @Param scope string The scope required to access the route (e.g., "jobs:read").
+// @Return gin.HandlerFunc The middleware function for Gin.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := PrincipalFrom(c)
+		if principal == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authentication"})
+			c.Abort()
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient scope", "scope": scope})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PrincipalFrom returns the caller of an authenticated request
+// @Description Reads the principal stored by Middleware, or derives it from the *Claims stored under "claims".
+// @Param c *gin.Context The request context.
+// @Return *Principal The caller, nil when the request is not authenticated.
+func PrincipalFrom(c *gin.Context) *Principal {
+	if value, ok := c.Get("principal"); ok {
+		if principal, ok := value.(*Principal); ok {
+			return principal
+		}
+	}
+	if value, ok := c.Get("claims"); ok {
+		if claims, ok := value.(*Claims); ok {
+			return principalFromClaims(claims)
+		}
+	}
+	return nil
+}
+
+// principalFromClaims builds the principal of a user authenticated with a JWT
+func principalFromClaims(claims *Claims) *Principal {
+	principal := &Principal{Subject: claims.subject(), Role: claims.Role, Kind: KindUser}
+	if claims.Scope != "" {
+		principal.Scopes = strings.Fields(claims.Scope)
+	}
+	return principal
+}
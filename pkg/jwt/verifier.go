@@ -0,0 +1,257 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Algorithms verified with the shared secret
+var hmacAlgorithms = []string{"HS256", "HS384", "HS512"}
+
+// Algorithms verified with the public keys of a KeySet
+var asymmetricAlgorithms = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Verifier validates tokens signed with a shared HMAC secret, the keys of a JWKS, or both
+// The algorithm of a token must be one of the accepted algorithms: HMAC tokens are only ever verified
+// with the secret and asymmetric ones with the key selected by their kid, so a public key can never be
+// used as an HMAC secret.
+type Verifier struct {
+	secret     []byte   // Shared secret of the HMAC algorithms, nil to reject them
+	keys       *KeySet  // Public keys of the asymmetric algorithms, nil to reject them
+	algorithms []string // Accepted "alg" header values
+
+	issuers        []string       // Accepted "iss" values, empty to accept any
+	audiences      []string       // Accepted "aud" values, one of which the token must name; empty to accept any
+	leeway         time.Duration  // Clock skew tolerated on exp, nbf and iat
+	maxLifetime    time.Duration  // Longest exp - iat accepted, 0 for no limit
+	requiredClaims []string       // Claims every token must carry with a non-empty value
+	revocations    RevocationList // Revoked tokens, nil when tokens cannot be revoked
+}
+
+// RevocationList tells whether a token has been revoked before its expiry
+type RevocationList interface {
+	IsRevoked(jti, subject string, issuedAt, now time.Time) (bool, error)
+}
+
+// VerifierOption configures a Verifier
+type VerifierOption func(*Verifier)
+
+// WithHMACSecret accepts tokens signed with a shared secret
+// @Param secret string The secret; empty to reject HMAC tokens.
+// @Return VerifierOption The option.
+func WithHMACSecret(secret string) VerifierOption {
+	return func(v *Verifier) {
+		if secret != "" {
+			v.secret = []byte(secret)
+		}
+	}
+}
+
+// WithKeySet accepts tokens signed with the keys of a JWKS
+// @Param keys *KeySet The key set; nil to reject asymmetric tokens.
+// @Return VerifierOption The option.
+func WithKeySet(keys *KeySet) VerifierOption {
+	return func(v *Verifier) {
+		v.keys = keys
+	}
+}
+
+// WithAlgorithms restricts the accepted algorithms
+// @Param algorithms ...string The "alg" values to accept (e.g., "RS256", "ES256", "EdDSA", "HS256").
+// @Return VerifierOption The option.
+func WithAlgorithms(algorithms ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.algorithms = algorithms
+	}
+}
+
+// WithIssuer only accepts tokens issued by one of the given issuers
+// @Param issuers ...string The accepted "iss" values.
+// @Return VerifierOption The option.
+func WithIssuer(issuers ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.issuers = issuers
+	}
+}
+
+// WithAudience only accepts tokens intended for one of the given audiences
+// @Param audiences ...string The accepted "aud" values (e.g., "jobs-service").
+// @Return VerifierOption The option.
+func WithAudience(audiences ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.audiences = audiences
+	}
+}
+
+// WithLeeway tolerates clock skew between the issuer and the service
+// @Param leeway time.Duration How far exp, nbf and iat may be off (default: 0).
+// @Return VerifierOption The option.
+func WithLeeway(leeway time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+// WithMaxLifetime rejects the tokens valid for longer than the given lifetime, or without iat
+// It must not exceed the time revocations are kept, or a revoked token would become valid again.
+// @Param lifetime time.Duration The longest exp - iat accepted (default: 0, no limit).
+// @Return VerifierOption The option.
+func WithMaxLifetime(lifetime time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.maxLifetime = lifetime
+	}
+}
+
+// WithRequiredClaims rejects tokens lacking any of the given claims
+// @Param names ...string The claims that must be present and non-empty (e.g., "role").
+// @Return VerifierOption The option.
+func WithRequiredClaims(names ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.requiredClaims = names
+	}
+}
+
+// WithRevocationList rejects the tokens revoked before their expiry
+// @Param revocations RevocationList The revoked tokens (e.g., a revocation.Store).
+// @Return VerifierOption The option.
+func WithRevocationList(revocations RevocationList) VerifierOption {
+	return func(v *Verifier) {
+		v.revocations = revocations
+	}
+}
+
+// NewVerifier creates a token verifier
+// @Description Without WithAlgorithms, every HMAC algorithm is accepted when a secret is set and every
+// supported asymmetric algorithm (RS256/384/512, ES256/384/512, EdDSA) when a key set is.
+// @Param opts ...VerifierOption The secret, key set and algorithms.
+// @Return *Verifier The verifier.
+// @Return error An error if an algorithm is unsupported or lacks its secret or key set.
+func NewVerifier(opts ...VerifierOption) (*Verifier, error) {
+	v := &Verifier{}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.algorithms == nil {
+		if v.secret != nil {
+			v.algorithms = append(v.algorithms, hmacAlgorithms...)
+		}
+		if v.keys != nil {
+			v.algorithms = append(v.algorithms, asymmetricAlgorithms...)
+		}
+	}
+	if len(v.algorithms) == 0 {
+		return nil, errors.New("no token algorithm accepted: set a secret or a key set")
+	}
+	for _, alg := range v.algorithms {
+		switch {
+		case slices.Contains(hmacAlgorithms, alg):
+			if v.secret == nil {
+				return nil, fmt.Errorf("algorithm %s requires a secret", alg)
+			}
+		case slices.Contains(asymmetricAlgorithms, alg):
+			if v.keys == nil {
+				return nil, fmt.Errorf("algorithm %s requires a key set", alg)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported algorithm %q", alg)
+		}
+	}
+	return v, nil
+}
+
+// hmacVerifier returns a verifier accepting the HMAC algorithms with the given secret
+func hmacVerifier(secretKey string) *Verifier {
+	return &Verifier{secret: []byte(secretKey), algorithms: hmacAlgorithms}
+}
+
+// Validate validates a JWT and returns its claims if valid
+// @Description The error explains why the token was rejected; it is meant for logs, not for clients.
+// @Param tokenString string The JWT string to be validated.
+// @Return *Claims The claims extracted from the valid token.
+// @Return error An error if the token is invalid, uses a rejected algorithm or an unknown key, its
+// claims are expired or do not match the accepted issuer, audience and required claims, or it is revoked.
+func (v *Verifier) Validate(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	// Time claims are checked by validateClaims, with the leeway
+	token, err := jwt.ParseWithClaims(tokenString, claims, v.key,
+		jwt.WithValidMethods(v.algorithms), jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	now := time.Now()
+	if err := v.validateClaims(claims, now); err != nil {
+		return nil, err
+	}
+	if err := v.checkRevocation(claims, now); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// key selects the key verifying a token, checking it suits the algorithm of the token
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	if strings.HasPrefix(alg, "HS") {
+		if v.secret == nil {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return v.secret, nil
+	}
+	if v.keys == nil {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := v.keys.lookup(context.Background(), kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.alg != "" && key.alg != alg {
+		return nil, fmt.Errorf("key %q is restricted to %s", kid, key.alg)
+	}
+	var suitable bool
+	switch key.key.(type) {
+	case *rsa.PublicKey:
+		suitable = strings.HasPrefix(alg, "RS")
+	case *ecdsa.PublicKey:
+		suitable = strings.HasPrefix(alg, "ES")
+	case ed25519.PublicKey:
+		suitable = alg == "EdDSA"
+	}
+	if !suitable {
+		return nil, fmt.Errorf("key %q cannot verify %s", kid, alg)
+	}
+	return key.key, nil
+}
+
+// checkRevocation rejects revoked tokens; when the revocation list cannot be read, tokens are rejected too
+func (v *Verifier) checkRevocation(claims *Claims, now time.Time) error {
+	if v.revocations == nil {
+		return nil
+	}
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	revoked, err := v.revocations.IsRevoked(claims.ID, claims.subject(), issuedAt, now)
+	if err != nil {
+		return fmt.Errorf("check token revocation: %w", err)
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/poolcamacho/jobs-service/pkg/revocation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeJWKS writes a JWKS document holding the given keys, by kid, to path
+func writeJWKS(t *testing.T, path string, keys map[string]any) {
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+				"n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": encode(key)})
+		}
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// signToken signs claims (by default a subject and an expiry) with the given method and key, setting the kid header
+func signToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims ...jwt.MapClaims) string {
+	payload := jwt.MapClaims{"sub": "recruiter-1", "exp": time.Now().Add(time.Hour).Unix()}
+	if len(claims) > 0 {
+		payload = claims[0]
+	}
+	token := jwt.NewWithClaims(method, payload)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerifier_Signatures(t *testing.T) {
+	// Setup
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"rsa-1": &rsaKey.PublicKey, "ed-1": edPublic})
+
+	keys, err := NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"), WithKeySet(keys))
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	// A public key used as the HMAC secret must not verify anything
+	confused := signToken(t, jwt.SigningMethodHS256, publicKeyPEM(t, &rsaKey.PublicKey), "rsa-1")
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"RS256 selected by kid", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1"), true},
+		{"EdDSA selected by kid", signToken(t, jwt.SigningMethodEdDSA, edPrivate, "ed-1"), true},
+		{"HS256 with the shared secret", signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), ""), true},
+		{"HS256 with another secret", signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), ""), false},
+		{"public key as HMAC secret", confused, false},
+		{"unsigned", signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, ""), false},
+		{"unknown kid", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-9"), false},
+		{"wrong key for kid", signToken(t, jwt.SigningMethodRS256, otherKey, "rsa-1"), false},
+		{"algorithm not allowed for key", signToken(t, jwt.SigningMethodRS512, rsaKey, "rsa-1"), false},
+		{"key type mismatch", signToken(t, jwt.SigningMethodRS256, rsaKey, "ed-1"), false},
+		{"no kid with several keys", signToken(t, jwt.SigningMethodRS256, rsaKey, ""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			claims, err := verifier.Validate(tt.token)
+
+			// Assertions
+			if tt.valid {
+				require.NoError(t, err)
+				assert.Equal(t, "recruiter-1", claims.subject())
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// publicKeyPEM returns the PEM encoding of a public key, as an attacker would pass it as an HMAC secret
+func publicKeyPEM(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerifier_AlgorithmAllowList(t *testing.T) {
+	// Setup: HS256 disabled while RS256 is accepted
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]any{"rsa-1": &rsaKey.PublicKey})
+
+	keys, err := NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"), WithKeySet(keys), WithAlgorithms("RS256"))
+	require.NoError(t, err)
+
+	// Execute and assert: a single key is used for tokens without kid
+	_, err = verifier.Validate(signToken(t, jwt.SigningMethodRS256, rsaKey, ""))
+	assert.NoError(t, err)
+	_, err = verifier.Validate(signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), ""))
+	assert.Error(t, err)
+
+	tests := []struct {
+		name string
+		opts []VerifierOption
+	}{
+		{"no key source", nil},
+		{"asymmetric algorithm without key set", []VerifierOption{WithHMACSecret("shared-secret"), WithAlgorithms("ES256")}},
+		{"HMAC algorithm without secret", []VerifierOption{WithKeySet(keys), WithAlgorithms("HS256")}},
+		{"unknown algorithm", []VerifierOption{WithHMACSecret("shared-secret"), WithAlgorithms("none")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			_, err := NewVerifier(tt.opts...)
+
+			// Assertions: the configuration is rejected upfront
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifier_Revocation(t *testing.T) {
+	// Setup
+	store := revocation.NewMemoryStore()
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"), WithRevocationList(store))
+	require.NoError(t, err)
+	now := time.Now()
+	sign := func(jti, subject string, issuedAt time.Time) string {
+		return signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), "", jwt.MapClaims{
+			"sub": subject, "jti": jti, "iat": issuedAt.Unix(), "exp": now.Add(time.Hour).Unix()})
+	}
+	leaked := sign("tok-leaked", "recruiter-1", now.Add(-time.Hour))
+	other := sign("tok-other", "recruiter-1", now.Add(-time.Hour))
+	colleague := sign("tok-colleague", "recruiter-2", now.Add(-time.Hour))
+
+	// Execute: revoke one token by jti, then every token of recruiter-1 issued so far
+	require.NoError(t, store.RevokeToken("tok-leaked", now.Add(time.Hour)))
+	_, leakedErr := verifier.Validate(leaked)
+	_, otherErr := verifier.Validate(other)
+	require.NoError(t, store.RevokeSubject("recruiter-1", now.Add(-time.Minute), now.Add(time.Hour)))
+	_, otherAfterErr := verifier.Validate(other)
+	_, colleagueErr := verifier.Validate(colleague)
+	_, newErr := verifier.Validate(sign("tok-new", "recruiter-1", now))
+
+	// Assertions
+	assert.ErrorIs(t, leakedErr, ErrTokenRevoked)
+	assert.NoError(t, otherErr)
+	assert.ErrorIs(t, otherAfterErr, ErrTokenRevoked)
+	assert.NoError(t, colleagueErr)
+	assert.NoError(t, newErr)
+}
+
+// failingRevocations cannot be read
+type failingRevocations struct{}
+
+func (failingRevocations) IsRevoked(string, string, time.Time, time.Time) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestVerifier_RevocationUnavailable(t *testing.T) {
+	// Setup
+	verifier, err := NewVerifier(WithHMACSecret("shared-secret"), WithRevocationList(failingRevocations{}))
+	require.NoError(t, err)
+
+	// Execute
+	_, err = verifier.Validate(signToken(t, jwt.SigningMethodHS256, []byte("shared-secret"), ""))
+
+	// Assertions: tokens are rejected rather than accepted unchecked
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTokenRevoked)
+}
@@ -0,0 +1,184 @@
+package loginguard
+
+import "time"
+
+// Record is the failed logins counted under a key (an account or a client IP)
+// The zero Record means no failure counted.
+type Record struct {
+	Failures    int       // Failed logins since the record was created
+	LastFailure time.Time // When the last failure was counted
+}
+
+// Store keeps the failed-login records
+// MemoryStore keeps them in the process; implement Store on a shared database for the counters
+// to hold across replicas. Implementations must be safe for concurrent use.
+type Store interface {
+	// Reserve counts an attempt under key, unless allow rejects the current record
+	// allow is called with the record as of now (the zero Record if there is none or it expired before
+	// now), and the check and the increment are atomic: concurrent reservations of the same key see
+	// each other. When allow returns false nothing changes and the current record is returned.
+	// Otherwise the record, expiring ttl after now, is returned with the attempt counted; an expired
+	// record starts again at one.
+	Reserve(key string, now time.Time, ttl time.Duration, allow func(Record) bool) (Record, bool, error)
+	// Release uncounts an attempt reserved under key
+	Release(key string) error
+	// Reset forgets the failures counted under key
+	Reset(key string) error
+}
+
+// Policy sets when failed logins slow down and lock out further attempts
+// A zero limit disables the lockout for that counter.
+type Policy struct {
+	MaxAccountFailures int           // Failures of an account, from any IP, before it is locked
+	MaxIPFailures      int           // Failures from an IP, on any account, before it is locked
+	LockoutDuration    time.Duration // How long a lockout lasts after the failure that caused it
+	FailureWindow      time.Duration // How long failures are remembered after the last one
+	BaseDelay          time.Duration // Wait imposed on an account after its first failure, doubled by each further one
+	MaxDelay           time.Duration // Longest wait imposed between two failures of an account
+}
+
+// Decision tells whether a login attempt may proceed
+type Decision struct {
+	RetryAfter time.Duration // Wait before the next attempt; zero if it may proceed now
+	Locked     bool          // True if the wait is a lockout rather than a progressive delay
+}
+
+// Allowed reports whether the attempt may proceed
+func (d Decision) Allowed() bool {
+	return d.RetryAfter <= 0
+}
+
+// Outcome reports the counters after a failed login
+type Outcome struct {
+	AccountFailures int  // Failures counted for the account
+	IPFailures      int  // Failures counted for the IP
+	AccountLocked   bool // True if this failure locked the account
+	IPLocked        bool // True if this failure locked the IP
+}
+
+// Attempt is a login attempt counted by Reserve before its credentials are checked
+// It counts as a failure from the start, so a failed attempt needs nothing more; pass a successful
+// one to Succeed.
+type Attempt struct {
+	Outcome // The counters, and the lockouts caused, should the attempt fail
+
+	account    string
+	ip         string
+	ipReserved bool // Whether the attempt is counted for the IP
+}
+
+// Guard applies a Policy to the failed-login records of a Store
+// Accounts get progressively longer delays between failures and a lockout once they reach
+// MaxAccountFailures; IPs are only locked out, as many users may share one. Attempts are counted
+// before the credentials are checked. A success resets the account counter but only uncounts itself
+// from the IP one, so an attacker owning an account cannot clear the counter of the IP they guess
+// other accounts from.
+type Guard struct {
+	store  Store
+	policy Policy
+}
+
+// NewGuard creates a guard
+// @Param store Store The store of the failed-login records.
+// @Param policy Policy The delays and lockouts to apply.
+// @Return *Guard The guard.
+func NewGuard(store Store, policy Policy) *Guard {
+	return &Guard{store: store, policy: policy}
+}
+
+// Reserve counts a login attempt to account from ip at now, unless it has to wait
+// @Description The IP and then the account are checked and counted atomically, so parallel attempts
+// cannot all pass the check before any of them is counted. A rejected attempt is not counted.
+// @Param account string The account identifier (e.g., the normalised email).
+// @Param ip string The client IP, empty if unknown.
+// @Param now time.Time The time of the attempt.
+// @Return Decision The wait imposed on the IP or the account; when it is not allowed, nothing is counted.
+// @Return Attempt The counted attempt, to pass to Succeed if the credentials are valid.
+// @Return error An error if the store fails.
+func (g *Guard) Reserve(account, ip string, now time.Time) (Decision, Attempt, error) {
+	attempt := Attempt{account: account, ip: ip}
+	ttl := max(g.policy.FailureWindow, g.policy.LockoutDuration)
+	var decision Decision
+	if ip != "" {
+		record, ok, err := g.store.Reserve(ipKey(ip), now, ttl, func(record Record) bool {
+			decision = g.wait(record, g.policy.MaxIPFailures, false, now)
+			return decision.Allowed()
+		})
+		if err != nil || !ok {
+			return decision, attempt, err
+		}
+		attempt.ipReserved = true
+		attempt.IPFailures = record.Failures
+		attempt.IPLocked = record.Failures == g.policy.MaxIPFailures
+	}
+
+	record, ok, err := g.store.Reserve(accountKey(account), now, ttl, func(record Record) bool {
+		decision = g.wait(record, g.policy.MaxAccountFailures, true, now)
+		return decision.Allowed()
+	})
+	if err != nil || !ok {
+		// Attempts on a locked account do not count against the IP
+		if attempt.ipReserved {
+			if releaseErr := g.store.Release(ipKey(ip)); releaseErr != nil && err == nil {
+				err = releaseErr
+			}
+			attempt.ipReserved = false
+		}
+		return decision, attempt, err
+	}
+	attempt.AccountFailures = record.Failures
+	attempt.AccountLocked = record.Failures == g.policy.MaxAccountFailures
+	return Decision{}, attempt, nil
+}
+
+// Succeed resets the failures of the account of a successful attempt and uncounts it for the IP
+// @Param attempt Attempt The attempt returned by Reserve.
+// @Return error An error if the store fails.
+func (g *Guard) Succeed(attempt Attempt) error {
+	if err := g.store.Reset(accountKey(attempt.account)); err != nil {
+		return err
+	}
+	if attempt.ipReserved {
+		return g.store.Release(ipKey(attempt.ip))
+	}
+	return nil
+}
+
+// Unlock lifts the lockout and delays of an account
+// @Param account string The account identifier.
+// @Return error An error if the store fails.
+func (g *Guard) Unlock(account string) error {
+	return g.store.Reset(accountKey(account))
+}
+
+// wait returns the wait a record imposes at now
+func (g *Guard) wait(record Record, maxFailures int, delays bool, now time.Time) Decision {
+	if record.Failures == 0 {
+		return Decision{}
+	}
+	if maxFailures > 0 && record.Failures >= maxFailures {
+		return Decision{RetryAfter: record.LastFailure.Add(g.policy.LockoutDuration).Sub(now), Locked: true}
+	}
+	if !delays || g.policy.BaseDelay <= 0 {
+		return Decision{}
+	}
+
+	delay := g.policy.BaseDelay
+	for i := 1; i < record.Failures && (g.policy.MaxDelay <= 0 || delay < g.policy.MaxDelay); i++ {
+		delay *= 2
+	}
+	if g.policy.MaxDelay > 0 {
+		delay = min(delay, g.policy.MaxDelay)
+	}
+	return Decision{RetryAfter: record.LastFailure.Add(delay).Sub(now)}
+}
+
+// accountKey returns the store key of an account
+func accountKey(account string) string {
+	return "account:" + account
+}
+
+// ipKey returns the store key of a client IP
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
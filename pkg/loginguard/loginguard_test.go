@@ -0,0 +1,268 @@
+package loginguard
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestGuard_DelayDoublesUpToCap(t *testing.T) {
+	// Setup: no lockout, so only the delays apply
+	guard := NewGuard(NewMemoryStore(), Policy{
+		FailureWindow: time.Hour,
+		BaseDelay:     time.Second,
+		MaxDelay:      10 * time.Second,
+	})
+
+	// Execute and assert: each failure doubles the wait until the cap
+	now := testStart
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		decision, attempt, err := guard.Reserve("ana@example.com", "192.0.2.1", now)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed(), "attempt %d", i+1)
+		assert.Equal(t, i+1, attempt.AccountFailures)
+
+		decision, _, err = guard.Reserve("ana@example.com", "192.0.2.1", now.Add(want-time.Millisecond))
+		require.NoError(t, err)
+		assert.Equal(t, time.Millisecond, decision.RetryAfter, "after %d failures", i+1)
+		assert.False(t, decision.Locked)
+		now = now.Add(want)
+	}
+}
+
+func TestGuard_Wait(t *testing.T) {
+	guard := NewGuard(NewMemoryStore(), Policy{
+		MaxAccountFailures: 4,
+		LockoutDuration:    15 * time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           3 * time.Second,
+	})
+
+	tests := []struct {
+		name     string
+		failures int
+		delays   bool
+		want     Decision
+	}{
+		{"no failure", 0, true, Decision{}},
+		{"first failure", 1, true, Decision{RetryAfter: time.Second}},
+		{"doubled", 2, true, Decision{RetryAfter: 2 * time.Second}},
+		{"capped", 3, true, Decision{RetryAfter: 3 * time.Second}},
+		{"locked at the limit", 4, true, Decision{RetryAfter: 15 * time.Minute, Locked: true}},
+		{"locked past the limit", 9, true, Decision{RetryAfter: 15 * time.Minute, Locked: true}},
+		{"no delays below the limit", 3, false, Decision{}},
+		{"locked without delays", 4, false, Decision{RetryAfter: 15 * time.Minute, Locked: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := Record{Failures: tt.failures, LastFailure: testStart}
+			assert.Equal(t, tt.want, guard.wait(record, 4, tt.delays, testStart))
+		})
+	}
+}
+
+func TestGuard_LockoutAtMaxAccountFailures(t *testing.T) {
+	// Setup
+	guard := NewGuard(NewMemoryStore(), Policy{
+		MaxAccountFailures: 3,
+		LockoutDuration:    15 * time.Minute,
+		FailureWindow:      time.Hour,
+	})
+
+	// Execute: three failures, from different IPs
+	var attempts []Attempt
+	for i := 0; i < 3; i++ {
+		decision, attempt, err := guard.Reserve("ana@example.com", "192.0.2."+strconv.Itoa(i), testStart)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed())
+		attempts = append(attempts, attempt)
+	}
+	decision, attempt, err := guard.Reserve("ana@example.com", "192.0.2.9", testStart.Add(time.Minute))
+
+	// Assertions: only the third failure locks the account, for the lockout duration from then
+	require.NoError(t, err)
+	assert.False(t, attempts[0].AccountLocked)
+	assert.False(t, attempts[1].AccountLocked)
+	assert.True(t, attempts[2].AccountLocked)
+	assert.Equal(t, 3, attempts[2].AccountFailures)
+	assert.Equal(t, Decision{RetryAfter: 14 * time.Minute, Locked: true}, decision)
+	assert.Zero(t, attempt.AccountFailures)
+}
+
+func TestGuard_LockoutExpires(t *testing.T) {
+	tests := []struct {
+		name         string
+		window       time.Duration
+		wantFailures int // Failures counted by the first attempt after the lockout
+	}{
+		{"failures forgotten with the lockout", 15 * time.Minute, 1},
+		{"failures remembered after the lockout", time.Hour, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup: an account locked by two failures
+			guard := NewGuard(NewMemoryStore(), Policy{
+				MaxAccountFailures: 2,
+				LockoutDuration:    15 * time.Minute,
+				FailureWindow:      tt.window,
+			})
+			for i := 0; i < 2; i++ {
+				_, _, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+				require.NoError(t, err)
+			}
+
+			// Execute
+			before, _, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart.Add(15*time.Minute-time.Second))
+			require.NoError(t, err)
+			after, attempt, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart.Add(15*time.Minute))
+			require.NoError(t, err)
+
+			// Assertions
+			assert.Equal(t, Decision{RetryAfter: time.Second, Locked: true}, before)
+			assert.True(t, after.Allowed())
+			assert.Equal(t, tt.wantFailures, attempt.AccountFailures)
+		})
+	}
+}
+
+func TestGuard_IPCounterIsSeparate(t *testing.T) {
+	// Setup
+	guard := NewGuard(NewMemoryStore(), Policy{
+		MaxAccountFailures: 2,
+		MaxIPFailures:      3,
+		LockoutDuration:    15 * time.Minute,
+		FailureWindow:      time.Hour,
+	})
+	reserve := func(account, ip string) (Decision, Attempt) {
+		decision, attempt, err := guard.Reserve(account, ip, testStart)
+		require.NoError(t, err)
+		return decision, attempt
+	}
+
+	// Execute and assert: one IP guessing a different account each time is locked by its own counter
+	for i := 0; i < 3; i++ {
+		decision, attempt := reserve(strconv.Itoa(i)+"@example.com", "192.0.2.1")
+		require.True(t, decision.Allowed())
+		assert.Equal(t, 1, attempt.AccountFailures)
+		assert.Equal(t, i+1, attempt.IPFailures)
+		assert.Equal(t, i == 2, attempt.IPLocked)
+	}
+	decision, _ := reserve("fresh@example.com", "192.0.2.1")
+	assert.True(t, decision.Locked)
+
+	// The accounts it guessed are not locked, from another IP
+	decision, attempt := reserve("0@example.com", "198.51.100.1")
+	assert.True(t, decision.Allowed())
+	assert.Equal(t, 2, attempt.AccountFailures)
+	assert.Equal(t, 1, attempt.IPFailures)
+
+	// That account is now locked, and the attempt rejected on it is not counted against the IP
+	decision, _ = reserve("0@example.com", "203.0.113.1")
+	assert.True(t, decision.Locked)
+	_, attempt = reserve("fresh@example.com", "203.0.113.1")
+	assert.Equal(t, 1, attempt.IPFailures)
+}
+
+func TestGuard_WithoutIP(t *testing.T) {
+	// Setup
+	guard := NewGuard(NewMemoryStore(), Policy{MaxAccountFailures: 5, MaxIPFailures: 1, FailureWindow: time.Hour})
+
+	// Execute
+	for i := 0; i < 2; i++ {
+		_, _, err := guard.Reserve("ana@example.com", "", testStart)
+		require.NoError(t, err)
+	}
+	decision, attempt, err := guard.Reserve("ana@example.com", "", testStart)
+
+	// Assertions: only the account is counted
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+	assert.Equal(t, 3, attempt.AccountFailures)
+	assert.Zero(t, attempt.IPFailures)
+}
+
+func TestGuard_Succeed(t *testing.T) {
+	// Setup: two failures then a success from the same IP
+	guard := NewGuard(NewMemoryStore(), Policy{MaxAccountFailures: 5, MaxIPFailures: 10, FailureWindow: time.Hour})
+	for i := 0; i < 2; i++ {
+		_, _, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+		require.NoError(t, err)
+	}
+	_, success, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+	require.NoError(t, err)
+
+	// Execute
+	require.NoError(t, guard.Succeed(success))
+	_, attempt, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+
+	// Assertions: the account starts again, the IP keeps its failures but not the success
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempt.AccountFailures)
+	assert.Equal(t, 3, attempt.IPFailures)
+}
+
+func TestGuard_Unlock(t *testing.T) {
+	// Setup: a locked account
+	guard := NewGuard(NewMemoryStore(), Policy{
+		MaxAccountFailures: 2,
+		MaxIPFailures:      10,
+		LockoutDuration:    15 * time.Minute,
+		FailureWindow:      time.Hour,
+	})
+	for i := 0; i < 2; i++ {
+		_, _, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+		require.NoError(t, err)
+	}
+	locked, _, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+	require.NoError(t, err)
+	require.True(t, locked.Locked)
+
+	// Execute
+	require.NoError(t, guard.Unlock("ana@example.com"))
+	decision, attempt, err := guard.Reserve("ana@example.com", "192.0.2.1", testStart)
+
+	// Assertions: the account starts again, the IP does not
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+	assert.Equal(t, 1, attempt.AccountFailures)
+	assert.Equal(t, 3, attempt.IPFailures)
+}
+
+func TestGuard_ParallelReservations(t *testing.T) {
+	// Setup: no delays, so only the lockout limits the attempts made at the same instant
+	guard := NewGuard(NewMemoryStore(), Policy{
+		MaxAccountFailures: 5,
+		LockoutDuration:    15 * time.Minute,
+		FailureWindow:      time.Hour,
+	})
+
+	// Execute
+	const attempts = 50
+	allowed := make([]bool, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			decision, _, err := guard.Reserve("ana@example.com", "192.0.2."+strconv.Itoa(i), testStart)
+			assert.NoError(t, err)
+			allowed[i] = decision.Allowed()
+		}(i)
+	}
+	wg.Wait()
+
+	// Assertions
+	count := 0
+	for _, ok := range allowed {
+		if ok {
+			count++
+		}
+	}
+	assert.Equal(t, 5, count)
+}
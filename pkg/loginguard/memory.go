@@ -0,0 +1,96 @@
+package loginguard
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often a store drops the records past their expiry
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the failed-login records in the process
+// Records are lost on restart and not shared between replicas; use a shared store when running several.
+// Expired records are dropped as new attempts are counted. It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.Mutex
+	records   map[string]memoryRecord
+	lastSweep time.Time
+}
+
+// memoryRecord is a record and its expiry
+type memoryRecord struct {
+	Record
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+// @Return *MemoryStore The store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]memoryRecord)}
+}
+
+// Reserve counts an attempt under key, unless allow rejects the current record
+// The lock is held from the check to the increment.
+// @Param key string The account or IP key.
+// @Param now time.Time The time of the attempt.
+// @Param ttl time.Duration How long the record is kept after now.
+// @Param allow func(Record) bool Tells whether the attempt may proceed given the current record.
+// @Return Record The updated record, or the current one if the attempt was rejected.
+// @Return bool True if the attempt was counted.
+// @Return error Always nil.
+func (s *MemoryStore) Reserve(key string, now time.Time, ttl time.Duration, allow func(Record) bool) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	stored, ok := s.records[key]
+	if !ok || !now.Before(stored.expiresAt) {
+		stored = memoryRecord{}
+	}
+	if !allow(stored.Record) {
+		return stored.Record, false, nil
+	}
+	stored.Failures++
+	stored.LastFailure = now
+	stored.expiresAt = now.Add(ttl)
+	s.records[key] = stored
+	return stored.Record, true, nil
+}
+
+// Release uncounts an attempt reserved under key
+// @Param key string The account or IP key.
+// @Return error Always nil.
+func (s *MemoryStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.records[key]; ok && stored.Failures > 0 {
+		stored.Failures--
+		s.records[key] = stored
+	}
+	return nil
+}
+
+// Reset forgets the failures counted under key
+// @Param key string The account or IP key.
+// @Return error Always nil.
+func (s *MemoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// sweep drops the expired records, at most once per sweepInterval; the caller holds the lock
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, stored := range s.records {
+		if !now.Before(stored.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allowAll lets every attempt proceed
+func allowAll(Record) bool { return true }
+
+func TestMemoryStore_Reserve(t *testing.T) {
+	// Setup
+	store := NewMemoryStore()
+	var seen []Record
+	allow := func(record Record) bool {
+		seen = append(seen, record)
+		return true
+	}
+
+	// Execute: two attempts within the TTL, then one after it
+	first, ok1, err := store.Reserve("account:ana", testStart, time.Minute, allow)
+	require.NoError(t, err)
+	second, ok2, err := store.Reserve("account:ana", testStart.Add(59*time.Second), time.Minute, allow)
+	require.NoError(t, err)
+	third, ok3, err := store.Reserve("account:ana", testStart.Add(59*time.Second+time.Minute), time.Minute, allow)
+	require.NoError(t, err)
+
+	// Assertions: each attempt extends the TTL, and an expired record starts again
+	assert.True(t, ok1 && ok2 && ok3)
+	assert.Equal(t, Record{Failures: 1, LastFailure: testStart}, first)
+	assert.Equal(t, Record{Failures: 2, LastFailure: testStart.Add(59 * time.Second)}, second)
+	assert.Equal(t, Record{Failures: 1, LastFailure: testStart.Add(59*time.Second + time.Minute)}, third)
+	assert.Equal(t, []Record{{}, first, {}}, seen)
+}
+
+func TestMemoryStore_RejectedAttemptIsNotCounted(t *testing.T) {
+	// Setup
+	store := NewMemoryStore()
+	_, _, err := store.Reserve("account:ana", testStart, time.Minute, allowAll)
+	require.NoError(t, err)
+
+	// Execute
+	record, ok, err := store.Reserve("account:ana", testStart.Add(time.Second), time.Minute, func(Record) bool { return false })
+	require.NoError(t, err)
+	next, _, err := store.Reserve("account:ana", testStart.Add(2*time.Second), time.Minute, allowAll)
+	require.NoError(t, err)
+
+	// Assertions
+	assert.False(t, ok)
+	assert.Equal(t, Record{Failures: 1, LastFailure: testStart}, record)
+	assert.Equal(t, 2, next.Failures)
+}
+
+func TestMemoryStore_ReleaseAndReset(t *testing.T) {
+	// Setup
+	store := NewMemoryStore()
+	for i := 0; i < 2; i++ {
+		_, _, err := store.Reserve("ip:192.0.2.1", testStart, time.Minute, allowAll)
+		require.NoError(t, err)
+	}
+	_, _, err := store.Reserve("account:ana", testStart, time.Minute, allowAll)
+	require.NoError(t, err)
+
+	// Execute: releases never go below zero, and missing keys are ignored
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Release("ip:192.0.2.1"))
+	}
+	require.NoError(t, store.Release("ip:198.51.100.1"))
+	require.NoError(t, store.Reset("account:ana"))
+	ip, _, err := store.Reserve("ip:192.0.2.1", testStart, time.Minute, allowAll)
+	require.NoError(t, err)
+	account, _, err := store.Reserve("account:ana", testStart, time.Minute, allowAll)
+	require.NoError(t, err)
+
+	// Assertions
+	assert.Equal(t, 1, ip.Failures)
+	assert.Equal(t, 1, account.Failures)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	// Setup: a short-lived and a long-lived record
+	store := NewMemoryStore()
+	_, _, err := store.Reserve("ip:192.0.2.1", testStart, time.Minute, allowAll)
+	require.NoError(t, err)
+	_, _, err = store.Reserve("account:ana", testStart, time.Hour, allowAll)
+	require.NoError(t, err)
+
+	// Execute and assert: expired records stay until the next sweep is due
+	_, _, err = store.Reserve("account:bob", testStart.Add(sweepInterval-time.Second), time.Hour, allowAll)
+	require.NoError(t, err)
+	assert.Len(t, store.records, 3)
+
+	_, _, err = store.Reserve("account:bob", testStart.Add(2*sweepInterval), time.Hour, allowAll)
+	require.NoError(t, err)
+	assert.Len(t, store.records, 2)
+	assert.NotContains(t, store.records, "ip:192.0.2.1")
+}
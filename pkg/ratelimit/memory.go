@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often a store drops the buckets that have refilled
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the process
+// Each replica counts the requests it serves, so with N replicas a client gets up to N times the limit.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+// memoryBucket is a bucket and the period needed to refill it
+type memoryBucket struct {
+	state  State
+	period time.Duration
+}
+
+// NewMemoryStore creates an empty in-memory store
+// @Return *MemoryStore The store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]memoryBucket)}
+}
+
+// Take takes a token from the bucket stored under key
+// @Param key string The bucket.
+// @Param limit Limit The size and refill rate of the bucket.
+// @Param now time.Time The time of the request.
+// @Return Result Whether the request is allowed.
+// @Return error Always nil.
+func (s *MemoryStore) Take(key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	state, result := limit.Take(s.buckets[key].state, now)
+	s.buckets[key] = memoryBucket{state: state, period: limit.Period}
+	return result, nil
+}
+
+// sweep drops the buckets full again, which are equivalent to missing ones, at most once per sweepInterval
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.state.UpdatedAt) >= bucket.period {
+			delete(s.buckets, key)
+		}
+	}
+}
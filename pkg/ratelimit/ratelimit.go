@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: it holds up to Requests tokens and refills them evenly over Period
+// Each request takes one token, so a client can burst Requests requests and then sustain
+// Requests per Period.
+type Limit struct {
+	Requests int           // Capacity of the bucket
+	Period   time.Duration // Time to refill an empty bucket
+}
+
+// IsZero reports whether the limit is unset, meaning requests are not limited
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// String formats the limit as accepted by ParseLimit (e.g., "10/1m0s")
+func (l Limit) String() string {
+	return strconv.Itoa(l.Requests) + "/" + l.Period.String()
+}
+
+// State is the content of a bucket between two requests
+// The zero State is a full bucket.
+type State struct {
+	Tokens    float64   // Tokens left after the last request
+	UpdatedAt time.Time // When Tokens was computed; zero for a bucket never used
+}
+
+// Result reports the outcome of taking a token
+type Result struct {
+	Allowed    bool          // False if the bucket was empty
+	Remaining  int           // Whole tokens left
+	RetryAfter time.Duration // Wait before a token is available, zero when allowed
+	Reset      time.Duration // Wait before the bucket is full again
+}
+
+// Take refills the bucket for the time elapsed since its last use and takes a token if one is available
+// @Param state State The bucket before the request.
+// @Param now time.Time The time of the request.
+// @Return State The bucket after the request, to store for the next one.
+// @Return Result Whether the request is allowed, and the values of the rate limit headers.
+func (l Limit) Take(state State, now time.Time) (State, Result) {
+	capacity := float64(l.Requests)
+	perToken := l.Period.Seconds() / capacity // Seconds to refill one token
+
+	tokens := capacity
+	if !state.UpdatedAt.IsZero() {
+		elapsed := now.Sub(state.UpdatedAt).Seconds()
+		tokens = math.Min(capacity, state.Tokens+math.Max(0, elapsed)/perToken)
+	}
+
+	result := Result{Allowed: tokens >= 1}
+	if result.Allowed {
+		tokens--
+	} else {
+		result.RetryAfter = seconds((1 - tokens) * perToken)
+	}
+	result.Remaining = int(tokens)
+	result.Reset = seconds((capacity - tokens) * perToken)
+	return State{Tokens: tokens, UpdatedAt: now}, result
+}
+
+// seconds converts a number of seconds into a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Store holds the buckets of the clients
+// MemoryStore keeps them in the process; implement Store on a shared database for the limits
+// to hold across replicas.
+type Store interface {
+	// Take takes a token from the bucket stored under key
+	// @Param key string The bucket (e.g., a client and a route).
+	// @Param limit Limit The size and refill rate of the bucket.
+	// @Param now time.Time The time of the request.
+	// @Return Result Whether the request is allowed.
+	// @Return error An error if the store cannot be reached.
+	Take(key string, limit Limit, now time.Time) (Result, error)
+}
+
+// units maps the period suffixes accepted by ParseLimit to their duration
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseLimit parses a limit written as requests per period
+// @Description The period is a unit (s, m, h or d) or a Go duration, e.g. "10/m", "1000/h" or "5/30s".
+// @Param value string The limit.
+// @Return Limit The parsed limit.
+// @Return error An error if the value is malformed.
+func ParseLimit(value string) (Limit, error) {
+	count, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q: expected requests/period", value)
+	}
+	requests, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || requests < 1 {
+		return Limit{}, fmt.Errorf("rate limit %q: requests must be a positive integer", value)
+	}
+	period = strings.TrimSpace(period)
+	duration, ok := units[period]
+	if !ok {
+		if duration, err = time.ParseDuration(period); err != nil || duration <= 0 {
+			return Limit{}, fmt.Errorf("rate limit %q: unknown period %q", value, period)
+		}
+	}
+	return Limit{Requests: requests, Period: duration}, nil
+}
+
+// ParseRules parses a comma separated list of route limits
+// @Description Each rule is "METHOD /path=requests/period", the path being the route pattern
+// (e.g., "POST /jobs=30/m, PUT /jobs/:id=60/m"). An empty value gives no rules.
+// @Param value string The rules.
+// @Return map[string]Limit The limits keyed by "METHOD /path".
+// @Return error An error if a rule is malformed.
+func ParseRules(value string) (map[string]Limit, error) {
+	rules := make(map[string]Limit)
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		route, limit, ok := strings.Cut(rule, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok || !hasPath || !strings.HasPrefix(strings.TrimSpace(path), "/") {
+			return nil, fmt.Errorf("rate limit rule %q: expected METHOD /path=requests/period", rule)
+		}
+		parsed, err := ParseLimit(limit)
+		if err != nil {
+			return nil, err
+		}
+		rules[strings.ToUpper(method)+" "+strings.TrimSpace(path)] = parsed
+	}
+	return rules, nil
+}
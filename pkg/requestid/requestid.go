@@ -0,0 +1,50 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header is the HTTP header carrying the request ID
+const Header = "X-Request-ID"
+
+// contextKey is the gin context key under which the request ID is stored
+const contextKey = "request_id"
+
+// maxLength bounds the length of request IDs accepted from clients
+const maxLength = 128
+
+// Middleware assigns an ID to every request
+// @Description Reuses the X-Request-ID header sent by the client (or a proxy) when present,
+// generates a random one otherwise, and echoes it in the response.
+// @Return gin.HandlerFunc The middleware function for Gin.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if id == "" || len(id) > maxLength {
+			id = generate()
+		}
+
+		c.Set(contextKey, id)
+		c.Header(Header, id)
+		c.Next()
+	}
+}
+
+// Get returns the ID assigned to the request by Middleware
+// @Param c *gin.Context The request context.
+// @Return string The request ID, empty when Middleware is not registered.
+func Get(c *gin.Context) string {
+	return c.GetString(contextKey)
+}
+
+// generate returns a random 128-bit ID encoded as hex
+func generate() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
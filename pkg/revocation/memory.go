@@ -0,0 +1,90 @@
+package revocation
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often a store drops the revocations past their expiry
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the revocations in the process
+// Revocations are lost on restart and not shared between replicas; use a shared store when running several.
+// Expired revocations are dropped as new ones are added. It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	tokens    map[string]time.Time     // Expiry of the revoked tokens, by jti
+	subjects  map[string]SubjectCutoff // Revoked subjects
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+// @Return *MemoryStore The store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]time.Time), subjects: make(map[string]SubjectCutoff)}
+}
+
+// RevokeToken revokes the token with the given jti until it expires
+// @Param jti string The "jti" claim of the token.
+// @Param until time.Time When the token expires.
+// @Return error Always nil.
+func (s *MemoryStore) RevokeToken(jti string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(time.Now())
+	if until.After(s.tokens[jti]) {
+		s.tokens[jti] = until
+	}
+	return nil
+}
+
+// RevokeSubject revokes the tokens of a subject issued at or before the given time, until they expire
+// @Param subject string The subject of the tokens.
+// @Param before time.Time The cutoff time.
+// @Param until time.Time When the tokens covered by the cutoff have expired.
+// @Return error Always nil.
+func (s *MemoryStore) RevokeSubject(subject string, before, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(time.Now())
+	s.subjects[subject] = SubjectCutoff{Before: before, Until: until}
+	return nil
+}
+
+// IsRevoked reports whether a token is revoked at now
+// @Param jti string The "jti" claim of the token, empty if none.
+// @Param subject string The subject of the token.
+// @Param issuedAt time.Time The "iat" claim of the token, zero if none.
+// @Param now time.Time The time of the request.
+// @Return bool True if the token is revoked.
+// @Return error Always nil.
+func (s *MemoryStore) IsRevoked(jti, subject string, issuedAt, now time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if until, ok := s.tokens[jti]; ok && jti != "" && now.Before(until) {
+		return true, nil
+	}
+	cutoff, ok := s.subjects[subject]
+	return ok && now.Before(cutoff.Until) && cutoff.Covers(issuedAt), nil
+}
+
+// sweep drops the expired revocations, at most once per sweepInterval; the caller holds the write lock
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for jti, until := range s.tokens {
+		if !now.Before(until) {
+			delete(s.tokens, jti)
+		}
+	}
+	for subject, cutoff := range s.subjects {
+		if !now.Before(cutoff.Until) {
+			delete(s.subjects, subject)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package revocation
+
+import "time"
+
+// Store keeps the revoked tokens until they would have expired anyway
+// Tokens are revoked one by one by their jti, or all the tokens of a subject issued up to a point in time
+// at once (e.g., after a password change or a compromised account). Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// RevokeToken revokes the token with the given jti until it expires
+	RevokeToken(jti string, until time.Time) error
+	// RevokeSubject revokes the tokens of a subject issued at or before the given time, until they expire
+	// A later revocation of the same subject replaces an earlier one.
+	RevokeSubject(subject string, before, until time.Time) error
+	// IsRevoked reports whether a token is revoked at now
+	// A token without jti can only be revoked through its subject, and one without issue time
+	// (zero issuedAt) is revoked by any revocation of its subject.
+	IsRevoked(jti, subject string, issuedAt, now time.Time) (bool, error)
+}
+
+// SubjectCutoff is the revocation of the tokens of a subject
+type SubjectCutoff struct {
+	Before time.Time // Tokens issued at or before this time are revoked
+	Until  time.Time // When the revocation can be forgotten, as the tokens it covers have expired
+}
+
+// Covers reports whether the cutoff revokes a token issued at issuedAt
+// @Param issuedAt time.Time The "iat" of the token, zero when unknown.
+// @Return bool True if the token is revoked.
+func (c SubjectCutoff) Covers(issuedAt time.Time) bool {
+	// iat has second precision: a token issued in the second of the cutoff is revoked too
+	return issuedAt.IsZero() || !issuedAt.After(c.Before)
+}
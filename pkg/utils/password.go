@@ -1,36 +1,67 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrPasswordMismatch is returned when a password does not match its hash
+var ErrPasswordMismatch = errors.New("password does not match")
 
 // PasswordUtils defines methods for password operations
 // @Description Interface that provides methods for hashing and verifying passwords.
+// Every implementation checks both bcrypt and Argon2id hashes, so the algorithm can be changed
+// without invalidating the stored passwords; NeedsRehash tells which hashes to upgrade.
 type PasswordUtils interface {
-	// HashPassword hashes a plain-text password using bcrypt
-	// @Description Hashes a plain-text password using the bcrypt algorithm.
+	// HashPassword hashes a plain-text password
+	// @Description Hashes a plain-text password with the algorithm and parameters of the implementation.
 	// @Param password string The plain-text password to hash.
 	// @Return string The hashed password as a string.
 	// @Return error An error if the hashing process fails.
 	HashPassword(password string) (string, error)
 
 	// CheckPassword compares a plain-text password with its hashed counterpart
-	// @Description Validates if a plain-text password matches its hashed counterpart using bcrypt.
+	// @Description Validates if a plain-text password matches its bcrypt or Argon2id hash.
 	// @Param hashedPassword string The hashed password.
 	// @Param plainPassword string The plain-text password to compare.
 	// @Return error An error if the passwords do not match or if the comparison fails.
 	CheckPassword(hashedPassword, plainPassword string) error
+
+	// NeedsRehash reports whether a hash was made with another algorithm or other parameters
+	// @Description Used after a successful login to upgrade the stored hash transparently.
+	// @Param hashedPassword string The hashed password.
+	// @Return bool True if the password should be hashed again.
+	NeedsRehash(hashedPassword string) bool
 }
 
 // DefaultPasswordUtils is the default implementation of PasswordUtils
 // @Description Default implementation of the PasswordUtils interface using bcrypt.
-type DefaultPasswordUtils struct{}
+type DefaultPasswordUtils struct {
+	Cost int // bcrypt cost; zero means bcrypt.DefaultCost
+}
+
+// cost returns the configured bcrypt cost
+func (d *DefaultPasswordUtils) cost() int {
+	if d.Cost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return d.Cost
+}
 
 // HashPassword hashes a plain-text password using bcrypt
-// @Description Hashes a plain-text password using the bcrypt algorithm with the default cost.
+// @Description Hashes a plain-text password using the bcrypt algorithm with the configured cost.
 // @Param password string The plain-text password to hash.
 // @Return string The hashed password as a string.
-// @Return error An error if the hashing process fails.
+// @Return error An error if the hashing process fails (e.g., the password is longer than 72 bytes).
 func (d *DefaultPasswordUtils) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), d.cost())
 	if err != nil {
 		return "", err
 	}
@@ -38,10 +69,152 @@ func (d *DefaultPasswordUtils) HashPassword(password string) (string, error) {
 }
 
 // CheckPassword compares a plain-text password with its hashed counterpart
-// @Description Validates if a plain-text password matches its hashed counterpart using bcrypt.
+// @Description Validates if a plain-text password matches its bcrypt or Argon2id hash.
 // @Param hashedPassword string The hashed password.
 // @Param plainPassword string The plain-text password to compare.
 // @Return error An error if the passwords do not match or if the comparison fails.
 func (d *DefaultPasswordUtils) CheckPassword(hashedPassword, plainPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+	return checkPassword(hashedPassword, plainPassword)
+}
+
+// NeedsRehash reports whether a hash is not a bcrypt hash of the configured cost
+// @Param hashedPassword string The hashed password.
+// @Return bool True if the password should be hashed again.
+func (d *DefaultPasswordUtils) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != d.cost()
+}
+
+// Argon2idPasswordUtils implements PasswordUtils with Argon2id (RFC 9106)
+// @Description Hashes are stored in the PHC string format: $argon2id$v=19$m=<KiB>,t=<passes>,p=<lanes>$<salt>$<key>.
+// Zero parameters take the defaults of NewArgon2idPasswordUtils.
+type Argon2idPasswordUtils struct {
+	Memory      uint32 // Memory in KiB
+	Iterations  uint32 // Number of passes over the memory
+	Parallelism uint8  // Number of lanes
+	SaltLength  uint32 // Length of the random salt in bytes
+	KeyLength   uint32 // Length of the derived key in bytes
+}
+
+// NewArgon2idPasswordUtils returns an Argon2id hasher with the recommended parameters
+// @Description 64 MiB of memory, 3 passes and 2 lanes, with a 16-byte salt and a 32-byte key.
+// @Return *Argon2idPasswordUtils The hasher.
+func NewArgon2idPasswordUtils() *Argon2idPasswordUtils {
+	return &Argon2idPasswordUtils{Memory: 64 * 1024, Iterations: 3, Parallelism: 2, SaltLength: 16, KeyLength: 32}
+}
+
+// params returns the parameters, zero values replaced by the defaults
+func (a *Argon2idPasswordUtils) params() argon2Params {
+	defaults := NewArgon2idPasswordUtils()
+	p := argon2Params{memory: a.Memory, iterations: a.Iterations, parallelism: a.Parallelism, keyLength: a.KeyLength}
+	if p.memory == 0 {
+		p.memory = defaults.Memory
+	}
+	if p.iterations == 0 {
+		p.iterations = defaults.Iterations
+	}
+	if p.parallelism == 0 {
+		p.parallelism = defaults.Parallelism
+	}
+	if p.keyLength == 0 {
+		p.keyLength = defaults.KeyLength
+	}
+	return p
+}
+
+// HashPassword hashes a plain-text password using Argon2id
+// @Description Derives a key from the password and a random salt; both are encoded in the returned PHC string.
+// @Param password string The plain-text password to hash.
+// @Return string The hashed password as a string.
+// @Return error An error if the salt cannot be generated.
+func (a *Argon2idPasswordUtils) HashPassword(password string) (string, error) {
+	saltLength := a.SaltLength
+	if saltLength == 0 {
+		saltLength = NewArgon2idPasswordUtils().SaltLength
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	p := a.params()
+	key := argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, p.keyLength)
+	return p.encode(salt, key), nil
+}
+
+// CheckPassword compares a plain-text password with its hashed counterpart
+// @Description Validates if a plain-text password matches its Argon2id or bcrypt hash.
+// @Param hashedPassword string The hashed password.
+// @Param plainPassword string The plain-text password to compare.
+// @Return error An error if the passwords do not match or if the comparison fails.
+func (a *Argon2idPasswordUtils) CheckPassword(hashedPassword, plainPassword string) error {
+	return checkPassword(hashedPassword, plainPassword)
+}
+
+// NeedsRehash reports whether a hash is not an Argon2id hash of the configured parameters
+// @Param hashedPassword string The hashed password.
+// @Return bool True if the password should be hashed again.
+func (a *Argon2idPasswordUtils) NeedsRehash(hashedPassword string) bool {
+	p, _, _, err := decodeArgon2id(hashedPassword)
+	return err != nil || p != a.params()
+}
+
+// argon2Params are the parameters of an Argon2id hash
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	keyLength   uint32
+}
+
+// encode formats a salt and key derived with the parameters as a PHC string
+func (p argon2Params) encode(salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.memory, p.iterations, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+// decodeArgon2id parses an Argon2id PHC string
+func decodeArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var p argon2Params
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return p, nil, nil, errors.New("not an argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, errors.New("unsupported argon2 version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return p, nil, nil, errors.New("invalid argon2 parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, errors.New("invalid argon2 salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, errors.New("invalid argon2 key")
+	}
+	p.keyLength = uint32(len(key))
+	return p, salt, key, nil
+}
+
+// checkPassword verifies a password against a bcrypt or Argon2id hash
+func checkPassword(hashedPassword, plainPassword string) error {
+	if !strings.HasPrefix(hashedPassword, "$argon2id$") {
+		err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+
+	p, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return err
+	}
+	derived := argon2.IDKey([]byte(plainPassword), salt, p.iterations, p.memory, p.parallelism, p.keyLength)
+	if subtle.ConstantTimeCompare(derived, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
 }
@@ -18,3 +18,8 @@ func (m *MockPasswordUtils) CheckPassword(hashedPassword, plainPassword string)
 	args := m.Called(hashedPassword, plainPassword)
 	return args.Error(0)
 }
+
+func (m *MockPasswordUtils) NeedsRehash(hashedPassword string) bool {
+	args := m.Called(hashedPassword)
+	return args.Bool(0)
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Password policy violations returned by PasswordPolicy.Check
+var (
+	ErrPasswordTooShort   = errors.New("password is too short")
+	ErrPasswordTooLong    = errors.New("password is too long")
+	ErrPasswordBreached   = errors.New("password appears in a list of breached passwords")
+	ErrPasswordTooSimilar = errors.New("password is too similar to the username or email")
+)
+
+// minSimilarityFragmentSize is the shortest identifier compared with a password
+const minSimilarityFragmentSize = 3
+
+// PasswordPolicy validates new passwords before they are hashed
+// @Description Checks the length of a password, rejects passwords from a breached-password list
+// and passwords that are too close to the username or email of the account.
+type PasswordPolicy struct {
+	MinLength int // Minimum length in characters; zero disables the check
+	MaxLength int // Maximum length in bytes; zero disables the check (bcrypt only uses 72)
+
+	breached map[[sha1.Size]byte]struct{}
+}
+
+// LoadBreachedPasswords reads a breached-password list into the policy
+// @Description Each line holds either a plain password or the upper- or lower-case SHA-1 hex digest
+// of one, optionally followed by ":<count>" as in the Have I Been Pwned downloads. Blank lines and
+// lines starting with "#" are ignored. Only the digests are kept in memory.
+// @Param path string The path of the list.
+// @Return error An error if the file cannot be read.
+func (p *PasswordPolicy) LoadBreachedPasswords(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	breached := make(map[[sha1.Size]byte]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if digest, ok := parseSHA1Line(line); ok {
+			breached[digest] = struct{}{}
+			continue
+		}
+		breached[sha1.Sum([]byte(line))] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read breached passwords from %s: %w", path, err)
+	}
+
+	p.breached = breached
+	return nil
+}
+
+// BreachedCount returns the number of entries in the breached-password list
+// @Return int The number of loaded entries.
+func (p *PasswordPolicy) BreachedCount() int {
+	return len(p.breached)
+}
+
+// Check validates a new password against the policy
+// @Description Returns the first violation found, in order: length, breached list, similarity.
+// @Param password string The plain-text password.
+// @Param username string The username of the account (may be empty).
+// @Param email string The email of the account (may be empty).
+// @Return error One of the ErrPassword* errors, or nil if the password is acceptable.
+func (p *PasswordPolicy) Check(password, username, email string) error {
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("%w: at least %d characters are required", ErrPasswordTooShort, p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return fmt.Errorf("%w: at most %d bytes are allowed", ErrPasswordTooLong, p.MaxLength)
+	}
+	if _, ok := p.breached[sha1.Sum([]byte(password))]; ok {
+		return ErrPasswordBreached
+	}
+
+	localPart, _, _ := strings.Cut(email, "@")
+	for _, identifier := range []string{username, localPart, email} {
+		if tooSimilar(password, identifier) {
+			return ErrPasswordTooSimilar
+		}
+	}
+	return nil
+}
+
+// parseSHA1Line parses a "<sha1 hex>[:count]" line
+func parseSHA1Line(line string) ([sha1.Size]byte, bool) {
+	var digest [sha1.Size]byte
+	hash, _, _ := strings.Cut(line, ":")
+	if len(hash) != 2*sha1.Size {
+		return digest, false
+	}
+	if _, err := hex.Decode(digest[:], []byte(hash)); err != nil {
+		return digest, false
+	}
+	return digest, true
+}
+
+// tooSimilar reports whether a password contains, is contained in or is a few edits away from an identifier
+func tooSimilar(password, identifier string) bool {
+	password = strings.ToLower(password)
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if utf8.RuneCountInString(identifier) < minSimilarityFragmentSize {
+		return false
+	}
+	if strings.Contains(password, identifier) || strings.Contains(identifier, password) {
+		return true
+	}
+
+	longest := max(utf8.RuneCountInString(password), utf8.RuneCountInString(identifier))
+	return levenshtein(password, identifier) <= longest/4
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
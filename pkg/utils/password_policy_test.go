@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSHA1Line(t *testing.T) {
+	// Setup: SHA-1 of "password"
+	digest := sha1.Sum([]byte("password"))
+
+	tests := []struct {
+		name string
+		line string
+		ok   bool
+	}{
+		{"upper case with count", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493", true},
+		{"lower case without count", "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", true},
+		{"empty count", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:", true},
+		{"too short", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD:12", false},
+		{"too long", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD80:12", false},
+		{"not hex", "ZBAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:12", false},
+		{"plain password", "password", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			parsed, ok := parseSHA1Line(tt.line)
+
+			// Assertions
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, digest, parsed)
+			}
+		})
+	}
+}
+
+func TestLoadBreachedPasswords(t *testing.T) {
+	// Setup: a Have I Been Pwned excerpt mixed with plain passwords
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	list := strings.Join([]string{
+		"# breached passwords",
+		"",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493", // password
+		"7c4a8d09ca3762af61e59520943dc26494f8941b",         // 123456
+		"  letmein-2024  ",
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(list), 0o600))
+	policy := &PasswordPolicy{}
+
+	// Execute
+	err := policy.LoadBreachedPasswords(path)
+
+	// Assertions
+	require.NoError(t, err)
+	assert.Equal(t, 3, policy.BreachedCount())
+	for _, password := range []string{"password", "123456", "letmein-2024"} {
+		assert.ErrorIs(t, policy.Check(password, "", ""), ErrPasswordBreached, password)
+	}
+	assert.NoError(t, policy.Check("correct-horse-battery", "", ""))
+	assert.Error(t, policy.LoadBreachedPasswords(filepath.Join(t.TempDir(), "missing.txt")))
+}
+
+func TestTooSimilar(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		identifier string
+		want       bool
+	}{
+		{"identical", "abcdefgh", "abcdefgh", true},
+		{"identifier contained", "xx-abcdefgh-1", "abcdefgh", true},
+		{"password contained", "bcdef", "abcdefgh", true},
+		{"case insensitive", "ABCDEFGH", "abcdefgh", true},
+		{"identifier trimmed", "abcdefgh", "  abcdefgh ", true},
+		{"8 runes, 2 edits: at the threshold", "abcdefxy", "abcdefgh", true},
+		{"8 runes, 3 edits: over the threshold", "abcdexyz", "abcdefgh", false},
+		{"12 runes, 3 edits: at the threshold", "abcdefghixyz", "abcdefghijkl", true},
+		{"12 runes, 4 edits: over the threshold", "abcdefghwxyz", "abcdefghijkl", false},
+		{"identifier too short", "ab", "ab", false},
+		{"empty identifier", "abcdefgh", "", false},
+		{"unrelated", "correct-horse", "abcdefgh", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute and assert
+			assert.Equal(t, tt.want, tooSimilar(tt.password, tt.identifier))
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"ñandú", "nandu", 2}, // Runes, not bytes
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			// Execute and assert
+			assert.Equal(t, tt.want, levenshtein(tt.a, tt.b))
+			assert.Equal(t, tt.want, levenshtein(tt.b, tt.a))
+		})
+	}
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	// Setup
+	policy := &PasswordPolicy{MinLength: 8, MaxLength: 72}
+
+	tests := []struct {
+		name     string
+		password string
+		want     error
+	}{
+		{"acceptable", "correct-horse-battery", nil},
+		{"too short", "short", ErrPasswordTooShort},
+		{"length counted in characters", "ñandúes!", nil},
+		{"too long in bytes", strings.Repeat("ñ", 37), ErrPasswordTooLong},
+		{"username", "anabelle-2024", ErrPasswordTooSimilar},
+		{"email local part", "xx-ana.lopez", ErrPasswordTooSimilar},
+		{"a few edits from the email", "anna.lopes@example.com", ErrPasswordTooSimilar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			err := policy.Check(tt.password, "anabelle", "ana.lopez@example.com")
+
+			// Assertions
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
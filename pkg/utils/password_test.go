@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestArgon2id returns an Argon2id hasher with small parameters, to keep the tests fast
+func newTestArgon2id() *Argon2idPasswordUtils {
+	return &Argon2idPasswordUtils{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+func TestArgon2id_RoundTrip(t *testing.T) {
+	// Setup
+	hasher := newTestArgon2id()
+
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{"ascii", "s3cret-pass"},
+		{"empty", ""},
+		{"unicode", "contraseña-ñandú"},
+		{"longer than bcrypt accepts", strings.Repeat("a", 200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			hash, err := hasher.HashPassword(tt.password)
+			require.NoError(t, err)
+			other, err := hasher.HashPassword(tt.password)
+			require.NoError(t, err)
+
+			// Assertions: a PHC string with the parameters and a random salt
+			assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+			assert.NotEqual(t, hash, other)
+			assert.NoError(t, hasher.CheckPassword(hash, tt.password))
+			assert.ErrorIs(t, hasher.CheckPassword(hash, tt.password+"x"), ErrPasswordMismatch)
+			assert.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestCheckPassword_Bcrypt(t *testing.T) {
+	// Setup: a hash stored before the switch to Argon2id
+	legacy, err := bcrypt.GenerateFromPassword([]byte("s3cret-pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+	argon2id, err := newTestArgon2id().HashPassword("s3cret-pass")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		hasher PasswordUtils
+		hash   string
+	}{
+		{"bcrypt hash with the bcrypt hasher", &DefaultPasswordUtils{Cost: bcrypt.MinCost}, string(legacy)},
+		{"bcrypt hash with the Argon2id hasher", newTestArgon2id(), string(legacy)},
+		{"Argon2id hash with the bcrypt hasher", &DefaultPasswordUtils{Cost: bcrypt.MinCost}, argon2id},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute and assert: every hasher checks both formats
+			assert.NoError(t, tt.hasher.CheckPassword(tt.hash, "s3cret-pass"))
+			assert.ErrorIs(t, tt.hasher.CheckPassword(tt.hash, "wrong-pass"), ErrPasswordMismatch)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	// Setup
+	current := newTestArgon2id()
+	argon2id, err := current.HashPassword("s3cret-pass")
+	require.NoError(t, err)
+	bcryptHash, err := (&DefaultPasswordUtils{Cost: bcrypt.MinCost}).HashPassword("s3cret-pass")
+	require.NoError(t, err)
+	with := func(edit func(*Argon2idPasswordUtils)) *Argon2idPasswordUtils {
+		hasher := newTestArgon2id()
+		edit(hasher)
+		return hasher
+	}
+
+	tests := []struct {
+		name   string
+		hasher PasswordUtils
+		hash   string
+		want   bool
+	}{
+		{"same Argon2id parameters", current, argon2id, false},
+		{"Argon2id memory changed", with(func(h *Argon2idPasswordUtils) { h.Memory = 128 }), argon2id, true},
+		{"Argon2id passes changed", with(func(h *Argon2idPasswordUtils) { h.Iterations = 2 }), argon2id, true},
+		{"Argon2id lanes changed", with(func(h *Argon2idPasswordUtils) { h.Parallelism = 2 }), argon2id, true},
+		{"Argon2id key length changed", with(func(h *Argon2idPasswordUtils) { h.KeyLength = 64 }), argon2id, true},
+		{"Argon2id salt length changed", with(func(h *Argon2idPasswordUtils) { h.SaltLength = 32 }), argon2id, false},
+		{"bcrypt hash with the Argon2id hasher", current, bcryptHash, true},
+		{"same bcrypt cost", &DefaultPasswordUtils{Cost: bcrypt.MinCost}, bcryptHash, false},
+		{"bcrypt cost changed", &DefaultPasswordUtils{Cost: bcrypt.MinCost + 1}, bcryptHash, true},
+		{"bcrypt default cost", &DefaultPasswordUtils{}, bcryptHash, true},
+		{"Argon2id hash with the bcrypt hasher", &DefaultPasswordUtils{Cost: bcrypt.MinCost}, argon2id, true},
+		{"malformed hash", current, "$argon2id$v=19$m=64", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute and assert
+			assert.Equal(t, tt.want, tt.hasher.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestCheckPassword_MalformedPHC(t *testing.T) {
+	// Setup
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"missing key", "$argon2id$v=19$m=64,t=1,p=1$" + salt},
+		{"extra segment", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key + "$x"},
+		{"unsupported version", "$argon2id$v=16$m=64,t=1,p=1$" + salt + "$" + key},
+		{"missing version", "$argon2id$m=64,t=1,p=1$" + salt + "$" + key + "$"},
+		{"invalid parameters", "$argon2id$v=19$m=x,t=1,p=1$" + salt + "$" + key},
+		{"invalid salt", "$argon2id$v=19$m=64,t=1,p=1$!!!$" + key},
+		{"invalid key", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$!!!"},
+		{"empty key", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			_, _, _, decodeErr := decodeArgon2id(tt.hash)
+			checkErr := newTestArgon2id().CheckPassword(tt.hash, "s3cret-pass")
+
+			// Assertions: rejected as malformed, not as a wrong password
+			assert.Error(t, decodeErr)
+			assert.Error(t, checkErr)
+			assert.NotErrorIs(t, checkErr, ErrPasswordMismatch)
+		})
+	}
+
+	// The same segments, well formed, only fail as a wrong password
+	assert.ErrorIs(t, newTestArgon2id().CheckPassword("$argon2id$v=19$m=64,t=1,p=1$"+salt+"$"+key, "s3cret-pass"), ErrPasswordMismatch)
+}